@@ -0,0 +1,261 @@
+package tgbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+)
+
+// AckMode defines when an update is acknowledged, i.e. will not be delivered again.
+type AckMode int
+
+const (
+	// AckAfterHandling acknowledges the update when the handler returns: at-least-once, an
+	// update is handled again if the process crashes while handling it
+	AckAfterHandling AckMode = iota
+	// AckBeforeHandling acknowledges the update before calling the handler: at-most-once, an
+	// update is lost if the process crashes while handling it
+	AckBeforeHandling
+)
+
+// DefaultAckWindow is the number of the recently handled update ids remembered for deduplication.
+const DefaultAckWindow = 1000
+
+// AckState is the durable part of the update processing.
+type AckState struct {
+	// Offset for the next getUpdates call: the last acknowledged update id + 1
+	Offset int64 `json:"offset"`
+	// Handled are the recently acknowledged update ids, smallest first
+	Handled []int64 `json:"handled,omitempty"`
+	// LowWater is the low-water mark: the update ids below it that are not in Handled were
+	// acknowledged and dropped from the window
+	LowWater int64 `json:"low_water,omitempty"`
+}
+
+// AckStore keeps the AckState between the restarts.
+type AckStore interface {
+	// LoadAcks returns the saved state, empty state if nothing is saved.
+	LoadAcks(ctx context.Context) (*AckState, error)
+	SaveAcks(ctx context.Context, state *AckState) error
+}
+
+type memoryAckStore struct {
+	mu    sync.Mutex
+	state AckState
+}
+
+// NewMemoryAckStore returns an AckStore that forgets everything on restart.
+func NewMemoryAckStore() AckStore {
+	return &memoryAckStore{}
+}
+
+func (s *memoryAckStore) LoadAcks(context.Context) (*AckState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state := s.state
+	state.Handled = append([]int64{}, s.state.Handled...)
+	return &state, nil
+}
+
+func (s *memoryAckStore) SaveAcks(_ context.Context, state *AckState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.state = *state
+	s.state.Handled = append([]int64{}, state.Handled...)
+	return nil
+}
+
+type fileAckStore struct {
+	path string
+}
+
+// NewFileAckStore keeps the state in a json file, which is replaced atomically and synced to the
+// disk on every save.
+func NewFileAckStore(path string) AckStore {
+	return &fileAckStore{path: path}
+}
+
+func (s *fileAckStore) LoadAcks(context.Context) (*AckState, error) {
+	state := &AckState{}
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read acks: %s", err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("Cannot parse acks file %s: %s", s.path, err)
+	}
+	return state, nil
+}
+
+func (s *fileAckStore) SaveAcks(_ context.Context, state *AckState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomically(s.path, data); err != nil {
+		return fmt.Errorf("Cannot save acks: %s", err)
+	}
+	return nil
+}
+
+type sessionAckStore struct {
+	store SessionStore
+	key   string
+}
+
+// NewSessionAckStore keeps the state in the session store under the key, e.g. in a database
+// with SQLSessionStore.
+func NewSessionAckStore(store SessionStore, key string) AckStore {
+	return &sessionAckStore{store: store, key: key}
+}
+
+func (s *sessionAckStore) LoadAcks(ctx context.Context) (*AckState, error) {
+	state, ok, err := GetSession[*AckState](ctx, s.store, s.key)
+	if err != nil || !ok || state == nil {
+		return &AckState{}, err
+	}
+	return state, nil
+}
+
+func (s *sessionAckStore) SaveAcks(ctx context.Context, state *AckState) error {
+	return SetSession(ctx, s.store, s.key, state, 0)
+}
+
+// UpdateAcker acknowledges the handled updates in the store and skips the updates that were
+// already handled, e.g. webhook retries or updates received again after a restart.
+type UpdateAcker struct {
+	store  AckStore
+	mode   AckMode
+	window int
+
+	mu      sync.Mutex
+	state   *AckState
+	handled map[int64]bool
+	// inFlight are the updates being handled, e.g. a webhook retry of the update that is not
+	// acknowledged yet is skipped
+	inFlight map[int64]bool
+}
+
+// NewUpdateAcker loads the state from the store, window is the number of the remembered update
+// ids, DefaultAckWindow if <= 0.
+func NewUpdateAcker(ctx context.Context, store AckStore, mode AckMode, window int) (*UpdateAcker, error) {
+	if window <= 0 {
+		window = DefaultAckWindow
+	}
+	state, err := store.LoadAcks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	acker := &UpdateAcker{
+		store:    store,
+		mode:     mode,
+		window:   window,
+		state:    state,
+		handled:  map[int64]bool{},
+		inFlight: map[int64]bool{},
+	}
+	sort.Slice(state.Handled, func(i, j int) bool { return state.Handled[i] < state.Handled[j] })
+	for _, id := range state.Handled {
+		acker.handled[id] = true
+	}
+	return acker, nil
+}
+
+// Offset returns the offset for the next getUpdates call.
+func (a *UpdateAcker) Offset() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.state.Offset
+}
+
+// IsHandled reports if the update was acknowledged: it is in the window or below the low-water
+// mark. The window must be larger than the number of the updates handled in parallel.
+func (a *UpdateAcker) IsHandled(updateID int64) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.isHandled(updateID)
+}
+
+// isHandled must be called with the lock held.
+func (a *UpdateAcker) isHandled(updateID int64) bool {
+	return a.handled[updateID] || updateID < a.state.LowWater
+}
+
+// start marks the update as being handled, returns false if it is handled or being handled.
+func (a *UpdateAcker) start(updateID int64) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.isHandled(updateID) || a.inFlight[updateID] {
+		return false
+	}
+	a.inFlight[updateID] = true
+	return true
+}
+
+// finish forgets the update that was being handled.
+func (a *UpdateAcker) finish(updateID int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.inFlight, updateID)
+}
+
+// Ack saves the update as handled.
+func (a *UpdateAcker) Ack(ctx context.Context, updateID int64) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.isHandled(updateID) {
+		return nil
+	}
+	a.handled[updateID] = true
+	i := sort.Search(len(a.state.Handled), func(i int) bool { return a.state.Handled[i] > updateID })
+	a.state.Handled = append(a.state.Handled, 0)
+	copy(a.state.Handled[i+1:], a.state.Handled[i:])
+	a.state.Handled[i] = updateID
+	// The smallest ids leave the window, everything below them is treated as handled
+	for len(a.state.Handled) > a.window {
+		delete(a.handled, a.state.Handled[0])
+		a.state.LowWater = a.state.Handled[0] + 1
+		a.state.Handled = a.state.Handled[1:]
+	}
+	if updateID >= a.state.Offset {
+		a.state.Offset = updateID + 1
+	}
+	if err := a.store.SaveAcks(ctx, a.state); err != nil {
+		return fmt.Errorf("Cannot acknowledge update %d: %s", updateID, err)
+	}
+	return nil
+}
+
+// Handle calls the handler unless the update was already handled or is being handled and
+// acknowledges the update according to the mode. Returns false if the update was skipped.
+func (a *UpdateAcker) Handle(ctx context.Context, update *Update, handler UpdateHandler) (bool, error) {
+	return a.handle(ctx, update, func(ctx context.Context, update *Update) error {
+		handler(ctx, update)
+		return nil
+	})
+}
+
+// handle is Handle for a handler that can fail, the failed update is not acknowledged in the
+// AckAfterHandling mode.
+func (a *UpdateAcker) handle(ctx context.Context, update *Update, handler func(context.Context, *Update) error) (bool, error) {
+	if !a.start(update.UpdateID) {
+		return false, nil
+	}
+	defer a.finish(update.UpdateID)
+	if a.mode == AckBeforeHandling {
+		if err := a.Ack(ctx, update.UpdateID); err != nil {
+			return false, err
+		}
+		return true, handler(ctx, update)
+	}
+	if err := handler(ctx, update); err != nil {
+		return true, err
+	}
+	return true, a.Ack(ctx, update.UpdateID)
+}
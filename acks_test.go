@@ -0,0 +1,145 @@
+package tgbot_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/lanseg/tgbot"
+)
+
+func newAcker(t *testing.T, store tgbot.AckStore, mode tgbot.AckMode, window int) *tgbot.UpdateAcker {
+	t.Helper()
+	acker, err := tgbot.NewUpdateAcker(context.Background(), store, mode, window)
+	if err != nil {
+		t.Fatalf("Cannot create acker: %s", err)
+	}
+	return acker
+}
+
+func ack(t *testing.T, acker *tgbot.UpdateAcker, ids ...int64) {
+	t.Helper()
+	for _, id := range ids {
+		if err := acker.Ack(context.Background(), id); err != nil {
+			t.Fatalf("Ack(%d) failed: %s", id, err)
+		}
+	}
+}
+
+func handledIDs(acker *tgbot.UpdateAcker, upTo int64) []int64 {
+	result := []int64{}
+	for id := int64(1); id <= upTo; id++ {
+		if acker.IsHandled(id) {
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func TestAckerLowWaterMark(t *testing.T) {
+	store := tgbot.NewMemoryAckStore()
+	acker := newAcker(t, store, tgbot.AckAfterHandling, 2)
+
+	// Acknowledged out of order, 4 is still being handled while the window is full.
+	ack(t, acker, 5, 3)
+	if got := handledIDs(acker, 8); !reflect.DeepEqual(got, []int64{3, 5}) {
+		t.Errorf("Handled ids %v, want [3 5]", got)
+	}
+	ack(t, acker, 4, 6)
+	if got := handledIDs(acker, 8); !reflect.DeepEqual(got, []int64{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("Handled ids %v, want everything up to 6", got)
+	}
+	if acker.Offset() != 7 {
+		t.Errorf("Offset() = %d, want 7", acker.Offset())
+	}
+
+	reloaded := newAcker(t, store, tgbot.AckAfterHandling, 2)
+	if got := handledIDs(reloaded, 8); !reflect.DeepEqual(got, []int64{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("Reloaded acker has handled ids %v", got)
+	}
+	state, _ := store.LoadAcks(context.Background())
+	if state.LowWater != 5 || !reflect.DeepEqual(state.Handled, []int64{5, 6}) {
+		t.Errorf("Saved state %+v", state)
+	}
+}
+
+func TestAckerSkipsUpdatesInFlight(t *testing.T) {
+	acker := newAcker(t, tgbot.NewMemoryAckStore(), tgbot.AckAfterHandling, 0)
+	started := make(chan struct{})
+	release := make(chan struct{})
+	calls := 0
+	handler := func(context.Context, *tgbot.Update) {
+		calls++
+		close(started)
+		<-release
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if handled, err := acker.Handle(context.Background(), &tgbot.Update{UpdateID: 1}, handler); !handled || err != nil {
+			t.Errorf("Handle = %v, %v", handled, err)
+		}
+	}()
+	<-started
+	if handled, err := acker.Handle(context.Background(), &tgbot.Update{UpdateID: 1}, handler); handled || err != nil {
+		t.Errorf("Update in flight was handled again: %v, %v", handled, err)
+	}
+	close(release)
+	wg.Wait()
+
+	if handled, _ := acker.Handle(context.Background(), &tgbot.Update{UpdateID: 1}, handler); handled || calls != 1 {
+		t.Errorf("Acknowledged update was handled again, %d calls", calls)
+	}
+}
+
+func TestAckModes(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		mode        tgbot.AckMode
+		ackedInside bool
+	}{
+		{name: "after handling", mode: tgbot.AckAfterHandling},
+		{name: "before handling", mode: tgbot.AckBeforeHandling, ackedInside: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			acker := newAcker(t, tgbot.NewMemoryAckStore(), tc.mode, 0)
+			ackedInside := false
+			acker.Handle(context.Background(), &tgbot.Update{UpdateID: 7}, func(context.Context, *tgbot.Update) {
+				ackedInside = acker.IsHandled(7)
+			})
+			if ackedInside != tc.ackedInside || !acker.IsHandled(7) {
+				t.Errorf("Acknowledged inside the handler: %v, after: %v", ackedInside, acker.IsHandled(7))
+			}
+		})
+	}
+}
+
+func TestFileAckStore(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "acks.json")
+	store := tgbot.NewFileAckStore(path)
+	if state, err := store.LoadAcks(context.Background()); err != nil || state.Offset != 0 {
+		t.Fatalf("LoadAcks of a missing file = %+v, %v", state, err)
+	}
+
+	want := &tgbot.AckState{Offset: 11, Handled: []int64{9, 10}, LowWater: 9}
+	if err := store.SaveAcks(context.Background(), want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := tgbot.NewFileAckStore(path).LoadAcks(context.Background())
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("LoadAcks = %+v, %v, want %+v", got, err, want)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("Temporary files are left: %v", entries)
+	}
+
+	if err := tgbot.NewFileAckStore(filepath.Join(dir, "missing", "acks.json")).SaveAcks(context.Background(), want); err == nil {
+		t.Errorf("SaveAcks to a missing directory succeeded")
+	}
+}
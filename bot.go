@@ -2,11 +2,13 @@ package tgbot
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 type Response[T any] struct {
@@ -22,43 +24,111 @@ type TelegramBot interface {
 	Query(methodName string, body interface{}) ([]byte, error)
 }
 
+// ContextBot is a TelegramBot that supports cancellation and passes the context to the
+// interceptors.
+type ContextBot interface {
+	TelegramBot
+	QueryContext(ctx context.Context, methodName string, body interface{}) ([]byte, error)
+}
+
+func queryContext(ctx context.Context, b TelegramBot, apiMethod string, body interface{}) ([]byte, error) {
+	if contextBot, ok := b.(ContextBot); ok {
+		return contextBot.QueryContext(ctx, apiMethod, body)
+	}
+	return b.Query(apiMethod, body)
+}
+
 type TelegramBotImpl struct {
 	TelegramBot
 
 	httpClient *http.Client
-	server     *url.URL
-	token      string
+	// ownTransport is the transport of httpClient cloned for the options to change
+	ownTransport *http.Transport
+	server       *url.URL
+	token        Token
+	local        bool
+	timeout      time.Duration
+	userAgent    string
+
+	interceptors []Interceptor
+	query        QueryFunc
 }
 
-func NewCustomBot(server string, token string) (TelegramBot, error) {
+// NewCustomBot creates a bot for the Bot API server at the url, the token is checked with
+// ParseToken.
+func NewCustomBot(server string, token string, options ...BotOption) (TelegramBot, error) {
+	parsed, err := ParseToken(token)
+	if err != nil {
+		return nil, err
+	}
 	url, err := url.Parse(server)
 	if err != nil {
 		return nil, err
 	}
-	return &TelegramBotImpl{
-		token:      token,
+	bot := &TelegramBotImpl{
+		token:      parsed,
 		server:     url,
 		httpClient: &http.Client{},
-	}, nil
+	}
+	for _, option := range options {
+		if err := option(bot); err != nil {
+			return nil, err
+		}
+	}
+	bot.query = Chain(bot.doQuery, bot.interceptors...)
+	return bot, nil
+}
 
+func NewBot(token string, options ...BotOption) (TelegramBot, error) {
+	return NewCustomBot("https://api.telegram.org", token, options...)
 }
 
-func NewBot(token string) (TelegramBot, error) {
-	return NewCustomBot("https://api.telegram.org", token)
+func (b *TelegramBotImpl) queryTimeout(body interface{}) time.Duration {
+	if b.timeout <= 0 {
+		return 0
+	}
+	if updates, ok := body.(*GetUpdatesRequest); ok {
+		return b.timeout + updates.TimeoutAsDuration()
+	}
+	return b.timeout
 }
 
 func (b *TelegramBotImpl) Query(apiMethod string, body interface{}) ([]byte, error) {
+	return b.QueryContext(context.Background(), apiMethod, body)
+}
+
+func (b *TelegramBotImpl) QueryContext(ctx context.Context, apiMethod string, body interface{}) ([]byte, error) {
+	if err := b.checkLimits(body); err != nil {
+		return nil, err
+	}
+	if timeout := b.queryTimeout(body); timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return b.query(ctx, apiMethod, body)
+}
+
+func (b *TelegramBotImpl) doQuery(ctx context.Context, apiMethod string, body interface{}) ([]byte, error) {
+	response, err := b.doHTTPQuery(ctx, apiMethod, body)
+	return response, redactError(err, string(b.token))
+}
+
+func (b *TelegramBotImpl) doHTTPQuery(ctx context.Context, apiMethod string, body interface{}) ([]byte, error) {
 	jsonBody, err := json.Marshal(body)
 	if err != nil {
 		return nil, err
 	}
 
-	methodURL := b.server.JoinPath("bot"+b.token, apiMethod)
-	request, err := http.NewRequest("POST", methodURL.String(), bytes.NewBuffer(jsonBody))
+	methodURL := b.server.JoinPath("bot"+string(b.token), apiMethod)
+	request, err := http.NewRequestWithContext(ctx, "POST", methodURL.String(), bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return nil, err
 	}
 	request.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	if b.userAgent != "" {
+		request.Header.Set("User-Agent", b.userAgent)
+	}
 	resp, err := b.httpClient.Do(request)
 	if err != nil {
 		return nil, err
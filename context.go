@@ -0,0 +1,46 @@
+package tgbot
+
+import (
+	"context"
+	"fmt"
+)
+
+type contextBot struct {
+	bot TelegramBot
+	ctx context.Context
+}
+
+func (b *contextBot) Query(apiMethod string, body interface{}) ([]byte, error) {
+	return queryContext(b.ctx, b.bot, apiMethod, body)
+}
+
+func (b *contextBot) QueryContext(ctx context.Context, apiMethod string, body interface{}) ([]byte, error) {
+	return queryContext(ctx, b.bot, apiMethod, body)
+}
+
+func (b *contextBot) FetchFile(ctx context.Context, filePath string, offset int64) (*FileContent, error) {
+	fetcher, ok := b.bot.(FileFetcher)
+	if !ok {
+		return nil, fmt.Errorf("Bot %T cannot download files", b.bot)
+	}
+	return fetcher.FetchFile(ctx, filePath, offset)
+}
+
+func (b *contextBot) IsLocal() bool {
+	return isLocalBot(b.bot)
+}
+
+// WithContext returns a copy of the api that makes all calls with the context: calls are
+// cancelled with it and interceptors see its values, e.g. the tracing span of the update. The
+// result is a *TelegramApi.
+func (a *TelegramApi) WithContext(ctx context.Context) TelegramApiInterface {
+	return a.withContext(ctx)
+}
+
+func (a *TelegramApi) withContext(ctx context.Context) *TelegramApi {
+	bot := a.bot
+	if withContext, ok := bot.(*contextBot); ok {
+		bot = withContext.bot
+	}
+	return NewTelegramApi(&contextBot{bot: bot, ctx: ctx})
+}
@@ -0,0 +1,325 @@
+package tgbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// ConversationEnd is returned by a step to finish the conversation.
+const ConversationEnd = ""
+
+// ConversationKey identifies a conversation: a user talking to the bot in a chat.
+type ConversationKey struct {
+	ChatID int64 `json:"chat_id"`
+	UserID int64 `json:"user_id"`
+}
+
+// ConversationState is the stored part of a conversation.
+type ConversationState struct {
+	Key     ConversationKey   `json:"key"`
+	Step    string            `json:"step"`
+	Data    map[string]string `json:"data,omitempty"`
+	Updated time.Time         `json:"updated"`
+}
+
+// ConversationStore keeps the states of the active conversations, e.g. in a database so the
+// conversations survive restarts.
+type ConversationStore interface {
+	// Get returns the state of the conversation, nil without error if there is none.
+	Get(ctx context.Context, key ConversationKey) (*ConversationState, error)
+	Set(ctx context.Context, state *ConversationState) error
+	Delete(ctx context.Context, key ConversationKey) error
+	// Expire removes and returns the conversations last updated before the given time.
+	Expire(ctx context.Context, before time.Time) ([]*ConversationState, error)
+}
+
+type memoryConversationStore struct {
+	mu     sync.Mutex
+	states map[ConversationKey]ConversationState
+}
+
+// NewMemoryConversationStore returns a ConversationStore that keeps the conversations in memory.
+func NewMemoryConversationStore() ConversationStore {
+	return &memoryConversationStore{
+		states: map[ConversationKey]ConversationState{},
+	}
+}
+
+func copyState(state ConversationState) *ConversationState {
+	data := make(map[string]string, len(state.Data))
+	for k, v := range state.Data {
+		data[k] = v
+	}
+	state.Data = data
+	return &state
+}
+
+func (s *memoryConversationStore) Get(_ context.Context, key ConversationKey) (*ConversationState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[key]
+	if !ok {
+		return nil, nil
+	}
+	return copyState(state), nil
+}
+
+func (s *memoryConversationStore) Set(_ context.Context, state *ConversationState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[state.Key] = *copyState(*state)
+	return nil
+}
+
+func (s *memoryConversationStore) Delete(_ context.Context, key ConversationKey) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, key)
+	return nil
+}
+
+func (s *memoryConversationStore) Expire(_ context.Context, before time.Time) ([]*ConversationState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	expired := []*ConversationState{}
+	for key, state := range s.states {
+		if state.Updated.Before(before) {
+			expired = append(expired, copyState(state))
+			delete(s.states, key)
+		}
+	}
+	return expired, nil
+}
+
+// Conversation is passed to the steps: where the conversation is and what it has collected.
+type Conversation struct {
+	Key  ConversationKey
+	Step string
+	// Data collected by the steps, saved with the conversation
+	Data map[string]string
+}
+
+// InvalidInputError keeps the conversation at the same step and makes it ask again.
+type InvalidInputError struct {
+	Message string
+}
+
+func (e *InvalidInputError) Error() string {
+	return e.Message
+}
+
+// InvalidInput is returned by a step to reject the input, the message explains why.
+func InvalidInput(message string) error {
+	return &InvalidInputError{Message: message}
+}
+
+// Step is a state of the conversation.
+type Step struct {
+	// Prompt is called when the conversation enters the step, e.g. to ask a question, and when
+	// Handle rejects the input, then invalid is the InvalidInputError.
+	Prompt func(ctx context.Context, conv *Conversation, invalid error) error
+	// Handle processes the user input and returns the next step: the same step to wait for
+	// more input, ConversationEnd to finish the conversation.
+	Handle func(ctx context.Context, conv *Conversation, update *Update) (string, error)
+}
+
+type conversationEntry struct {
+	match func(update *Update) bool
+	step  string
+}
+
+// Conversations is a finite state machine of multi-step flows, e.g. registration forms, with a
+// separate conversation for each user in each chat. Updates of the same conversation should be
+// handled in order.
+//
+//	conv := tgbot.NewConversations(tgbot.NewMemoryConversationStore())
+//	conv.Entry("/register", "name")
+//	conv.Step("name", &tgbot.Step{Prompt: askName, Handle: saveName})
+//	conv.Step("age", &tgbot.Step{Prompt: askAge, Handle: saveAge})
+//	handler := conv.Handler(otherUpdates)
+type Conversations struct {
+	store   ConversationStore
+	entries []conversationEntry
+	steps   map[string]*Step
+
+	// Timeout expires conversations without updates, zero to keep them forever
+	Timeout time.Duration
+	// CancelCommand finishes any conversation, "/cancel" by default
+	CancelCommand string
+	// OnCancel and OnTimeout are called when the conversation is cancelled or expired
+	OnCancel  func(ctx context.Context, conv *Conversation)
+	OnTimeout func(ctx context.Context, conv *Conversation)
+	// OnError is called when a step fails, by default the error is logged
+	OnError func(ctx context.Context, conv *Conversation, err error)
+	// Now returns the current time, for tests
+	Now func() time.Time
+}
+
+func NewConversations(store ConversationStore) *Conversations {
+	return &Conversations{
+		store:         store,
+		steps:         map[string]*Step{},
+		CancelCommand: "/cancel",
+		OnError: func(ctx context.Context, conv *Conversation, err error) {
+			slog.ErrorContext(ctx, "Conversation step failed",
+				slog.String("step", conv.Step), slog.String("error", err.Error()))
+		},
+		Now: time.Now,
+	}
+}
+
+// Step declares a state of the conversation.
+func (c *Conversations) Step(name string, step *Step) {
+	c.steps[name] = step
+}
+
+// Entry starts a conversation at the step when the user sends the command, e.g. "/register".
+func (c *Conversations) Entry(command string, step string) {
+	c.EntryFunc(func(update *Update) bool {
+		return update.Message != nil && commandName(update.Message.Text) == command
+	}, step)
+}
+
+// EntryFunc starts a conversation at the step for the matching updates.
+func (c *Conversations) EntryFunc(match func(update *Update) bool, step string) {
+	c.entries = append(c.entries, conversationEntry{match: match, step: step})
+}
+
+func toConversation(state *ConversationState) *Conversation {
+	return &Conversation{Key: state.Key, Step: state.Step, Data: state.Data}
+}
+
+// Handler returns the UpdateHandler that runs the conversations, updates that are not part of
+// any conversation are passed to the fallback, which can be nil.
+func (c *Conversations) Handler(fallback UpdateHandler) UpdateHandler {
+	return func(ctx context.Context, update *Update) {
+		handled, err := c.handle(ctx, update)
+		if err != nil {
+			key := ConversationKey{ChatID: updateChatID(update), UserID: updateUserID(update)}
+			c.OnError(ctx, &Conversation{Key: key}, err)
+		}
+		if !handled && fallback != nil {
+			fallback(ctx, update)
+		}
+	}
+}
+
+func (c *Conversations) handle(ctx context.Context, update *Update) (bool, error) {
+	key := ConversationKey{ChatID: updateChatID(update), UserID: updateUserID(update)}
+	if key.ChatID == 0 && key.UserID == 0 {
+		return false, nil
+	}
+	state, err := c.store.Get(ctx, key)
+	if err != nil {
+		return false, fmt.Errorf("Cannot load conversation: %s", err)
+	}
+	if state != nil && c.Timeout > 0 && c.Now().Sub(state.Updated) > c.Timeout {
+		if err := c.store.Delete(ctx, key); err != nil {
+			return false, fmt.Errorf("Cannot expire conversation: %s", err)
+		}
+		if c.OnTimeout != nil {
+			c.OnTimeout(ctx, toConversation(state))
+		}
+		state = nil
+	}
+
+	if state == nil {
+		for _, entry := range c.entries {
+			if entry.match(update) {
+				conv := &Conversation{Key: key, Data: map[string]string{}}
+				return true, c.enter(ctx, conv, entry.step)
+			}
+		}
+		return false, nil
+	}
+
+	conv := toConversation(state)
+	if update.Message != nil && commandName(update.Message.Text) == c.CancelCommand {
+		if err := c.store.Delete(ctx, key); err != nil {
+			return true, fmt.Errorf("Cannot cancel conversation: %s", err)
+		}
+		if c.OnCancel != nil {
+			c.OnCancel(ctx, conv)
+		}
+		return true, nil
+	}
+
+	step, ok := c.steps[conv.Step]
+	if !ok {
+		if err := c.store.Delete(ctx, key); err != nil {
+			return true, fmt.Errorf("Cannot drop conversation at unknown step %q: %s", conv.Step, err)
+		}
+		return true, fmt.Errorf("Unknown conversation step %q", conv.Step)
+	}
+	next, err := step.Handle(ctx, conv, update)
+	invalid := &InvalidInputError{}
+	if errors.As(err, &invalid) {
+		if step.Prompt != nil {
+			if err := step.Prompt(ctx, conv, invalid); err != nil {
+				return true, err
+			}
+		}
+		return true, c.save(ctx, conv)
+	}
+	if err != nil {
+		return true, err
+	}
+	if next == ConversationEnd {
+		return true, c.store.Delete(ctx, key)
+	}
+	if next == conv.Step {
+		return true, c.save(ctx, conv)
+	}
+	return true, c.enter(ctx, conv, next)
+}
+
+// enter moves the conversation to the step and prompts for its input.
+func (c *Conversations) enter(ctx context.Context, conv *Conversation, stepName string) error {
+	step, ok := c.steps[stepName]
+	if !ok {
+		return fmt.Errorf("Unknown conversation step %q", stepName)
+	}
+	conv.Step = stepName
+	if err := c.save(ctx, conv); err != nil {
+		return err
+	}
+	if step.Prompt != nil {
+		return step.Prompt(ctx, conv, nil)
+	}
+	return nil
+}
+
+func (c *Conversations) save(ctx context.Context, conv *Conversation) error {
+	err := c.store.Set(ctx, &ConversationState{
+		Key:     conv.Key,
+		Step:    conv.Step,
+		Data:    conv.Data,
+		Updated: c.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("Cannot save conversation: %s", err)
+	}
+	return nil
+}
+
+// ExpireStale removes the conversations older than Timeout and calls OnTimeout for them, the
+// handler expires them too, but only when the user writes again.
+func (c *Conversations) ExpireStale(ctx context.Context) error {
+	if c.Timeout <= 0 {
+		return nil
+	}
+	expired, err := c.store.Expire(ctx, c.Now().Add(-c.Timeout))
+	if err != nil {
+		return fmt.Errorf("Cannot expire conversations: %s", err)
+	}
+	if c.OnTimeout != nil {
+		for _, state := range expired {
+			c.OnTimeout(ctx, toConversation(state))
+		}
+	}
+	return nil
+}
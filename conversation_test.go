@@ -0,0 +1,183 @@
+package tgbot_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lanseg/tgbot"
+)
+
+var convKey = tgbot.ConversationKey{ChatID: 10, UserID: 20}
+
+func textUpdate(text string) *tgbot.Update {
+	return &tgbot.Update{Message: &tgbot.Message{
+		Chat: &tgbot.Chat{ID: convKey.ChatID, Type: "private"},
+		From: &tgbot.User{ID: convKey.UserID},
+		Text: text,
+	}}
+}
+
+// failingDeleteStore is a memory store that cannot delete the conversations.
+type failingDeleteStore struct {
+	tgbot.ConversationStore
+}
+
+func (s *failingDeleteStore) Delete(context.Context, tgbot.ConversationKey) error {
+	return errors.New("store is read only")
+}
+
+// registration asks for the name and the age, the prompts are recorded in the order they are sent.
+func registration(store tgbot.ConversationStore, prompts *[]string, errs *[]error) *tgbot.Conversations {
+	conv := tgbot.NewConversations(store)
+	conv.Entry("/register", "name")
+	conv.Step("name", &tgbot.Step{
+		Prompt: func(_ context.Context, _ *tgbot.Conversation, _ error) error {
+			*prompts = append(*prompts, "name?")
+			return nil
+		},
+		Handle: func(_ context.Context, c *tgbot.Conversation, update *tgbot.Update) (string, error) {
+			c.Data["name"] = update.Message.Text
+			return "age", nil
+		},
+	})
+	conv.Step("age", &tgbot.Step{
+		Prompt: func(_ context.Context, _ *tgbot.Conversation, invalid error) error {
+			if invalid != nil {
+				*prompts = append(*prompts, invalid.Error())
+			} else {
+				*prompts = append(*prompts, "age?")
+			}
+			return nil
+		},
+		Handle: func(_ context.Context, c *tgbot.Conversation, update *tgbot.Update) (string, error) {
+			if _, err := strconv.Atoi(update.Message.Text); err != nil {
+				return "", tgbot.InvalidInput("not a number")
+			}
+			c.Data["age"] = update.Message.Text
+			*prompts = append(*prompts, "done "+c.Data["name"]+" "+c.Data["age"])
+			return tgbot.ConversationEnd, nil
+		},
+	})
+	conv.OnError = func(_ context.Context, _ *tgbot.Conversation, err error) {
+		*errs = append(*errs, err)
+	}
+	return conv
+}
+
+func TestConversationFlow(t *testing.T) {
+	store := tgbot.NewMemoryConversationStore()
+	var prompts []string
+	var errs []error
+	var fallback []string
+	handler := registration(store, &prompts, &errs).Handler(func(_ context.Context, update *tgbot.Update) {
+		fallback = append(fallback, update.Message.Text)
+	})
+
+	for _, text := range []string{"hello", "/register", "Alice", "thirty", "30", "bye"} {
+		handler(context.Background(), textUpdate(text))
+	}
+
+	want := []string{"name?", "age?", "not a number", "done Alice 30"}
+	if strings.Join(prompts, "|") != strings.Join(want, "|") {
+		t.Errorf("Got prompts %q, want %q", prompts, want)
+	}
+	if strings.Join(fallback, "|") != "hello|bye" {
+		t.Errorf("Fallback got %q, want the updates outside of the conversation", fallback)
+	}
+	if len(errs) != 0 {
+		t.Errorf("Got errors: %v", errs)
+	}
+	if state, _ := store.Get(context.Background(), convKey); state != nil {
+		t.Errorf("Finished conversation is still stored: %+v", state)
+	}
+}
+
+func TestConversationCancel(t *testing.T) {
+	store := tgbot.NewMemoryConversationStore()
+	var prompts []string
+	var errs []error
+	conv := registration(store, &prompts, &errs)
+	cancelled := ""
+	conv.OnCancel = func(_ context.Context, c *tgbot.Conversation) {
+		cancelled = c.Step
+	}
+	handler := conv.Handler(nil)
+
+	handler(context.Background(), textUpdate("/register"))
+	handler(context.Background(), textUpdate("/cancel@bot"))
+
+	if cancelled != "name" {
+		t.Errorf("OnCancel got step %q, want \"name\"", cancelled)
+	}
+	if state, _ := store.Get(context.Background(), convKey); state != nil {
+		t.Errorf("Cancelled conversation is still stored: %+v", state)
+	}
+}
+
+func TestConversationTimeout(t *testing.T) {
+	store := tgbot.NewMemoryConversationStore()
+	var prompts []string
+	var errs []error
+	conv := registration(store, &prompts, &errs)
+	now := time.Unix(1700000000, 0)
+	conv.Now = func() time.Time { return now }
+	conv.Timeout = time.Minute
+	var expired []string
+	conv.OnTimeout = func(_ context.Context, c *tgbot.Conversation) {
+		expired = append(expired, c.Step)
+	}
+	handler := conv.Handler(nil)
+
+	handler(context.Background(), textUpdate("/register"))
+	now = now.Add(2 * time.Minute)
+	// The expired conversation is dropped, the input does not reach the "name" step.
+	handler(context.Background(), textUpdate("Alice"))
+	if len(prompts) != 1 || len(expired) != 1 || expired[0] != "name" {
+		t.Errorf("Got prompts %q and expired steps %q after the timeout", prompts, expired)
+	}
+
+	handler(context.Background(), textUpdate("/register"))
+	now = now.Add(30 * time.Second)
+	if err := conv.ExpireStale(context.Background()); err != nil || len(expired) != 1 {
+		t.Errorf("ExpireStale expired a fresh conversation: %v, %q", err, expired)
+	}
+	now = now.Add(time.Minute)
+	if err := conv.ExpireStale(context.Background()); err != nil || len(expired) != 2 {
+		t.Errorf("ExpireStale did not expire a stale conversation: %v, %q", err, expired)
+	}
+	if state, _ := store.Get(context.Background(), convKey); state != nil {
+		t.Errorf("Expired conversation is still stored: %+v", state)
+	}
+}
+
+func TestConversationUnknownStep(t *testing.T) {
+	tests := []struct {
+		name    string
+		store   tgbot.ConversationStore
+		wantErr string
+		deleted bool
+	}{
+		{name: "deleted", store: tgbot.NewMemoryConversationStore(), wantErr: "Unknown conversation step", deleted: true},
+		{name: "delete fails", store: &failingDeleteStore{tgbot.NewMemoryConversationStore()}, wantErr: "store is read only"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			tc.store.Set(ctx, &tgbot.ConversationState{Key: convKey, Step: "removed", Updated: time.Now()})
+			var prompts []string
+			var errs []error
+			registration(tc.store, &prompts, &errs).Handler(nil)(ctx, textUpdate("Alice"))
+
+			if len(errs) != 1 || !strings.Contains(errs[0].Error(), tc.wantErr) {
+				t.Errorf("Got errors %v, want %q", errs, tc.wantErr)
+			}
+			if state, _ := tc.store.Get(ctx, convKey); (state == nil) != tc.deleted {
+				t.Errorf("Got state %+v, deleted %v", state, tc.deleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,209 @@
+package tgbot
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// FileContent is a stream of the file bytes with some metadata.
+type FileContent struct {
+	io.ReadCloser
+
+	// Total size of the file in bytes, -1 if unknown
+	Size int64
+	// Position in the file the stream starts from, 0 unless a partial download was requested
+	Offset int64
+	// MIME type reported by the server or guessed from the file extension
+	MimeType string
+}
+
+// FileFetcher is a TelegramBot that can fetch file contents by the file_path from getFile.
+type FileFetcher interface {
+	FetchFile(ctx context.Context, filePath string, offset int64) (*FileContent, error)
+}
+
+func guessMimeType(contentType string, filePath string) string {
+	if contentType != "" && !strings.HasPrefix(contentType, "application/octet-stream") {
+		return contentType
+	}
+	if byExt := mime.TypeByExtension(filepath.Ext(filePath)); byExt != "" {
+		return byExt
+	}
+	return "application/octet-stream"
+}
+
+func openLocalFile(filePath string, offset int64) (*FileContent, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	stat, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &FileContent{
+		ReadCloser: file,
+		Size:       stat.Size(),
+		Offset:     offset,
+		MimeType:   guessMimeType("", filePath),
+	}, nil
+}
+
+func unsatisfiedRangeSize(contentRange string) (int64, bool) {
+	size, ok := strings.CutPrefix(contentRange, "bytes */")
+	if !ok {
+		return 0, false
+	}
+	value, err := strconv.ParseInt(size, 10, 64)
+	return value, err == nil
+}
+
+// FetchFile downloads the file starting from the offset. Absolute paths are returned by the
+// local Bot API server and are read directly from the disk. The content is nil if there is
+// nothing to read after the offset.
+func (b *TelegramBotImpl) FetchFile(ctx context.Context, filePath string, offset int64) (*FileContent, error) {
+	content, err := b.fetchFile(ctx, filePath, offset)
+	return content, redactError(err, string(b.token))
+}
+
+func (b *TelegramBotImpl) fetchFile(ctx context.Context, filePath string, offset int64) (*FileContent, error) {
+	if b.local && filepath.IsAbs(filePath) {
+		return openLocalFile(filePath, offset)
+	}
+
+	fileURL := b.server.JoinPath("file", "bot"+string(b.token), filePath)
+	request, err := http.NewRequestWithContext(ctx, "GET", fileURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if b.userAgent != "" {
+		request.Header.Set("User-Agent", b.userAgent)
+	}
+	if offset > 0 {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := b.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		offset = 0
+	case http.StatusPartialContent:
+	case http.StatusRequestedRangeNotSatisfiable:
+		resp.Body.Close()
+		if offset == 0 {
+			return nil, fmt.Errorf("Cannot download file \"%s\": %s", filePath, resp.Status)
+		}
+		// The offset is at the end of the file or past it, the server tells the file size in
+		// "Content-Range: bytes */<size>". A file of another size is downloaded again.
+		if size, ok := unsatisfiedRangeSize(resp.Header.Get("Content-Range")); ok && size != offset {
+			return b.fetchFile(ctx, filePath, 0)
+		}
+		return nil, nil
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("Cannot download file \"%s\": %s", filePath, resp.Status)
+	}
+
+	size := int64(-1)
+	if resp.ContentLength >= 0 {
+		size = offset + resp.ContentLength
+	}
+	return &FileContent{
+		ReadCloser: resp.Body,
+		Size:       size,
+		Offset:     offset,
+		MimeType:   guessMimeType(resp.Header.Get("Content-Type"), filePath),
+	}, nil
+}
+
+// fetchFile returns nil content if there is nothing left to read after the offset.
+func (a *TelegramApi) fetchFile(ctx context.Context, fileID string, offset int64) (*FileContent, error) {
+	fetcher, ok := a.bot.(FileFetcher)
+	if !ok {
+		return nil, fmt.Errorf("Bot %T cannot download files", a.bot)
+	}
+	file, err := a.withContext(ctx).GetFile(&GetFileRequest{FileID: fileID})
+	if err != nil {
+		return nil, err
+	}
+	if file.Result == nil || file.Result.FilePath == "" {
+		return nil, fmt.Errorf("No file path for file \"%s\"", fileID)
+	}
+	if size := file.Result.FileSize; size > 0 && offset >= size {
+		if offset == size {
+			return nil, nil
+		}
+		offset = 0
+	}
+	content, err := fetcher.FetchFile(ctx, file.Result.FilePath, offset)
+	if err != nil || content == nil {
+		return content, err
+	}
+	if content.Size < 0 && file.Result.FileSize > 0 {
+		content.Size = file.Result.FileSize
+	}
+	return content, nil
+}
+
+// DownloadFile resolves the file with getFile and opens its contents for reading. Caller
+// must close the returned content.
+func (a *TelegramApi) DownloadFile(ctx context.Context, fileID string) (*FileContent, error) {
+	return a.fetchFile(ctx, fileID, 0)
+}
+
+// DownloadToPath saves the file to the path. Data is written to "<path>.part" first and then
+// renamed, an existing ".part" file left by an interrupted download is resumed.
+func (a *TelegramApi) DownloadToPath(ctx context.Context, fileID string, path string) error {
+	partPath := path + ".part"
+	part, err := os.OpenFile(partPath, os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer part.Close()
+
+	offset, err := part.Seek(0, io.SeekEnd)
+	if err != nil {
+		return err
+	}
+	content, err := a.fetchFile(ctx, fileID, offset)
+	if err != nil {
+		return err
+	}
+	if content != nil {
+		defer content.Close()
+		if content.Offset != offset {
+			if err := part.Truncate(content.Offset); err != nil {
+				return err
+			}
+			if _, err := part.Seek(content.Offset, io.SeekStart); err != nil {
+				return err
+			}
+		}
+		if _, err := io.Copy(part, content); err != nil {
+			return err
+		}
+	}
+
+	if err := part.Sync(); err != nil {
+		return err
+	}
+	if err := part.Close(); err != nil {
+		return err
+	}
+	return os.Rename(partPath, path)
+}
@@ -0,0 +1,132 @@
+package tgbot_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lanseg/tgbot"
+	"github.com/lanseg/tgbot/tgbottest"
+)
+
+var fileData = []byte("0123456789abcdefghijklmnopqrstuvwxyz")
+
+// withoutFileSize makes getFile return the file without file_size, as the server does for some
+// files.
+func withoutFileSize(server *tgbottest.Server, file *tgbot.File) {
+	server.Handle("getFile", func(json.RawMessage) (interface{}, error) {
+		return &tgbot.File{FileID: file.FileID, FileUniqueID: file.FileUniqueID, FilePath: file.FilePath}, nil
+	})
+}
+
+func downloadToPath(t *testing.T, api *tgbot.TelegramApi, fileID string, part []byte) []byte {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "file")
+	if part != nil {
+		if err := os.WriteFile(path+".part", part, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := api.DownloadToPath(context.Background(), fileID, path); err != nil {
+		t.Fatalf("DownloadToPath failed: %s", err)
+	}
+	if _, err := os.Stat(path + ".part"); !os.IsNotExist(err) {
+		t.Errorf("Part file is left after download: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestDownloadFile(t *testing.T) {
+	server, api := newTestApi(t)
+	file := server.AddFile("photo.jpg", fileData)
+
+	content, err := api.DownloadFile(context.Background(), file.FileID)
+	if err != nil {
+		t.Fatalf("DownloadFile failed: %s", err)
+	}
+	defer content.Close()
+	data, err := io.ReadAll(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != string(fileData) {
+		t.Errorf("Downloaded %q, want %q", data, fileData)
+	}
+	if content.Size != int64(len(fileData)) || content.Offset != 0 || content.MimeType != "image/jpeg" {
+		t.Errorf("Got size %d, offset %d, mime type %q", content.Size, content.Offset, content.MimeType)
+	}
+}
+
+func TestDownloadFileUsesContext(t *testing.T) {
+	server, api := newTestApi(t)
+	file := server.AddFile("photo.jpg", fileData)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := api.DownloadFile(ctx, file.FileID); err == nil {
+		t.Errorf("DownloadFile succeeded with a cancelled context")
+	}
+	if calls := server.CallsTo("getFile"); len(calls) != 0 {
+		t.Errorf("getFile was called with a cancelled context")
+	}
+}
+
+func TestDownloadToPath(t *testing.T) {
+	tests := []struct {
+		name        string
+		part        []byte
+		unknownSize bool
+	}{
+		{name: "new file"},
+		{name: "resume", part: fileData[:10]},
+		{name: "resume without size", part: fileData[:10], unknownSize: true},
+		{name: "complete part", part: fileData},
+		{name: "complete part without size", part: fileData, unknownSize: true},
+		{name: "part longer than file", part: append(fileData, "extra"...), unknownSize: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			server, api := newTestApi(t)
+			file := server.AddFile("document.txt", fileData)
+			if tc.unknownSize {
+				withoutFileSize(server, file)
+			}
+			if data := downloadToPath(t, api, file.FileID, tc.part); string(data) != string(fileData) {
+				t.Errorf("Downloaded %q, want %q", data, fileData)
+			}
+		})
+	}
+}
+
+func TestDownloadLocalFile(t *testing.T) {
+	server := tgbottest.NewServer()
+	defer server.Close()
+	bot, err := tgbot.NewLocalBot(server.URL, server.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "local.txt")
+	if err := os.WriteFile(path, fileData, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	server.Handle("getFile", func(json.RawMessage) (interface{}, error) {
+		return &tgbot.File{FileID: "local", FilePath: path, FileSize: int64(len(fileData))}, nil
+	})
+
+	content, err := tgbot.NewTelegramApi(bot).DownloadFile(context.Background(), "local")
+	if err != nil {
+		t.Fatalf("DownloadFile failed: %s", err)
+	}
+	defer content.Close()
+	data, _ := io.ReadAll(content)
+	if string(data) != string(fileData) || content.MimeType != "text/plain; charset=utf-8" {
+		t.Errorf("Downloaded %q of type %q", data, content.MimeType)
+	}
+}
@@ -0,0 +1,169 @@
+package tgbot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+)
+
+// OrderKey returns the key of the updates that must be handled in order, zero if the update can
+// be handled in any order.
+type OrderKey func(update *Update) int64
+
+// ChatOrder handles the updates of the same chat in order, the updates without a chat are
+// ordered by the user.
+func ChatOrder(update *Update) int64 {
+	if chatID := updateChatID(update); chatID != 0 {
+		return chatID
+	}
+	return updateUserID(update)
+}
+
+// UserOrder handles the updates of the same user in order, the updates without a user are
+// ordered by the chat.
+func UserOrder(update *Update) int64 {
+	if userID := updateUserID(update); userID != 0 {
+		return userID
+	}
+	return updateChatID(update)
+}
+
+// ErrExecutorClosed is returned by Submit after Close.
+var ErrExecutorClosed = errors.New("Executor is closed")
+
+type executorJob struct {
+	ctx     context.Context
+	update  *Update
+	handler UpdateHandler
+	done    func()
+}
+
+// Executor handles the updates in a pool of workers. Updates with the same OrderKey always go
+// to the same worker, so the updates of a chat are handled in order while different chats are
+// handled in parallel. A panic in the handler is logged and the worker continues.
+type Executor struct {
+	key    OrderKey
+	queues []chan *executorJob
+	wg     sync.WaitGroup
+	logger *slog.Logger
+
+	// mu is held for reading while submitting, so the queues are not closed under Submit
+	mu     sync.RWMutex
+	closed bool
+}
+
+// NewExecutor starts the workers, each with a queue of queueSize updates. Submit blocks when the
+// queue is full, so a slow handler slows down the polling instead of piling up the updates.
+func NewExecutor(workers int, queueSize int, key OrderKey, logger *slog.Logger) *Executor {
+	if workers <= 0 {
+		workers = 1
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	e := &Executor{
+		key:    key,
+		queues: make([]chan *executorJob, workers),
+		logger: logger,
+	}
+	for i := range e.queues {
+		e.queues[i] = make(chan *executorJob, queueSize)
+		e.wg.Add(1)
+		go e.work(e.queues[i])
+	}
+	return e
+}
+
+func (e *Executor) work(queue chan *executorJob) {
+	defer e.wg.Done()
+	for job := range queue {
+		e.run(job)
+	}
+}
+
+func (e *Executor) run(job *executorJob) {
+	defer job.done()
+	defer func() {
+		if r := recover(); r != nil {
+			e.logger.ErrorContext(job.ctx, "Update handler panic",
+				slog.Int64("update_id", job.update.UpdateID),
+				slog.String("update_type", string(job.update.Kind())),
+				slog.String("panic", fmt.Sprint(r)),
+				slog.String("stack", string(debug.Stack())))
+		}
+	}()
+	job.handler(job.ctx, job.update)
+}
+
+func (e *Executor) queue(update *Update) chan *executorJob {
+	key := int64(0)
+	if e.key != nil {
+		key = e.key(update)
+	}
+	if key == 0 {
+		key = update.UpdateID
+	}
+	return e.queues[uint64(key)%uint64(len(e.queues))]
+}
+
+// Submit queues the update for the handler, done is called when it is handled. Blocks while the
+// queue is full, fails if the context is cancelled first or the executor is closed.
+func (e *Executor) Submit(ctx context.Context, update *Update, handler UpdateHandler, done func()) error {
+	if done == nil {
+		done = func() {}
+	}
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.closed {
+		return ErrExecutorClosed
+	}
+	select {
+	case e.queue(update) <- &executorJob{ctx: ctx, update: update, handler: handler, done: done}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Handler returns an UpdateHandler that submits the update and waits until it is handled. An
+// update that cannot be submitted is logged and dropped, use HandlerErr to report it instead.
+func (e *Executor) Handler(handler UpdateHandler) UpdateHandler {
+	handle := e.HandlerErr(handler)
+	return func(ctx context.Context, update *Update) {
+		if err := handle(ctx, update); err != nil {
+			e.logger.ErrorContext(ctx, "Update is dropped",
+				slog.Int64("update_id", update.UpdateID), slog.String("error", err.Error()))
+		}
+	}
+}
+
+// HandlerErr returns a function that submits the update and waits until it is handled, e.g. for
+// the webhook requests. The error is ErrExecutorClosed or the context error when the update is
+// not submitted.
+func (e *Executor) HandlerErr(handler UpdateHandler) func(ctx context.Context, update *Update) error {
+	return func(ctx context.Context, update *Update) error {
+		handled := make(chan struct{})
+		if err := e.Submit(ctx, update, handler, func() { close(handled) }); err != nil {
+			return err
+		}
+		<-handled
+		return nil
+	}
+}
+
+// Close waits for the queued updates, Submit fails with ErrExecutorClosed after Close.
+func (e *Executor) Close() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return
+	}
+	e.closed = true
+	for _, queue := range e.queues {
+		close(queue)
+	}
+	e.wg.Wait()
+}
@@ -0,0 +1,142 @@
+package tgbot_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lanseg/tgbot"
+)
+
+var quietLogger = slog.New(slog.NewTextHandler(io.Discard, nil))
+
+func chatUpdate(id int64, chatID int64) *tgbot.Update {
+	return &tgbot.Update{UpdateID: id, Message: &tgbot.Message{
+		Chat: &tgbot.Chat{ID: chatID, Type: "private"},
+	}}
+}
+
+func TestExecutorOrdersUpdatesByKey(t *testing.T) {
+	executor := tgbot.NewExecutor(3, 5, tgbot.ChatOrder, quietLogger)
+	var mu sync.Mutex
+	handled := map[int64][]int64{}
+	var wg sync.WaitGroup
+	handler := func(_ context.Context, update *tgbot.Update) {
+		// The later updates finish faster, so they would overtake the earlier ones in parallel.
+		time.Sleep(time.Duration(20-update.UpdateID%20) * time.Millisecond / 10)
+		mu.Lock()
+		defer mu.Unlock()
+		handled[update.Message.Chat.ID] = append(handled[update.Message.Chat.ID], update.UpdateID)
+	}
+
+	want := map[int64][]int64{}
+	for id := int64(1); id <= 60; id++ {
+		chatID := id%4 + 1
+		want[chatID] = append(want[chatID], id)
+		wg.Add(1)
+		if err := executor.Submit(context.Background(), chatUpdate(id, chatID), handler, wg.Done); err != nil {
+			t.Fatalf("Submit(%d) failed: %s", id, err)
+		}
+	}
+	wg.Wait()
+	executor.Close()
+
+	if !reflect.DeepEqual(handled, want) {
+		t.Errorf("Handled updates per chat %v, want %v", handled, want)
+	}
+}
+
+func TestExecutorRecoversFromPanic(t *testing.T) {
+	executor := tgbot.NewExecutor(1, 5, nil, quietLogger)
+	defer executor.Close()
+
+	done := make(chan int64, 2)
+	var handled []int64
+	handler := func(_ context.Context, update *tgbot.Update) {
+		if update.UpdateID == 1 {
+			panic("broken handler")
+		}
+		handled = append(handled, update.UpdateID)
+	}
+	for id := int64(1); id <= 2; id++ {
+		id := id
+		if err := executor.Submit(context.Background(), &tgbot.Update{UpdateID: id}, handler, func() { done <- id }); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, want := range []int64{1, 2} {
+		select {
+		case id := <-done:
+			if id != want {
+				t.Errorf("Update %d is done, want %d", id, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Update %d is not done after the panic", want)
+		}
+	}
+	if !reflect.DeepEqual(handled, []int64{2}) {
+		t.Errorf("Handled %v, want the worker to continue after the panic", handled)
+	}
+}
+
+func TestExecutorHandlerWaits(t *testing.T) {
+	executor := tgbot.NewExecutor(2, 0, tgbot.ChatOrder, quietLogger)
+	defer executor.Close()
+
+	handled := false
+	executor.Handler(func(context.Context, *tgbot.Update) {
+		time.Sleep(10 * time.Millisecond)
+		handled = true
+	})(context.Background(), chatUpdate(1, 1))
+	if !handled {
+		t.Errorf("Handler returned before the update was handled")
+	}
+}
+
+func TestExecutorSubmitCancelled(t *testing.T) {
+	executor := tgbot.NewExecutor(1, 0, nil, quietLogger)
+	defer executor.Close()
+
+	release := make(chan struct{})
+	blocking := func(context.Context, *tgbot.Update) { <-release }
+	if err := executor.Submit(context.Background(), &tgbot.Update{UpdateID: 1}, blocking, nil); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := executor.Submit(ctx, &tgbot.Update{UpdateID: 2}, blocking, nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Submit to a full queue = %v, want the context error", err)
+	}
+	close(release)
+}
+
+func TestExecutorSubmitAfterClose(t *testing.T) {
+	executor := tgbot.NewExecutor(2, 1, tgbot.ChatOrder, quietLogger)
+	executor.Close()
+	executor.Close()
+
+	called := false
+	err := executor.Submit(context.Background(), chatUpdate(1, 1), func(context.Context, *tgbot.Update) {
+		called = true
+	}, nil)
+	if !errors.Is(err, tgbot.ErrExecutorClosed) || called {
+		t.Errorf("Submit after Close = %v, handler called: %v", err, called)
+	}
+}
+
+func TestExecutorHandlerErr(t *testing.T) {
+	executor := tgbot.NewExecutor(2, 1, tgbot.ChatOrder, quietLogger)
+	handler := executor.HandlerErr(func(context.Context, *tgbot.Update) {})
+	if err := handler(context.Background(), chatUpdate(1, 1)); err != nil {
+		t.Errorf("HandlerErr = %v, want the update handled", err)
+	}
+	executor.Close()
+	if err := handler(context.Background(), chatUpdate(2, 1)); !errors.Is(err, tgbot.ErrExecutorClosed) {
+		t.Errorf("HandlerErr after Close = %v, want ErrExecutorClosed", err)
+	}
+}
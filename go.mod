@@ -1,3 +1,5 @@
 module github.com/lanseg/tgbot
 
 go 1.21.5
+
+require github.com/mattn/go-sqlite3 v1.14.22
@@ -0,0 +1,20 @@
+package tgbot_test
+
+import (
+	"testing"
+
+	"github.com/lanseg/tgbot"
+	"github.com/lanseg/tgbot/tgbottest"
+)
+
+// newTestApi starts a fake Bot API server that is closed when the test ends.
+func newTestApi(t *testing.T, options ...tgbot.BotOption) (*tgbottest.Server, *tgbot.TelegramApi) {
+	t.Helper()
+	server := tgbottest.NewServer()
+	t.Cleanup(server.Close)
+	bot, err := tgbot.NewCustomBot(server.URL, server.Token, options...)
+	if err != nil {
+		t.Fatalf("Cannot create bot: %s", err)
+	}
+	return server, tgbot.NewTelegramApi(bot)
+}
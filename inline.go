@@ -0,0 +1,282 @@
+package tgbot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxInlineResults is the number of results allowed in one answerInlineQuery call
+	MaxInlineResults = 50
+	// MaxInlineResultIDLength is the length of the result id in bytes
+	MaxInlineResultIDLength = 64
+	// MaxInlineOffsetLength is the length of the next_offset in bytes
+	MaxInlineOffsetLength = 64
+)
+
+// NewArticle returns an article result that sends the text.
+func NewArticle(id string, title string, text string) *InlineQueryResultArticle {
+	return &InlineQueryResultArticle{
+		Type:                "article",
+		ID:                  id,
+		Title:               title,
+		InputMessageContent: &InputTextMessageContent{MessageText: text},
+	}
+}
+
+// NewPhotoResult returns a result with the jpeg photo from the url.
+func NewPhotoResult(id string, photoURL string, thumbnailURL string) *InlineQueryResultPhoto {
+	return &InlineQueryResultPhoto{Type: "photo", ID: id, PhotoURL: photoURL, ThumbnailURL: thumbnailURL}
+}
+
+// NewCachedPhoto returns a result with the photo stored on the Telegram servers.
+func NewCachedPhoto(id string, fileID string) *InlineQueryResultCachedPhoto {
+	return &InlineQueryResultCachedPhoto{Type: "photo", ID: id, PhotoFileID: fileID}
+}
+
+// NewCachedDocument returns a result with the file stored on the Telegram servers.
+func NewCachedDocument(id string, title string, fileID string) *InlineQueryResultCachedDocument {
+	return &InlineQueryResultCachedDocument{Type: "document", ID: id, Title: title, DocumentFileID: fileID}
+}
+
+// NewCachedSticker returns a result with the sticker stored on the Telegram servers.
+func NewCachedSticker(id string, fileID string) *InlineQueryResultCachedSticker {
+	return &InlineQueryResultCachedSticker{Type: "sticker", ID: id, StickerFileID: fileID}
+}
+
+// NewCachedVideo returns a result with the video stored on the Telegram servers.
+func NewCachedVideo(id string, title string, fileID string) *InlineQueryResultCachedVideo {
+	return &InlineQueryResultCachedVideo{Type: "video", ID: id, Title: title, VideoFileID: fileID}
+}
+
+// NewCachedAudio returns a result with the mp3 audio stored on the Telegram servers.
+func NewCachedAudio(id string, fileID string) *InlineQueryResultCachedAudio {
+	return &InlineQueryResultCachedAudio{Type: "audio", ID: id, AudioFileID: fileID}
+}
+
+// NewCachedVoice returns a result with the voice message stored on the Telegram servers.
+func NewCachedVoice(id string, title string, fileID string) *InlineQueryResultCachedVoice {
+	return &InlineQueryResultCachedVoice{Type: "voice", ID: id, Title: title, VoiceFileID: fileID}
+}
+
+// NewCachedGif returns a result with the animation stored on the Telegram servers.
+func NewCachedGif(id string, fileID string) *InlineQueryResultCachedGif {
+	return &InlineQueryResultCachedGif{Type: "gif", ID: id, GifFileID: fileID}
+}
+
+// NewCachedMpeg4Gif returns a result with the MPEG-4 animation stored on the Telegram servers.
+func NewCachedMpeg4Gif(id string, fileID string) *InlineQueryResultCachedMpeg4Gif {
+	return &InlineQueryResultCachedMpeg4Gif{Type: "mpeg4_gif", ID: id, Mpeg4FileID: fileID}
+}
+
+// NewCachedResult returns a cached result with the media of the message, e.g. received from an
+// admin, the title is used for the media that require it.
+func NewCachedResult(id string, title string, message *Message) (InlineQueryResult, error) {
+	file := message.File()
+	if file == nil {
+		return nil, fmt.Errorf("Message %d has no media", message.MessageID)
+	}
+	switch message.ContentType() {
+	case ContentTypeAnimation:
+		// Telegram converts most of the animations to MPEG-4 without sound
+		if message.Animation.MimeType == "image/gif" {
+			return NewCachedGif(id, file.FileID), nil
+		}
+		return NewCachedMpeg4Gif(id, file.FileID), nil
+	case ContentTypeAudio:
+		return NewCachedAudio(id, file.FileID), nil
+	case ContentTypeDocument:
+		return NewCachedDocument(id, title, file.FileID), nil
+	case ContentTypePhoto:
+		return NewCachedPhoto(id, file.FileID), nil
+	case ContentTypeSticker:
+		return NewCachedSticker(id, file.FileID), nil
+	case ContentTypeVideo:
+		return NewCachedVideo(id, title, file.FileID), nil
+	case ContentTypeVoice:
+		return NewCachedVoice(id, title, file.FileID), nil
+	}
+	return nil, fmt.Errorf("Message %d with %s cannot be an inline result", message.MessageID, message.ContentType())
+}
+
+// ValidateInlineAnswer checks the limits of answerInlineQuery: up to 50 results with unique ids
+// of 1-64 bytes and the offset up to 64 bytes.
+func ValidateInlineAnswer(request *AnswerInlineQueryRequest) error {
+	if len(request.Results) > MaxInlineResults {
+		return fmt.Errorf("Too many inline results: %d, at most %d are allowed", len(request.Results), MaxInlineResults)
+	}
+	if len(request.NextOffset) > MaxInlineOffsetLength {
+		return fmt.Errorf("Inline offset %q is longer than %d bytes", request.NextOffset, MaxInlineOffsetLength)
+	}
+	ids := map[string]bool{}
+	for i, result := range request.Results {
+		if result == nil {
+			return fmt.Errorf("Inline result %d is nil", i)
+		}
+		id := result.ResultID()
+		if id == "" || len(id) > MaxInlineResultIDLength {
+			return fmt.Errorf("Inline result %d has id %q, must be 1-%d bytes", i, id, MaxInlineResultIDLength)
+		}
+		if ids[id] {
+			return fmt.Errorf("Inline result %d has duplicate id %q", i, id)
+		}
+		ids[id] = true
+	}
+	return nil
+}
+
+// InlineResultSource returns up to limit results of the query, skipping the first offset results.
+type InlineResultSource func(ctx context.Context, query *InlineQuery, offset int, limit int) ([]InlineQueryResult, error)
+
+// InlineSlice is an InlineResultSource over the results that are ready at once.
+func InlineSlice(results func(ctx context.Context, query *InlineQuery) ([]InlineQueryResult, error)) InlineResultSource {
+	return func(ctx context.Context, query *InlineQuery, offset int, limit int) ([]InlineQueryResult, error) {
+		all, err := results(ctx, query)
+		if err != nil || offset >= len(all) {
+			return nil, err
+		}
+		return all[offset:min(offset+limit, len(all))], nil
+	}
+}
+
+type sentInlineResult struct {
+	userID   int64
+	resultID string
+}
+
+// InlineAnswerer answers the inline queries page by page from the source: the offset of the
+// next page is passed to the client as next_offset. The sent results are remembered, so the
+// ChosenInlineResult updates, if enabled with /setinlinefeedback, get back the chosen result.
+type InlineAnswerer struct {
+	api    TelegramApiInterface
+	source InlineResultSource
+
+	// PageSize is the number of results per answer, MaxInlineResults by default
+	PageSize int
+	// CacheTime is how long the server caches the answer, the server default of 5 minutes if zero
+	CacheTime time.Duration
+	// IsPersonal caches the answer only for the user that sent the query
+	IsPersonal bool
+	// Button is shown above the results
+	Button *InlineQueryResultsButton
+	// Remember is the number of the sent results kept for Chosen
+	Remember int
+	// OnChosen is called by the Handler for the chosen results, result is nil if it is forgotten
+	OnChosen func(ctx context.Context, chosen *ChosenInlineResult, result InlineQueryResult)
+	// Logger reports the failed answers in the Handler, slog.Default() if nil
+	Logger *slog.Logger
+
+	mu    sync.Mutex
+	sent  map[sentInlineResult]InlineQueryResult
+	order []sentInlineResult
+}
+
+func NewInlineAnswerer(api TelegramApiInterface, source InlineResultSource) *InlineAnswerer {
+	return &InlineAnswerer{
+		api:      api,
+		source:   source,
+		PageSize: MaxInlineResults,
+		Remember: 10000,
+		sent:     map[sentInlineResult]InlineQueryResult{},
+	}
+}
+
+func (a *InlineAnswerer) pageSize() int {
+	if a.PageSize <= 0 || a.PageSize > MaxInlineResults {
+		return MaxInlineResults
+	}
+	return a.PageSize
+}
+
+// Answer sends the page of the results for the query offset, the results of a bad offset start
+// from the beginning.
+func (a *InlineAnswerer) Answer(ctx context.Context, query *InlineQuery) error {
+	offset, err := strconv.Atoi(query.Offset)
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	limit := a.pageSize()
+	// One more result tells if there is the next page
+	results, err := a.source(ctx, query, offset, limit+1)
+	if err != nil {
+		return fmt.Errorf("Cannot get inline results for %q: %s", query.Query, err)
+	}
+	request := &AnswerInlineQueryRequest{
+		InlineQueryID: query.ID,
+		Results:       results,
+		IsPersonal:    a.IsPersonal,
+		Button:        a.Button,
+	}
+	if len(results) > limit {
+		request.Results = results[:limit]
+		request.NextOffset = strconv.Itoa(offset + limit)
+	}
+	if a.CacheTime > 0 {
+		request.SetCacheTime(a.CacheTime)
+	}
+	if err := ValidateInlineAnswer(request); err != nil {
+		return err
+	}
+	if _, err := a.api.WithContext(ctx).AnswerInlineQuery(request); err != nil {
+		return err
+	}
+	if query.From != nil {
+		a.remember(query.From.ID, request.Results)
+	}
+	return nil
+}
+
+func (a *InlineAnswerer) remember(userID int64, results []InlineQueryResult) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, result := range results {
+		key := sentInlineResult{userID: userID, resultID: result.ResultID()}
+		if _, ok := a.sent[key]; !ok {
+			a.order = append(a.order, key)
+		}
+		a.sent[key] = result
+	}
+	for len(a.order) > a.Remember {
+		delete(a.sent, a.order[0])
+		a.order = a.order[1:]
+	}
+}
+
+// Chosen returns the result that was sent to the user and chosen, false if it is forgotten.
+func (a *InlineAnswerer) Chosen(chosen *ChosenInlineResult) (InlineQueryResult, bool) {
+	if chosen.From == nil {
+		return nil, false
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	result, ok := a.sent[sentInlineResult{userID: chosen.From.ID, resultID: chosen.ResultID}]
+	return result, ok
+}
+
+// Handler answers the inline queries, calls OnChosen for the chosen results and passes the
+// other updates to the next handler, which may be nil.
+func (a *InlineAnswerer) Handler(next UpdateHandler) UpdateHandler {
+	return func(ctx context.Context, update *Update) {
+		switch {
+		case update.InlineQuery != nil:
+			if err := a.Answer(ctx, update.InlineQuery); err != nil {
+				logger := a.Logger
+				if logger == nil {
+					logger = slog.Default()
+				}
+				logger.ErrorContext(ctx, "Cannot answer inline query",
+					slog.Int64("update_id", update.UpdateID),
+					slog.String("error", err.Error()))
+			}
+		case update.ChosenInlineResult != nil && a.OnChosen != nil:
+			result, _ := a.Chosen(update.ChosenInlineResult)
+			a.OnChosen(ctx, update.ChosenInlineResult, result)
+		case next != nil:
+			next(ctx, update)
+		}
+	}
+}
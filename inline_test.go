@@ -0,0 +1,190 @@
+package tgbot_test
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/lanseg/tgbot"
+	"github.com/lanseg/tgbot/tgbottest"
+)
+
+func TestInlineResultsUnmarshal(t *testing.T) {
+	request := &tgbot.AnswerInlineQueryRequest{
+		InlineQueryID: "1",
+		Results: []tgbot.InlineQueryResult{
+			tgbot.NewArticle("text", "Text", "hello"),
+			&tgbot.InlineQueryResultArticle{Type: "article", ID: "venue", Title: "Venue",
+				InputMessageContent: &tgbot.InputVenueMessageContent{Latitude: 1, Longitude: 2, Title: "Cafe", Address: "Street"}},
+			&tgbot.InlineQueryResultArticle{Type: "article", ID: "location", Title: "Location",
+				InputMessageContent: &tgbot.InputLocationMessageContent{Latitude: 1, Longitude: 2}},
+			tgbot.NewPhotoResult("photo", "https://example.com/photo.jpg", "https://example.com/thumb.jpg"),
+			tgbot.NewCachedPhoto("cached photo", "photo-file"),
+			tgbot.NewCachedMpeg4Gif("animation", "animation-file"),
+		},
+	}
+	data, err := json.Marshal(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := &tgbot.AnswerInlineQueryRequest{}
+	if err := json.Unmarshal(data, got); err != nil {
+		t.Fatalf("Cannot unmarshal %s: %s", data, err)
+	}
+	if !reflect.DeepEqual(got, request) {
+		t.Errorf("Unmarshaled request differs\n got: %+v\nwant: %+v", got.Results, request.Results)
+	}
+
+	webApp := &tgbot.AnswerWebAppQueryRequest{WebAppQueryID: "1", Result: tgbot.NewCachedSticker("sticker", "sticker-file")}
+	data, _ = json.Marshal(webApp)
+	gotWebApp := &tgbot.AnswerWebAppQueryRequest{}
+	if err := json.Unmarshal(data, gotWebApp); err != nil || !reflect.DeepEqual(gotWebApp, webApp) {
+		t.Errorf("Unmarshaled %+v, %v, want %+v", gotWebApp, err, webApp)
+	}
+
+	for _, broken := range []string{
+		`{"results": [{"type": "hologram", "id": "1"}]}`,
+		`{"results": [{"type": "photo", "id": "1"}]}`,
+		`{"results": {"type": "article"}}`,
+	} {
+		if err := json.Unmarshal([]byte(broken), &tgbot.AnswerInlineQueryRequest{}); err == nil {
+			t.Errorf("Unmarshal of %s succeeded", broken)
+		}
+	}
+}
+
+func TestValidateInlineAnswer(t *testing.T) {
+	articles := func(ids ...string) []tgbot.InlineQueryResult {
+		result := []tgbot.InlineQueryResult{}
+		for _, id := range ids {
+			result = append(result, tgbot.NewArticle(id, id, id))
+		}
+		return result
+	}
+	many := []string{}
+	for i := 0; i <= tgbot.MaxInlineResults; i++ {
+		many = append(many, strconv.Itoa(i))
+	}
+	for _, tc := range []struct {
+		name    string
+		request *tgbot.AnswerInlineQueryRequest
+		valid   bool
+	}{
+		{name: "valid", request: &tgbot.AnswerInlineQueryRequest{Results: articles("1", "2"), NextOffset: "2"}, valid: true},
+		{name: "too many", request: &tgbot.AnswerInlineQueryRequest{Results: articles(many...)}},
+		{name: "duplicate id", request: &tgbot.AnswerInlineQueryRequest{Results: articles("1", "1")}},
+		{name: "empty id", request: &tgbot.AnswerInlineQueryRequest{Results: articles("")}},
+		{name: "long id", request: &tgbot.AnswerInlineQueryRequest{Results: articles(strings.Repeat("x", 65))}},
+		{name: "nil result", request: &tgbot.AnswerInlineQueryRequest{Results: []tgbot.InlineQueryResult{nil}}},
+		{name: "long offset", request: &tgbot.AnswerInlineQueryRequest{NextOffset: strings.Repeat("1", 65)}},
+	} {
+		if err := tgbot.ValidateInlineAnswer(tc.request); (err == nil) != tc.valid {
+			t.Errorf("%s: ValidateInlineAnswer = %v", tc.name, err)
+		}
+	}
+}
+
+func TestNewCachedResult(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		message *tgbot.Message
+		want    tgbot.InlineQueryResult
+	}{
+		{
+			name:    "mpeg4 animation",
+			message: &tgbot.Message{Animation: &tgbot.Animation{FileID: "mp4", MimeType: "video/mp4"}},
+			want:    tgbot.NewCachedMpeg4Gif("1", "mp4"),
+		},
+		{
+			name:    "gif animation",
+			message: &tgbot.Message{Animation: &tgbot.Animation{FileID: "gif", MimeType: "image/gif"}},
+			want:    tgbot.NewCachedGif("1", "gif"),
+		},
+		{
+			name: "largest photo",
+			message: &tgbot.Message{Photo: []*tgbot.PhotoSize{
+				{FileID: "small", Width: 90, Height: 90},
+				{FileID: "large", Width: 800, Height: 800},
+			}},
+			want: tgbot.NewCachedPhoto("1", "large"),
+		},
+		{
+			name:    "document",
+			message: &tgbot.Message{Document: &tgbot.Document{FileID: "doc"}},
+			want:    tgbot.NewCachedDocument("1", "Title", "doc"),
+		},
+	} {
+		got, err := tgbot.NewCachedResult("1", "Title", tc.message)
+		if err != nil || !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: NewCachedResult = %+v, %v, want %+v", tc.name, got, err, tc.want)
+		}
+	}
+	if _, err := tgbot.NewCachedResult("1", "Title", &tgbot.Message{Text: "text"}); err == nil {
+		t.Errorf("NewCachedResult of a text message succeeded")
+	}
+}
+
+func TestInlineAnswerer(t *testing.T) {
+	server, api := newTestApi(t)
+	server.Handle("answerInlineQuery", func(json.RawMessage) (interface{}, error) {
+		return true, nil
+	})
+	results := []tgbot.InlineQueryResult{
+		tgbot.NewArticle("a", "A", "a"),
+		tgbot.NewArticle("b", "B", "b"),
+		tgbot.NewCachedSticker("c", "sticker"),
+	}
+	answerer := tgbot.NewInlineAnswerer(api, tgbot.InlineSlice(func(context.Context, *tgbot.InlineQuery) ([]tgbot.InlineQueryResult, error) {
+		return results, nil
+	}))
+	answerer.PageSize = 2
+	var chosen tgbot.InlineQueryResult
+	answerer.OnChosen = func(_ context.Context, _ *tgbot.ChosenInlineResult, result tgbot.InlineQueryResult) {
+		chosen = result
+	}
+	handler := answerer.Handler(nil)
+	user := &tgbot.User{ID: 20}
+
+	for i, offset := range []string{"", "2"} {
+		handler(context.Background(), &tgbot.Update{InlineQuery: &tgbot.InlineQuery{ID: "q", From: user, Offset: offset}})
+		calls := server.CallsTo("answerInlineQuery")
+		if len(calls) != i+1 {
+			t.Fatalf("answerInlineQuery is called %d times", len(calls))
+		}
+		request := &tgbot.AnswerInlineQueryRequest{}
+		if err := calls[i].Decode(request); err != nil {
+			t.Fatalf("Cannot decode the answer: %s", err)
+		}
+		wantResults, wantOffset := results[:2], "2"
+		if offset == "2" {
+			wantResults, wantOffset = results[2:], ""
+		}
+		if !reflect.DeepEqual(request.Results, wantResults) || request.NextOffset != wantOffset {
+			t.Errorf("Offset %q: answered %+v with next offset %q", offset, request.Results, request.NextOffset)
+		}
+	}
+
+	handler(context.Background(), &tgbot.Update{ChosenInlineResult: &tgbot.ChosenInlineResult{ResultID: "c", From: user}})
+	if chosen != results[2] {
+		t.Errorf("OnChosen got %+v, want the sent sticker", chosen)
+	}
+	if _, ok := answerer.Chosen(&tgbot.ChosenInlineResult{ResultID: "c", From: &tgbot.User{ID: 21}}); ok {
+		t.Errorf("Result sent to another user is chosen")
+	}
+}
+
+func TestInlineAnswererMockApi(t *testing.T) {
+	api := tgbottest.NewMockApi()
+	answerer := tgbot.NewInlineAnswerer(api, tgbot.InlineSlice(func(_ context.Context, query *tgbot.InlineQuery) ([]tgbot.InlineQueryResult, error) {
+		return []tgbot.InlineQueryResult{tgbot.NewArticle("a", "A", query.Query)}, nil
+	}))
+	if err := answerer.Answer(context.Background(), &tgbot.InlineQuery{ID: "q", Query: "text", From: &tgbot.User{ID: 20}}); err != nil {
+		t.Fatal(err)
+	}
+	tgbottest.AssertCalled(t, api.AnswerInlineQueryCalls(), func(r *tgbot.AnswerInlineQueryRequest) bool {
+		return r.InlineQueryID == "q" && len(r.Results) == 1
+	})
+}
@@ -0,0 +1,150 @@
+package tgbot
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"time"
+)
+
+// QueryFunc performs a single Bot API call: request is one of the *Request types, result is
+// the raw json response.
+type QueryFunc func(ctx context.Context, apiMethod string, request interface{}) ([]byte, error)
+
+// Interceptor wraps a QueryFunc to do something before or after the call.
+type Interceptor func(next QueryFunc) QueryFunc
+
+// Chain wraps the query with interceptors, the first interceptor is the outermost one.
+func Chain(query QueryFunc, interceptors ...Interceptor) QueryFunc {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		query = interceptors[i](query)
+	}
+	return query
+}
+
+// WithInterceptors adds interceptors around every Query of the bot.
+func WithInterceptors(interceptors ...Interceptor) BotOption {
+	return func(b *TelegramBotImpl) error {
+		b.interceptors = append(b.interceptors, interceptors...)
+		return nil
+	}
+}
+
+type interceptedBot struct {
+	TelegramBot
+
+	query QueryFunc
+}
+
+// Intercept wraps any TelegramBot with the interceptors. Prefer WithInterceptors for the bots
+// created with NewCustomBot, the wrapper hides the optional interfaces like FileFetcher.
+func Intercept(bot TelegramBot, interceptors ...Interceptor) TelegramBot {
+	return &interceptedBot{
+		TelegramBot: bot,
+		query: Chain(func(ctx context.Context, apiMethod string, request interface{}) ([]byte, error) {
+			return queryContext(ctx, bot, apiMethod, request)
+		}, interceptors...),
+	}
+}
+
+func (b *interceptedBot) Query(apiMethod string, request interface{}) ([]byte, error) {
+	return b.query(context.Background(), apiMethod, request)
+}
+
+func (b *interceptedBot) QueryContext(ctx context.Context, apiMethod string, request interface{}) ([]byte, error) {
+	return b.query(ctx, apiMethod, request)
+}
+
+func (b *interceptedBot) IsLocal() bool {
+	return isLocalBot(b.TelegramBot)
+}
+
+// responseError returns the error code and description of a failed call, zero code if the
+// response is ok or cannot be parsed.
+func responseError(response []byte) (int64, string) {
+	status := &Response[json.RawMessage]{}
+	if err := json.Unmarshal(response, status); err != nil || status.Ok {
+		return 0, ""
+	}
+	return status.ErrorCode, status.Description
+}
+
+// LoggingInterceptor logs every call: failed ones as errors, successful ones as debug.
+func LoggingInterceptor(logger *slog.Logger) Interceptor {
+	return func(next QueryFunc) QueryFunc {
+		return func(ctx context.Context, apiMethod string, request interface{}) ([]byte, error) {
+			start := time.Now()
+			response, err := next(ctx, apiMethod, request)
+			attrs := []any{
+				slog.String("method", apiMethod),
+				slog.Duration("duration", time.Since(start)),
+				slog.Int("response_size", len(response)),
+			}
+			if requestID := RequestID(ctx); requestID != "" {
+				attrs = append(attrs, slog.String("request_id", requestID))
+			}
+			if err != nil {
+				logger.ErrorContext(ctx, "Bot API call failed", append(attrs, slog.Any("error", err))...)
+			} else if code, description := responseError(response); code != 0 {
+				logger.WarnContext(ctx, "Bot API call returned an error", append(attrs,
+					slog.Int64("error_code", code), slog.String("description", description))...)
+			} else {
+				logger.DebugContext(ctx, "Bot API call", attrs...)
+			}
+			return response, err
+		}
+	}
+}
+
+// TimingInterceptor reports the duration of every call.
+func TimingInterceptor(observe func(apiMethod string, duration time.Duration, err error)) Interceptor {
+	return func(next QueryFunc) QueryFunc {
+		return func(ctx context.Context, apiMethod string, request interface{}) ([]byte, error) {
+			start := time.Now()
+			response, err := next(ctx, apiMethod, request)
+			observe(apiMethod, time.Since(start), err)
+			return response, err
+		}
+	}
+}
+
+type requestIDKey struct{}
+
+// RequestID returns the id assigned to the call by the RequestIDInterceptor.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+func randomRequestID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(id)
+}
+
+// RequestIDInterceptor tags every call with an id available with RequestID(ctx) to the inner
+// interceptors. Random ids are used if newID is nil.
+func RequestIDInterceptor(newID func() string) Interceptor {
+	if newID == nil {
+		newID = randomRequestID
+	}
+	return func(next QueryFunc) QueryFunc {
+		return func(ctx context.Context, apiMethod string, request interface{}) ([]byte, error) {
+			return next(context.WithValue(ctx, requestIDKey{}, newID()), apiMethod, request)
+		}
+	}
+}
+
+// RedactTokenInterceptor removes the bot token from the error messages.
+func RedactTokenInterceptor(token string) Interceptor {
+	return func(next QueryFunc) QueryFunc {
+		return func(ctx context.Context, apiMethod string, request interface{}) ([]byte, error) {
+			response, err := next(ctx, apiMethod, request)
+			return response, redactError(err, token)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package tgbot_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lanseg/tgbot"
+	"github.com/lanseg/tgbot/tgbottest"
+)
+
+// fakeQuery answers every call with the response and error and records the calls.
+type fakeQuery struct {
+	response string
+	err      error
+	methods  []string
+	contexts []context.Context
+}
+
+func (q *fakeQuery) query(ctx context.Context, apiMethod string, _ interface{}) ([]byte, error) {
+	q.methods = append(q.methods, apiMethod)
+	q.contexts = append(q.contexts, ctx)
+	return []byte(q.response), q.err
+}
+
+func tagInterceptor(name string, trace *[]string) tgbot.Interceptor {
+	return func(next tgbot.QueryFunc) tgbot.QueryFunc {
+		return func(ctx context.Context, apiMethod string, request interface{}) ([]byte, error) {
+			*trace = append(*trace, name+" before")
+			response, err := next(ctx, apiMethod, request)
+			*trace = append(*trace, name+" after")
+			return response, err
+		}
+	}
+}
+
+func TestChainOrder(t *testing.T) {
+	trace := []string{}
+	query := tgbot.Chain(func(context.Context, string, interface{}) ([]byte, error) {
+		trace = append(trace, "query")
+		return nil, nil
+	}, tagInterceptor("outer", &trace), tagInterceptor("inner", &trace))
+	query(context.Background(), "getMe", nil)
+
+	want := "outer before,inner before,query,inner after,outer after"
+	if got := strings.Join(trace, ","); got != want {
+		t.Errorf("Chain called %s, want %s", got, want)
+	}
+}
+
+func TestWithInterceptors(t *testing.T) {
+	var methods []string
+	var requests []interface{}
+	var responses []string
+	server, api := newTestApi(t, tgbot.WithInterceptors(func(next tgbot.QueryFunc) tgbot.QueryFunc {
+		return func(ctx context.Context, apiMethod string, request interface{}) ([]byte, error) {
+			response, err := next(ctx, apiMethod, request)
+			methods = append(methods, apiMethod)
+			requests = append(requests, request)
+			responses = append(responses, string(response))
+			return response, err
+		}
+	}))
+	server.AddChat(&tgbot.Chat{ID: 42, Type: "private"})
+
+	request := &tgbot.SendMessageRequest{ChatID: "42", Text: "hello"}
+	if _, err := api.SendMessage(request); err != nil {
+		t.Fatal(err)
+	}
+	if len(methods) != 1 || methods[0] != "SendMessage" || requests[0] != request {
+		t.Fatalf("Interceptor saw %v %v", methods, requests)
+	}
+	if !strings.Contains(responses[0], `"text":"hello"`) {
+		t.Errorf("Interceptor saw response %s", responses[0])
+	}
+}
+
+func TestRequestIDInterceptor(t *testing.T) {
+	var ids []string
+	query := tgbot.Chain(func(ctx context.Context, _ string, _ interface{}) ([]byte, error) {
+		ids = append(ids, tgbot.RequestID(ctx))
+		return nil, nil
+	}, tgbot.RequestIDInterceptor(nil))
+	query(context.Background(), "getMe", nil)
+	query(context.Background(), "getMe", nil)
+
+	if len(ids) != 2 || ids[0] == "" || ids[0] == ids[1] {
+		t.Errorf("Got request ids %q", ids)
+	}
+	if id := tgbot.RequestID(context.Background()); id != "" {
+		t.Errorf("RequestID() without the interceptor = %q", id)
+	}
+}
+
+func TestRedactTokenInterceptor(t *testing.T) {
+	token := "123456:SECRETsecretSECRETsecretSECRETsecret"
+	cause := errors.New("Post https://api.telegram.org/bot" + token + "/getMe: timeout")
+	fake := &fakeQuery{err: cause}
+	query := tgbot.Chain(fake.query, tgbot.RedactTokenInterceptor(token))
+
+	_, err := query(context.Background(), "getMe", nil)
+	if err == nil || strings.Contains(err.Error(), "SECRET") || !strings.Contains(err.Error(), "<token>") {
+		t.Errorf("Error is not redacted: %v", err)
+	}
+	if !errors.Is(err, cause) {
+		t.Errorf("Redacted error does not wrap the original one")
+	}
+}
+
+func TestTimingInterceptor(t *testing.T) {
+	fake := &fakeQuery{err: errors.New("failed")}
+	var observed []string
+	query := tgbot.Chain(fake.query, tgbot.TimingInterceptor(func(apiMethod string, duration time.Duration, err error) {
+		if duration < 0 || err == nil {
+			t.Errorf("Observed duration %s and error %v", duration, err)
+		}
+		observed = append(observed, apiMethod)
+	}))
+	query(context.Background(), "getMe", nil)
+	if len(observed) != 1 || observed[0] != "getMe" {
+		t.Errorf("Observed %v", observed)
+	}
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		err      error
+		want     string
+	}{
+		{name: "success", response: `{"ok":true}`, want: "level=DEBUG msg=\"Bot API call\""},
+		{name: "api error", response: `{"ok":false,"error_code":400,"description":"Bad Request"}`,
+			want: "error_code=400 description=\"Bad Request\""},
+		{name: "failure", err: errors.New("connection refused"), want: "error=\"connection refused\""},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			log := &bytes.Buffer{}
+			logger := slog.New(slog.NewTextHandler(log, &slog.HandlerOptions{Level: slog.LevelDebug}))
+			fake := &fakeQuery{response: tc.response, err: tc.err}
+			query := tgbot.Chain(fake.query, tgbot.RequestIDInterceptor(func() string { return "req-1" }),
+				tgbot.LoggingInterceptor(logger))
+			query(context.Background(), "getMe", nil)
+
+			if !strings.Contains(log.String(), tc.want) || !strings.Contains(log.String(), "request_id=req-1") {
+				t.Errorf("Log %q does not contain %q", log.String(), tc.want)
+			}
+		})
+	}
+}
+
+func TestIntercept(t *testing.T) {
+	trace := []string{}
+	server := tgbottest.NewServer()
+	defer server.Close()
+	bot, err := tgbot.NewCustomBot(server.URL, server.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	intercepted := tgbot.NewTelegramApi(tgbot.Intercept(bot, tagInterceptor("wrapper", &trace)))
+	if _, err := intercepted.GetMe(&tgbot.GetMeRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(trace) != 2 {
+		t.Errorf("Interceptor calls: %v", trace)
+	}
+}
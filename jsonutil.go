@@ -0,0 +1,70 @@
+package tgbot
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Helpers of the generated UnmarshalJSON methods: the fields with an interface type are decoded
+// into the member chosen by the json fields, e.g. by "type" of an InlineQueryResult.
+
+// jsonObject is a json object with the undecoded field values.
+type jsonObject map[string]json.RawMessage
+
+// has reports if the object has the field and it is not null.
+func (o jsonObject) has(field string) bool {
+	value, ok := o[field]
+	return ok && !bytes.Equal(value, []byte("null"))
+}
+
+// is reports if the field is the string value.
+func (o jsonObject) is(field string, value string) bool {
+	var actual string
+	return o.has(field) && json.Unmarshal(o[field], &actual) == nil && actual == value
+}
+
+// isZero reports if the field is missing, null or the number 0.
+func (o jsonObject) isZero(field string) bool {
+	return !o.has(field) || bytes.Equal(o[field], []byte("0"))
+}
+
+// unmarshalMember decodes the json object into the member returned by choose, a missing or null
+// value is the nil interface.
+func unmarshalMember[T comparable](data []byte, name string, choose func(fields jsonObject) T) (T, error) {
+	var member T
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return member, nil
+	}
+	fields := jsonObject{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return member, fmt.Errorf("Cannot unmarshal %s: %s", name, err)
+	}
+	if member = choose(fields); member == *new(T) {
+		return member, fmt.Errorf("Cannot unmarshal %s: unknown member %s", name, data)
+	}
+	if err := json.Unmarshal(data, any(member)); err != nil {
+		return member, fmt.Errorf("Cannot unmarshal %s: %s", name, err)
+	}
+	return member, nil
+}
+
+// unmarshalMembers decodes the json array with unmarshalMember.
+func unmarshalMembers[T comparable](data []byte, name string, choose func(fields jsonObject) T) ([]T, error) {
+	values := []json.RawMessage{}
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil, nil
+	}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("Cannot unmarshal %s array: %s", name, err)
+	}
+	result := make([]T, len(values))
+	for i, value := range values {
+		member, err := unmarshalMember(value, name, choose)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = member
+	}
+	return result, nil
+}
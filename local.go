@@ -0,0 +1,148 @@
+package tgbot
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// Upload limit of the cloud Bot API server for the multipart uploads
+	CloudMaxUploadSize = 50 << 20
+	// Upload limit of the local Bot API server, files are passed as file:// URIs
+	LocalMaxUploadSize = 2000 << 20
+
+	// Limit of max_connections in setWebhook on the cloud Bot API server
+	CloudMaxWebhookConnections = 100
+	// Limit of max_connections in setWebhook on the local Bot API server
+	LocalMaxWebhookConnections = 100000
+)
+
+// NewLocalBot creates a bot that talks to a local telegram-bot-api server. Local server
+// accepts local files as file:// URIs and returns absolute local paths from getFile.
+func NewLocalBot(server string, token string, options ...BotOption) (TelegramBot, error) {
+	return NewCustomBot(server, token, append(options, WithLocalServer())...)
+}
+
+// IsLocal reports if the bot is connected to a local Bot API server.
+func (b *TelegramBotImpl) IsLocal() bool {
+	return b.local
+}
+
+// isLocalBot checks the bot or the bot it wraps, all the wrappers in this package forward
+// IsLocal.
+func isLocalBot(bot TelegramBot) bool {
+	local, ok := bot.(interface{ IsLocal() bool })
+	return ok && local.IsLocal()
+}
+
+func maxUploadSize(local bool) int64 {
+	if local {
+		return LocalMaxUploadSize
+	}
+	return CloudMaxUploadSize
+}
+
+func maxWebhookConnections(local bool) int64 {
+	if local {
+		return LocalMaxWebhookConnections
+	}
+	return CloudMaxWebhookConnections
+}
+
+// CheckUploadSize fails if the file is too big to be uploaded to the server of the bot.
+func CheckUploadSize(bot TelegramBot, size int64) error {
+	if limit := maxUploadSize(isLocalBot(bot)); size > limit {
+		return fmt.Errorf("File is too big: %d bytes, at most %d allowed", size, limit)
+	}
+	return nil
+}
+
+// checkLimits rejects the requests the server would reject because of its limits.
+func (b *TelegramBotImpl) checkLimits(body interface{}) error {
+	if webhook, ok := body.(*SetWebhookRequest); ok {
+		if limit := maxWebhookConnections(b.local); webhook.MaxConnections > limit {
+			return fmt.Errorf("Too many webhook connections: %d, at most %d allowed",
+				webhook.MaxConnections, limit)
+		}
+	}
+	return nil
+}
+
+// LocalFileURI returns a file:// URI that can be used instead of InputFile when sending files
+// through the local Bot API server.
+func (b *TelegramBotImpl) LocalFileURI(path string) (string, error) {
+	return localFileURI(b, path)
+}
+
+func localFileURI(bot TelegramBot, path string) (string, error) {
+	if !isLocalBot(bot) {
+		return "", fmt.Errorf("Local files can be sent only through the local Bot API server")
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	stat, err := os.Stat(absPath)
+	if err != nil {
+		return "", err
+	}
+	if err := CheckUploadSize(bot, stat.Size()); err != nil {
+		return "", fmt.Errorf("Cannot send \"%s\": %s", path, err)
+	}
+	return "file://" + filepath.ToSlash(absPath), nil
+}
+
+// IsLocal reports if the api is connected to a local Bot API server.
+func (a *TelegramApi) IsLocal() bool {
+	return isLocalBot(a.bot)
+}
+
+// LocalFileURI returns a file:// URI of the local file, see TelegramBotImpl.LocalFileURI.
+func (a *TelegramApi) LocalFileURI(path string) (string, error) {
+	return localFileURI(a.bot, path)
+}
+
+// MinMoveRetryDelay is the shortest delay between the getMe calls in MoveBot.
+const MinMoveRetryDelay = time.Second
+
+// MoveBot moves the bot from one Bot API server to another. The bot is logged out from the
+// server it leaves, cloud or local, so that server stops receiving its updates, then the target
+// server is polled with getMe every retryDelay, at least MinMoveRetryDelay, until it accepts the
+// bot or the context is done. Logging back in to the cloud server is possible only 10 minutes
+// after the logOut from it, so use a context with a long enough deadline.
+func MoveBot(ctx context.Context, from TelegramBot, to TelegramBot, retryDelay time.Duration) error {
+	fromLocal, toLocal := isLocalBot(from), isLocalBot(to)
+	if !fromLocal && !toLocal {
+		return fmt.Errorf("Cannot move bot from the cloud server to the cloud server")
+	}
+	if retryDelay < MinMoveRetryDelay {
+		retryDelay = MinMoveRetryDelay
+	}
+
+	fromApi := NewTelegramApi(from)
+	if _, err := fromApi.DeleteWebhook(&DeleteWebhookRequest{}); err != nil {
+		return fmt.Errorf("Cannot delete webhook before moving: %s", err)
+	}
+	if _, err := fromApi.LogOut(&LogOutRequest{}); err != nil {
+		if fromLocal {
+			return fmt.Errorf("Cannot log out from the local server: %s", err)
+		}
+		return fmt.Errorf("Cannot log out from the cloud server: %s", err)
+	}
+
+	toApi := NewTelegramApi(to)
+	for {
+		_, err := toApi.GetMe(&GetMeRequest{})
+		if err == nil {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("Bot is not available on the target server: %s", err)
+		case <-time.After(retryDelay):
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package tgbot_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lanseg/tgbot"
+	"github.com/lanseg/tgbot/tgbottest"
+)
+
+func newLocalTestApi(t *testing.T) (*tgbottest.Server, *tgbot.TelegramApi) {
+	t.Helper()
+	server := tgbottest.NewServer()
+	t.Cleanup(server.Close)
+	server.Handle("setWebhook", func(json.RawMessage) (interface{}, error) {
+		return true, nil
+	})
+	bot, err := tgbot.NewLocalBot(server.URL, server.Token)
+	if err != nil {
+		t.Fatalf("Cannot create bot: %s", err)
+	}
+	return server, tgbot.NewTelegramApi(bot)
+}
+
+func TestIsLocal(t *testing.T) {
+	_, cloud := newTestApi(t)
+	_, local := newLocalTestApi(t)
+	if cloud.IsLocal() {
+		t.Errorf("Cloud bot is local")
+	}
+	if !local.IsLocal() {
+		t.Errorf("Local bot is not local")
+	}
+	if !local.WithContext(context.Background()).(*tgbot.TelegramApi).IsLocal() {
+		t.Errorf("Local bot with context is not local")
+	}
+	server := tgbottest.NewServer()
+	defer server.Close()
+	bot, _ := tgbot.NewLocalBot(server.URL, server.Token)
+	if !tgbot.NewTelegramApi(tgbot.Intercept(bot)).IsLocal() {
+		t.Errorf("Intercepted local bot is not local")
+	}
+}
+
+func TestLocalFileURI(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "video.mp4")
+	if err := os.WriteFile(path, []byte("video"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	_, cloud := newTestApi(t)
+	_, local := newLocalTestApi(t)
+
+	if _, err := cloud.LocalFileURI(path); err == nil {
+		t.Errorf("Cloud bot returned a local file URI")
+	}
+	uri, err := local.WithContext(context.Background()).(*tgbot.TelegramApi).LocalFileURI(path)
+	if err != nil {
+		t.Fatalf("LocalFileURI failed: %s", err)
+	}
+	if uri != "file://"+filepath.ToSlash(path) {
+		t.Errorf("LocalFileURI() = %q", uri)
+	}
+	if _, err := local.LocalFileURI(path + ".missing"); err == nil {
+		t.Errorf("LocalFileURI succeeded for a missing file")
+	}
+}
+
+func TestCheckUploadSize(t *testing.T) {
+	server := tgbottest.NewServer()
+	defer server.Close()
+	cloud, _ := tgbot.NewCustomBot(server.URL, server.Token)
+	local, _ := tgbot.NewLocalBot(server.URL, server.Token)
+
+	if err := tgbot.CheckUploadSize(cloud, tgbot.CloudMaxUploadSize); err != nil {
+		t.Errorf("CheckUploadSize failed at the cloud limit: %s", err)
+	}
+	if err := tgbot.CheckUploadSize(cloud, tgbot.CloudMaxUploadSize+1); err == nil {
+		t.Errorf("CheckUploadSize allowed a file over the cloud limit")
+	}
+	if err := tgbot.CheckUploadSize(local, tgbot.CloudMaxUploadSize+1); err != nil {
+		t.Errorf("CheckUploadSize failed for the local server: %s", err)
+	}
+	if err := tgbot.CheckUploadSize(local, tgbot.LocalMaxUploadSize+1); err == nil {
+		t.Errorf("CheckUploadSize allowed a file over the local limit")
+	}
+}
+
+func TestWebhookConnections(t *testing.T) {
+	cloudServer, cloud := newTestApi(t)
+	_, local := newLocalTestApi(t)
+	request := &tgbot.SetWebhookRequest{URL: "https://example.com/hook", MaxConnections: 1000}
+
+	if _, err := cloud.SetWebhook(request); err == nil || !strings.Contains(err.Error(), "webhook connections") {
+		t.Errorf("Cloud bot allowed 1000 webhook connections: %v", err)
+	}
+	if calls := cloudServer.CallsTo("setWebhook"); len(calls) != 0 {
+		t.Errorf("Rejected setWebhook was sent to the server")
+	}
+	if _, err := local.SetWebhook(request); err != nil {
+		t.Errorf("Local bot rejected 1000 webhook connections: %s", err)
+	}
+	request.MaxConnections = tgbot.LocalMaxWebhookConnections + 1
+	if _, err := local.SetWebhook(request); err == nil {
+		t.Errorf("Local bot allowed %d webhook connections", request.MaxConnections)
+	}
+}
+
+func TestMoveBot(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		fromLocal bool
+		toLocal   bool
+	}{
+		{name: "cloud to local", toLocal: true},
+		{name: "local to cloud", fromLocal: true},
+		{name: "local to local", fromLocal: true, toLocal: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			newBot := func(server *tgbottest.Server, local bool) tgbot.TelegramBot {
+				if local {
+					bot, _ := tgbot.NewLocalBot(server.URL, server.Token)
+					return bot
+				}
+				bot, _ := tgbot.NewCustomBot(server.URL, server.Token)
+				return bot
+			}
+			fromServer := tgbottest.NewServer()
+			defer fromServer.Close()
+			fromServer.Handle("logOut", func(json.RawMessage) (interface{}, error) {
+				return true, nil
+			})
+			toServer := tgbottest.NewServer()
+			defer toServer.Close()
+			// The target server accepts the bot on the second getMe.
+			unauthorized := 1
+			toServer.Handle("getMe", func(json.RawMessage) (interface{}, error) {
+				if unauthorized > 0 {
+					unauthorized--
+					return nil, &tgbottest.APIError{Code: 401, Description: "Unauthorized"}
+				}
+				return toServer.Bot, nil
+			})
+
+			// Zero delay is raised to MinMoveRetryDelay instead of spinning.
+			err := tgbot.MoveBot(context.Background(), newBot(fromServer, tc.fromLocal), newBot(toServer, tc.toLocal), 0)
+			if err != nil {
+				t.Fatalf("MoveBot failed: %s", err)
+			}
+			for _, method := range []string{"deleteWebhook", "logOut"} {
+				if calls := fromServer.CallsTo(method); len(calls) != 1 {
+					t.Errorf("Source server got %d %s calls, want 1", len(calls), method)
+				}
+			}
+			if calls := fromServer.CallsTo("close"); len(calls) != 0 {
+				t.Errorf("Source server got close, want logOut only")
+			}
+			if calls := toServer.CallsTo("getMe"); len(calls) != 2 {
+				t.Errorf("Target server got %d getMe calls, want 2", len(calls))
+			}
+			if calls := toServer.CallsTo("logOut"); len(calls) != 0 {
+				t.Errorf("Bot is logged out from the target server")
+			}
+		})
+	}
+}
+
+func TestMoveBotCloudToCloud(t *testing.T) {
+	server := tgbottest.NewServer()
+	defer server.Close()
+	bot, _ := tgbot.NewCustomBot(server.URL, server.Token)
+	if err := tgbot.MoveBot(context.Background(), bot, bot, time.Second); err == nil {
+		t.Errorf("MoveBot moved the bot from the cloud to the cloud")
+	}
+	if calls := server.Calls(); len(calls) != 0 {
+		t.Errorf("Server got %d calls", len(calls))
+	}
+}
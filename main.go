@@ -1,46 +1,117 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/lanseg/tgbot"
 )
 
+var (
+	server      = flag.String("server", "https://api.telegram.org", "Bot API server url")
+	local       = flag.Bool("local", false, "Server is a local Bot API server")
+	moveTo      = flag.String("move_to", "", "Move the bot to this Bot API server and exit")
+	moveToLocal = flag.Bool("move_to_local", true, "Server from --move_to is a local Bot API server")
+	moveTimeout = flag.Duration("move_timeout", 15*time.Minute, "How long to wait for the target server")
+	proxy       = flag.String("proxy", "", "Proxy url: http://, https:// or socks5://")
+	timeout     = flag.Duration("timeout", 30*time.Second, "Request timeout, not counting long polling")
+	tokenFile   = flag.String("token_file", "", "File with the bot token")
+	tokenEnv    = flag.String("token_env", "TELEGRAM_BOT_TOKEN", "Environment variable with the bot token")
+	acksFile    = flag.String("acks_file", "", "File with the last handled updates, to resume polling after restart")
+	apiVersion  = flag.String("server_api_version", "", "Bot API version of the local server as printed by telegram-bot-api --version, checked against "+tgbot.APIVersion)
+)
+
+// readToken takes the token from the file, the environment or, as a last resort, from the
+// command line where it is visible to everyone through ps.
+func readToken() (tgbot.Token, error) {
+	if *tokenFile != "" {
+		return tgbot.TokenFromFile(*tokenFile)
+	}
+	if _, ok := os.LookupEnv(*tokenEnv); ok {
+		return tgbot.TokenFromEnv(*tokenEnv)
+	}
+	if flag.NArg() == 1 {
+		fmt.Println("Warning: token passed as an argument is visible to other users, use --token_file")
+		return tgbot.ParseToken(flag.Arg(0))
+	}
+	return "", fmt.Errorf("No token, use --token_file or %s", *tokenEnv)
+}
+
+func newBot(server string, local bool, token tgbot.Token) (tgbot.TelegramBot, error) {
+	options := []tgbot.BotOption{tgbot.WithTimeout(*timeout)}
+	if *proxy != "" {
+		options = append(options, tgbot.WithProxy(*proxy))
+	}
+	if local {
+		return tgbot.NewLocalBot(server, string(token), options...)
+	}
+	return tgbot.NewCustomBot(server, string(token), options...)
+}
+
 func main() {
-	if len(os.Args) != 2 {
-		fmt.Println("Usage: ./main <bot_api_key>")
+	flag.Parse()
+	token, err := readToken()
+	if err != nil {
+		fmt.Printf("Cannot read token: %s\n", err)
+		fmt.Println("Usage: ./main [flags]")
+		flag.PrintDefaults()
 		os.Exit(1)
 	}
 
-	bot, err := tgbot.NewBot(os.Args[1])
+	if *apiVersion != "" {
+		if err := tgbot.CheckAPIVersion(*apiVersion); err != nil {
+			fmt.Printf("Incompatible server: %s\n", err)
+			os.Exit(1)
+		}
+	}
+
+	bot, err := newBot(*server, *local, token)
 	if err != nil {
 		fmt.Printf("Error while creating bot: %s\n", err)
 		os.Exit(1)
 	}
 
-	api := tgbot.NewTelegramApi(bot)
-	result, err := api.GetUpdates(&tgbot.GetUpdatesRequest{
-		Timeout: 60, // 60 Seconds
-	})
-	if err != nil {
-		fmt.Printf("Could not perform request: %s\n", err)
-		os.Exit(1)
+	if *moveTo != "" {
+		target, err := newBot(*moveTo, *moveToLocal, token)
+		if err != nil {
+			fmt.Printf("Error while creating bot: %s\n", err)
+			os.Exit(1)
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), *moveTimeout)
+		defer cancel()
+		if err := tgbot.MoveBot(ctx, bot, target, 10*time.Second); err != nil {
+			fmt.Printf("Could not move the bot: %s\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Bot moved to %s\n", *moveTo)
+		return
 	}
 
-	for _, upd := range result.Result {
-		msg := upd.Message
+	acks := tgbot.NewMemoryAckStore()
+	if *acksFile != "" {
+		acks = tgbot.NewFileAckStore(*acksFile)
+	}
+	api := tgbot.NewTelegramApi(bot)
+	poller := tgbot.NewPoller(api, tgbot.ContextHandler(api, func(c *tgbot.UpdateContext) {
+		msg := c.Update.Message
+		if msg == nil {
+			return
+		}
 		fmt.Printf("Got new message %d in chat %d: %s\n", msg.MessageID, msg.Chat.ID, msg.Text)
-		result, err := api.SetMessageReaction(&tgbot.SetMessageReactionRequest{
-			MessageID: msg.MessageID,
-			ChatID:    fmt.Sprintf("%d", msg.Chat.ID),
-			Reaction: []*tgbot.ReactionType{
-				{
-					Type:  "emoji",
-					Emoji: "❤",
-				},
-			},
-		})
-		fmt.Printf("Got a response: %s: %s", result, err)
+		err := c.React("❤")
+		fmt.Printf("Reacted to the message: %v\n", err)
+	}))
+	poller.Acks = acks
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := poller.Run(ctx); err != nil && ctx.Err() == nil {
+		fmt.Printf("Could not get updates: %s\n", err)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,31 @@
+package tgbot
+
+// LargestPhoto returns the biggest size of the photo, nil if the message is not a photo.
+func (m *Message) LargestPhoto() *PhotoSize {
+	var largest *PhotoSize
+	for _, size := range m.Photo {
+		if largest == nil || size.Width*size.Height > largest.Width*largest.Height {
+			largest = size
+		}
+	}
+	return largest
+}
+
+// IsCommand reports if the message text starts with a bot command, e.g. "/start".
+func (m *Message) IsCommand() bool {
+	for _, entity := range m.Entities {
+		if entity.Type == "bot_command" && entity.Offset == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Command returns the bot command without the bot username, e.g. "/start" for
+// "/start@bot now", empty if the message is not a command.
+func (m *Message) Command() string {
+	if !m.IsCommand() {
+		return ""
+	}
+	return commandName(m.Text)
+}
@@ -0,0 +1,86 @@
+package tgbot_test
+
+import (
+	"testing"
+
+	"github.com/lanseg/tgbot"
+)
+
+func TestMessageContentType(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		message *tgbot.Message
+		want    tgbot.ContentType
+		service bool
+		fileID  string
+	}{
+		{name: "text", message: &tgbot.Message{Text: "hi"}, want: tgbot.ContentTypeText},
+		{
+			name: "animation",
+			// Animations have the document set as well for the old clients
+			message: &tgbot.Message{Animation: &tgbot.Animation{FileID: "animation"}, Document: &tgbot.Document{FileID: "document"}},
+			want:    tgbot.ContentTypeAnimation,
+			fileID:  "animation",
+		},
+		{
+			name: "photo",
+			message: &tgbot.Message{Photo: []*tgbot.PhotoSize{
+				{FileID: "small", Width: 90, Height: 60},
+				{FileID: "large", Width: 1280, Height: 960},
+				{FileID: "medium", Width: 320, Height: 240},
+			}},
+			want:   tgbot.ContentTypePhoto,
+			fileID: "large",
+		},
+		{name: "voice", message: &tgbot.Message{Voice: &tgbot.Voice{FileID: "voice"}}, want: tgbot.ContentTypeVoice, fileID: "voice"},
+		{name: "location", message: &tgbot.Message{Location: &tgbot.Location{Latitude: 1}}, want: tgbot.ContentTypeLocation},
+		{
+			name:    "new members",
+			message: &tgbot.Message{NewChatMembers: []*tgbot.User{{ID: 1}}},
+			want:    tgbot.ContentTypeNewChatMembers,
+			service: true,
+		},
+		{
+			name:    "pinned",
+			message: &tgbot.Message{PinnedMessage: &tgbot.InaccessibleMessage{MessageID: 1}},
+			want:    tgbot.ContentTypePinnedMessage,
+			service: true,
+		},
+		{
+			name:    "forum topic",
+			message: &tgbot.Message{ForumTopicCreated: &tgbot.ForumTopicCreated{Name: "topic"}},
+			want:    tgbot.ContentTypeForumTopicCreated,
+			service: true,
+		},
+		{name: "unknown", message: &tgbot.Message{MessageID: 1}, want: tgbot.ContentTypeUnknown},
+	} {
+		if got := tc.message.ContentType(); got != tc.want {
+			t.Errorf("%s: ContentType() = %s, want %s", tc.name, got, tc.want)
+		}
+		if got := tc.message.IsService(); got != tc.service {
+			t.Errorf("%s: IsService() = %v", tc.name, got)
+		}
+		file := tc.message.File()
+		if (file == nil && tc.fileID != "") || (file != nil && file.FileID != tc.fileID) {
+			t.Errorf("%s: File() = %+v, want %q", tc.name, file, tc.fileID)
+		}
+	}
+}
+
+func TestMessageCommand(t *testing.T) {
+	command := &tgbot.MessageEntity{Type: "bot_command", Offset: 0, Length: 10}
+	for _, tc := range []struct {
+		message *tgbot.Message
+		want    string
+	}{
+		{message: &tgbot.Message{Text: "/start@bot now", Entities: []*tgbot.MessageEntity{command}}, want: "/start"},
+		{message: &tgbot.Message{Text: "/help", Entities: []*tgbot.MessageEntity{{Type: "bot_command", Length: 5}}}, want: "/help"},
+		// Not a command without the entity or in the middle of the text
+		{message: &tgbot.Message{Text: "/start"}},
+		{message: &tgbot.Message{Text: "see /start", Entities: []*tgbot.MessageEntity{{Type: "bot_command", Offset: 4, Length: 6}}}},
+	} {
+		if got := tc.message.Command(); got != tc.want || tc.message.IsCommand() != (tc.want != "") {
+			t.Errorf("Command() of %q = %q, want %q", tc.message.Text, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package tgbot
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// DefaultBuckets are latency histogram buckets in seconds, up to the usual long polling timeout.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30, 60}
+
+// Counter is a monotonically increasing metric.
+type Counter interface {
+	Add(value float64, labelValues ...string)
+}
+
+// Gauge is a metric that can go up and down.
+type Gauge interface {
+	Add(value float64, labelValues ...string)
+}
+
+// Histogram counts observations in buckets.
+type Histogram interface {
+	Observe(value float64, labelValues ...string)
+}
+
+// MetricsRegistry creates metrics, label values are passed in the same order as label names.
+type MetricsRegistry interface {
+	Counter(name string, help string, labels ...string) Counter
+	Gauge(name string, help string, labels ...string) Gauge
+	Histogram(name string, help string, buckets []float64, labels ...string) Histogram
+}
+
+// BotMetrics are the metrics of the API calls and update processing.
+type BotMetrics struct {
+	calls       Counter
+	errors      Counter
+	rateLimited Counter
+	latency     Histogram
+	inFlight    Gauge
+
+	updates        Counter
+	handlerLatency Histogram
+	handlerPanics  Counter
+}
+
+func NewBotMetrics(registry MetricsRegistry) *BotMetrics {
+	return &BotMetrics{
+		calls: registry.Counter("tgbot_api_calls_total",
+			"Bot API calls by method", "method"),
+		errors: registry.Counter("tgbot_api_errors_total",
+			"Failed Bot API calls by method and error code, \"transport\" for network errors",
+			"method", "code"),
+		rateLimited: registry.Counter("tgbot_api_rate_limited_total",
+			"Bot API calls rejected with 429 Too Many Requests", "method"),
+		latency: registry.Histogram("tgbot_api_call_duration_seconds",
+			"Bot API call latency, including long polling", DefaultBuckets, "method"),
+		inFlight: registry.Gauge("tgbot_api_calls_in_flight",
+			"Bot API calls in progress"),
+		updates: registry.Counter("tgbot_updates_total",
+			"Updates received by type", "type"),
+		handlerLatency: registry.Histogram("tgbot_update_handling_duration_seconds",
+			"Time spent in the update handler by update type", DefaultBuckets, "type"),
+		handlerPanics: registry.Counter("tgbot_update_handler_panics_total",
+			"Update handler panics by update type", "type"),
+	}
+}
+
+// Interceptor counts the calls, errors and measures latency of every Query.
+func (m *BotMetrics) Interceptor() Interceptor {
+	return func(next QueryFunc) QueryFunc {
+		return func(ctx context.Context, apiMethod string, request interface{}) ([]byte, error) {
+			m.inFlight.Add(1)
+			defer m.inFlight.Add(-1)
+
+			start := time.Now()
+			response, err := next(ctx, apiMethod, request)
+			m.latency.Observe(time.Since(start).Seconds(), apiMethod)
+			m.calls.Add(1, apiMethod)
+			if err != nil {
+				m.errors.Add(1, apiMethod, "transport")
+			} else if code, _ := responseError(response); code != 0 {
+				m.errors.Add(1, apiMethod, strconv.FormatInt(code, 10))
+				if code == 429 {
+					m.rateLimited.Add(1, apiMethod)
+				}
+			}
+			return response, err
+		}
+	}
+}
+
+// InstrumentHandler counts updates and measures the handler latency. Panics are counted and
+// passed further.
+func (m *BotMetrics) InstrumentHandler(handler UpdateHandler) UpdateHandler {
+	return func(ctx context.Context, update *Update) {
+		kind := string(update.Kind())
+		m.updates.Add(1, kind)
+		start := time.Now()
+		defer func() {
+			m.handlerLatency.Observe(time.Since(start).Seconds(), kind)
+			if r := recover(); r != nil {
+				m.handlerPanics.Add(1, kind)
+				panic(r)
+			}
+		}()
+		handler(ctx, update)
+	}
+}
@@ -0,0 +1,112 @@
+package tgbot_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lanseg/tgbot"
+)
+
+func metricsText(registry *tgbot.TextRegistry) string {
+	text := &strings.Builder{}
+	registry.WriteText(text)
+	return text.String()
+}
+
+func assertMetrics(t *testing.T, text string, lines ...string) {
+	t.Helper()
+	for _, line := range lines {
+		if !strings.Contains(text, line+"\n") {
+			t.Errorf("Metrics do not contain %q:\n%s", line, text)
+		}
+	}
+}
+
+func TestTextRegistry(t *testing.T) {
+	registry := tgbot.NewTextRegistry()
+	counter := registry.Counter("test_total", "Test counter", "method")
+	counter.Add(1, "getMe")
+	counter.Add(2, "getMe")
+	counter.Add(1, "say \"hi\"")
+	registry.Gauge("test_gauge", "Test gauge").Add(-1)
+	histogram := registry.Histogram("test_seconds", "Test histogram", []float64{1, 0.1})
+	histogram.Observe(0.05)
+	histogram.Observe(0.5)
+	histogram.Observe(5)
+
+	assertMetrics(t, metricsText(registry),
+		"# HELP test_total Test counter",
+		"# TYPE test_total counter",
+		`test_total{method="getMe"} 3`,
+		`test_total{method="say \"hi\""} 1`,
+		"# TYPE test_gauge gauge",
+		"test_gauge -1",
+		"# TYPE test_seconds histogram",
+		`test_seconds_bucket{le="0.1"} 1`,
+		`test_seconds_bucket{le="1"} 2`,
+		`test_seconds_bucket{le="+Inf"} 3`,
+		"test_seconds_sum 5.55",
+		"test_seconds_count 3")
+}
+
+func TestTextRegistryServeHTTP(t *testing.T) {
+	registry := tgbot.NewTextRegistry()
+	registry.Counter("test_total", "Test counter").Add(1)
+	recorder := httptest.NewRecorder()
+	registry.ServeHTTP(recorder, httptest.NewRequest("GET", "/metrics", nil))
+
+	if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain") {
+		t.Errorf("Content-Type is %q", contentType)
+	}
+	assertMetrics(t, recorder.Body.String(), "test_total 1")
+}
+
+func TestMetricsInterceptor(t *testing.T) {
+	registry := tgbot.NewTextRegistry()
+	metrics := tgbot.NewBotMetrics(registry)
+	responses := []*fakeQuery{
+		{response: `{"ok":true,"result":true}`},
+		{response: `{"ok":false,"error_code":429,"description":"Too Many Requests"}`},
+		{err: errors.New("connection refused")},
+	}
+	for _, fake := range responses {
+		tgbot.Chain(fake.query, metrics.Interceptor())(context.Background(), "sendMessage", nil)
+	}
+
+	assertMetrics(t, metricsText(registry),
+		`tgbot_api_calls_total{method="sendMessage"} 3`,
+		`tgbot_api_errors_total{method="sendMessage",code="429"} 1`,
+		`tgbot_api_errors_total{method="sendMessage",code="transport"} 1`,
+		`tgbot_api_rate_limited_total{method="sendMessage"} 1`,
+		`tgbot_api_call_duration_seconds_count{method="sendMessage"} 3`,
+		"tgbot_api_calls_in_flight 0")
+}
+
+func TestInstrumentHandler(t *testing.T) {
+	registry := tgbot.NewTextRegistry()
+	metrics := tgbot.NewBotMetrics(registry)
+	handler := metrics.InstrumentHandler(func(_ context.Context, update *tgbot.Update) {
+		if update.CallbackQuery != nil {
+			panic("callback failed")
+		}
+	})
+
+	handler(context.Background(), &tgbot.Update{Message: &tgbot.Message{Text: "hi"}})
+	func() {
+		defer func() {
+			if r := recover(); r != "callback failed" {
+				t.Errorf("Panic is not passed further: %v", r)
+			}
+		}()
+		handler(context.Background(), &tgbot.Update{CallbackQuery: &tgbot.CallbackQuery{ID: "1"}})
+	}()
+
+	assertMetrics(t, metricsText(registry),
+		`tgbot_updates_total{type="message"} 1`,
+		`tgbot_updates_total{type="callback_query"} 1`,
+		`tgbot_update_handler_panics_total{type="callback_query"} 1`,
+		`tgbot_update_handling_duration_seconds_count{type="message"} 1`)
+}
@@ -0,0 +1,173 @@
+package tgbot
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TextRegistry keeps metrics in memory and serves them in the Prometheus text exposition
+// format.
+type TextRegistry struct {
+	mu      sync.Mutex
+	metrics []*textMetric
+}
+
+type textSeries struct {
+	labelValues []string
+	value       float64
+	buckets     []uint64
+	count       uint64
+}
+
+type textMetric struct {
+	registry *TextRegistry
+	name     string
+	help     string
+	kind     string
+	labels   []string
+	buckets  []float64
+	series   map[string]*textSeries
+}
+
+func NewTextRegistry() *TextRegistry {
+	return &TextRegistry{}
+}
+
+func (r *TextRegistry) register(kind string, name string, help string, buckets []float64, labels []string) *textMetric {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	metric := &textMetric{
+		registry: r,
+		name:     name,
+		help:     help,
+		kind:     kind,
+		labels:   labels,
+		buckets:  buckets,
+		series:   map[string]*textSeries{},
+	}
+	r.metrics = append(r.metrics, metric)
+	return metric
+}
+
+func (r *TextRegistry) Counter(name string, help string, labels ...string) Counter {
+	return r.register("counter", name, help, nil, labels)
+}
+
+func (r *TextRegistry) Gauge(name string, help string, labels ...string) Gauge {
+	return r.register("gauge", name, help, nil, labels)
+}
+
+func (r *TextRegistry) Histogram(name string, help string, buckets []float64, labels ...string) Histogram {
+	sorted := append([]float64{}, buckets...)
+	sort.Float64s(sorted)
+	return r.register("histogram", name, help, sorted, labels)
+}
+
+// getSeries must be called with the registry lock held.
+func (m *textMetric) getSeries(labelValues []string) *textSeries {
+	if len(labelValues) != len(m.labels) {
+		panic(fmt.Sprintf("Metric %s expects labels %v, got values %v", m.name, m.labels, labelValues))
+	}
+	key := strings.Join(labelValues, "\xff")
+	series, ok := m.series[key]
+	if !ok {
+		series = &textSeries{
+			labelValues: append([]string{}, labelValues...),
+			buckets:     make([]uint64, len(m.buckets)),
+		}
+		m.series[key] = series
+	}
+	return series
+}
+
+func (m *textMetric) Add(value float64, labelValues ...string) {
+	m.registry.mu.Lock()
+	defer m.registry.mu.Unlock()
+	m.getSeries(labelValues).value += value
+}
+
+func (m *textMetric) Observe(value float64, labelValues ...string) {
+	m.registry.mu.Lock()
+	defer m.registry.mu.Unlock()
+	series := m.getSeries(labelValues)
+	series.value += value
+	series.count++
+	for i, bound := range m.buckets {
+		if value <= bound {
+			series.buckets[i]++
+		}
+	}
+}
+
+func escapeLabelValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+func formatLabels(names []string, values []string, extraName string, extraValue string) string {
+	pairs := []string{}
+	for i, name := range names {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, name, escapeLabelValue(values[i])))
+	}
+	if extraName != "" {
+		pairs = append(pairs, fmt.Sprintf(`%s="%s"`, extraName, extraValue))
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(value float64) string {
+	if math.IsInf(value, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func (m *textMetric) write(w io.Writer) {
+	fmt.Fprintf(w, "# HELP %s %s\n", m.name, strings.ReplaceAll(m.help, "\n", " "))
+	fmt.Fprintf(w, "# TYPE %s %s\n", m.name, m.kind)
+
+	keys := make([]string, 0, len(m.series))
+	for key := range m.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		series := m.series[key]
+		if m.kind != "histogram" {
+			fmt.Fprintf(w, "%s%s %s\n", m.name, formatLabels(m.labels, series.labelValues, "", ""),
+				formatValue(series.value))
+			continue
+		}
+		for i, bound := range m.buckets {
+			fmt.Fprintf(w, "%s_bucket%s %d\n", m.name,
+				formatLabels(m.labels, series.labelValues, "le", formatValue(bound)), series.buckets[i])
+		}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", m.name,
+			formatLabels(m.labels, series.labelValues, "le", "+Inf"), series.count)
+		labels := formatLabels(m.labels, series.labelValues, "", "")
+		fmt.Fprintf(w, "%s_sum%s %s\n", m.name, labels, formatValue(series.value))
+		fmt.Fprintf(w, "%s_count%s %d\n", m.name, labels, series.count)
+	}
+}
+
+// WriteText writes all metrics in the text exposition format.
+func (r *TextRegistry) WriteText(w io.Writer) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, metric := range r.metrics {
+		metric.write(w)
+	}
+}
+
+func (r *TextRegistry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	r.WriteText(w)
+}
@@ -0,0 +1,130 @@
+package tgbot
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BotOption configures TelegramBotImpl in NewCustomBot.
+type BotOption func(b *TelegramBotImpl) error
+
+// transport returns the transport the options can change. The transport of the client is
+// cloned the first time, so the shared transports like http.DefaultTransport are never changed.
+func (b *TelegramBotImpl) transport() (*http.Transport, error) {
+	if b.ownTransport != nil {
+		return b.ownTransport, nil
+	}
+	shared := b.httpClient.Transport
+	if shared == nil {
+		shared = http.DefaultTransport
+	}
+	transport, ok := shared.(*http.Transport)
+	if !ok {
+		return nil, fmt.Errorf("Cannot configure transport of type %T", shared)
+	}
+	b.ownTransport = transport.Clone()
+	b.httpClient.Transport = b.ownTransport
+	return b.ownTransport, nil
+}
+
+func withTransport(configure func(t *http.Transport) error) BotOption {
+	return func(b *TelegramBotImpl) error {
+		transport, err := b.transport()
+		if err != nil {
+			return err
+		}
+		return configure(transport)
+	}
+}
+
+// WithHTTPClient replaces the http client, transport options given before it are lost. The
+// client is copied, the options given after it do not change the client or its transport.
+func WithHTTPClient(client *http.Client) BotOption {
+	return func(b *TelegramBotImpl) error {
+		copied := *client
+		b.httpClient = &copied
+		b.ownTransport = nil
+		return nil
+	}
+}
+
+// WithTimeout limits the time of a single Query. The long polling timeout of getUpdates is
+// added on top, so the long polling requests are not interrupted too early.
+func WithTimeout(timeout time.Duration) BotOption {
+	return func(b *TelegramBotImpl) error {
+		b.timeout = timeout
+		return nil
+	}
+}
+
+// WithProxy sends all requests through the proxy: http://, https:// or socks5:// url. The
+// socks5h:// proxies are not supported before Go 1.22, so they are rejected.
+func WithProxy(proxy string) BotOption {
+	return withTransport(func(t *http.Transport) error {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			return err
+		}
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return fmt.Errorf("Unsupported proxy scheme \"%s\"", proxyURL.Scheme)
+		}
+		t.Proxy = http.ProxyURL(proxyURL)
+		return nil
+	})
+}
+
+// WithUserAgent sets the User-Agent header for all requests.
+func WithUserAgent(userAgent string) BotOption {
+	return func(b *TelegramBotImpl) error {
+		b.userAgent = userAgent
+		return nil
+	}
+}
+
+// WithRootCAs replaces the system root certificates, e.g. for the corporate TLS proxies.
+func WithRootCAs(roots *x509.CertPool) BotOption {
+	return withTransport(func(t *http.Transport) error {
+		if t.TLSClientConfig == nil {
+			t.TLSClientConfig = &tls.Config{}
+		}
+		t.TLSClientConfig.RootCAs = roots
+		return nil
+	})
+}
+
+// WithKeepAlive sets the interval of the TCP keep-alive probes, negative value disables them.
+func WithKeepAlive(keepAlive time.Duration) BotOption {
+	return withTransport(func(t *http.Transport) error {
+		t.DialContext = (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: keepAlive,
+		}).DialContext
+		return nil
+	})
+}
+
+// WithIdleConnections tunes the connection pool: how many idle connections to keep and for
+// how long.
+func WithIdleConnections(maxIdle int, idleTimeout time.Duration) BotOption {
+	return withTransport(func(t *http.Transport) error {
+		t.MaxIdleConns = maxIdle
+		t.MaxIdleConnsPerHost = maxIdle
+		t.IdleConnTimeout = idleTimeout
+		return nil
+	})
+}
+
+// WithLocalServer marks the server as a local Bot API server, see NewLocalBot.
+func WithLocalServer() BotOption {
+	return func(b *TelegramBotImpl) error {
+		b.local = true
+		return nil
+	}
+}
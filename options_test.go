@@ -0,0 +1,105 @@
+package tgbot_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lanseg/tgbot"
+	"github.com/lanseg/tgbot/tgbottest"
+)
+
+// recordingServer answers every request with an empty successful response.
+type recordingServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	requests []*http.Request
+}
+
+func newRecordingServer(t *testing.T, delay time.Duration) *recordingServer {
+	server := &recordingServer{}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		server.mu.Lock()
+		server.requests = append(server.requests, r)
+		server.mu.Unlock()
+		time.Sleep(delay)
+		w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (s *recordingServer) lastRequest(t *testing.T) *http.Request {
+	t.Helper()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.requests) == 0 {
+		t.Fatalf("Server got no requests")
+	}
+	return s.requests[len(s.requests)-1]
+}
+
+func TestWithProxy(t *testing.T) {
+	proxy := newRecordingServer(t, 0)
+	bot, err := tgbot.NewCustomBot("http://api.telegram.invalid", tgbottest.Token,
+		tgbot.WithProxy(proxy.URL), tgbot.WithUserAgent("test-agent"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tgbot.NewTelegramApi(bot).DeleteWebhook(&tgbot.DeleteWebhookRequest{}); err != nil {
+		t.Fatalf("Request through the proxy failed: %s", err)
+	}
+	request := proxy.lastRequest(t)
+	if request.Host != "api.telegram.invalid" || !strings.HasSuffix(request.URL.Path, "/DeleteWebhook") {
+		t.Errorf("Proxy got request to %s%s", request.Host, request.URL.Path)
+	}
+	if agent := request.Header.Get("User-Agent"); agent != "test-agent" {
+		t.Errorf("User-Agent is %q", agent)
+	}
+}
+
+func TestWithProxyRejectsScheme(t *testing.T) {
+	for _, proxy := range []string{"socks5h://localhost:1080", "ftp://localhost"} {
+		if _, err := tgbot.NewCustomBot("http://localhost", tgbottest.Token, tgbot.WithProxy(proxy)); err == nil {
+			t.Errorf("Proxy %s is accepted", proxy)
+		}
+	}
+}
+
+func TestOptionsDoNotChangeSharedClient(t *testing.T) {
+	transport := &http.Transport{}
+	client := &http.Client{Transport: transport}
+	for _, shared := range []*http.Client{http.DefaultClient, client} {
+		before := shared.Transport
+		_, err := tgbot.NewCustomBot("http://localhost", tgbottest.Token,
+			tgbot.WithHTTPClient(shared), tgbot.WithProxy("http://localhost:3128"),
+			tgbot.WithIdleConnections(1, time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if shared.Transport != before {
+			t.Errorf("Transport of the shared client is replaced")
+		}
+	}
+	if transport.Proxy != nil || transport.MaxIdleConns != 0 {
+		t.Errorf("Shared transport is changed")
+	}
+	if http.DefaultTransport.(*http.Transport).MaxIdleConns == 1 {
+		t.Errorf("http.DefaultTransport is changed")
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	server := newRecordingServer(t, 200*time.Millisecond)
+	bot, err := tgbot.NewCustomBot(server.URL, tgbottest.Token, tgbot.WithTimeout(20*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tgbot.NewTelegramApi(bot).DeleteWebhook(&tgbot.DeleteWebhookRequest{}); err == nil {
+		t.Errorf("Slow request did not time out")
+	}
+}
@@ -0,0 +1,81 @@
+package passport
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+)
+
+// parsePrivateKey reads the RSA key in the PKCS#1 ("RSA PRIVATE KEY") or PKCS#8 ("PRIVATE KEY")
+// PEM block.
+func parsePrivateKey(pemKey []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(pemKey)
+	if block == nil {
+		return nil, fmt.Errorf("Cannot find a PEM block in the private key")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot parse private key: %s", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("Private key is %T, not an RSA key", key)
+	}
+	return rsaKey, nil
+}
+
+// decryptSecret decrypts the credentials secret encrypted with the bot public key.
+func decryptSecret(key *rsa.PrivateKey, secret string) ([]byte, error) {
+	encrypted, err := decodeBase64("credentials secret", secret)
+	if err != nil {
+		return nil, err
+	}
+	decrypted, err := rsa.DecryptOAEP(sha1.New(), nil, key, encrypted, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot decrypt credentials secret: %s", err)
+	}
+	return decrypted, nil
+}
+
+func decodeBase64(name string, value string) ([]byte, error) {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot decode %s: %s", name, err)
+	}
+	return decoded, nil
+}
+
+// decryptData decrypts the credentials, the element data or a file: AES-256-CBC with the key and
+// the iv from SHA-512 of the secret and the hash, the SHA-256 of the result must be the hash and
+// its first byte is the length of the random padding.
+func decryptData(encrypted []byte, secret []byte, hash []byte) ([]byte, error) {
+	if len(encrypted) == 0 || len(encrypted)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("Encrypted data length %d is not a multiple of %d", len(encrypted), aes.BlockSize)
+	}
+	secretHash := sha512.Sum512(append(append([]byte{}, secret...), hash...))
+	block, err := aes.NewCipher(secretHash[:32])
+	if err != nil {
+		return nil, fmt.Errorf("Cannot create cipher: %s", err)
+	}
+	decrypted := make([]byte, len(encrypted))
+	cipher.NewCBCDecrypter(block, secretHash[32:48]).CryptBlocks(decrypted, encrypted)
+	if dataHash := sha256.Sum256(decrypted); !bytes.Equal(dataHash[:], hash) {
+		return nil, fmt.Errorf("Decrypted data hash does not match")
+	}
+	padding := int(decrypted[0])
+	if padding < 32 || padding > len(decrypted) {
+		return nil, fmt.Errorf("Decrypted data has bad padding length %d", padding)
+	}
+	return decrypted[padding:], nil
+}
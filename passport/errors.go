@@ -0,0 +1,79 @@
+package passport
+
+import "github.com/lanseg/tgbot"
+
+// NewErrorsRequest asks the user to fix the errors with setPassportDataErrors, the user cannot
+// share the passport again until they are fixed.
+func NewErrorsRequest(userID int64, errors ...tgbot.PassportElementError) *tgbot.SetPassportDataErrorsRequest {
+	return &tgbot.SetPassportDataErrorsRequest{UserID: userID, Errors: errors}
+}
+
+func fileHash(file *File) string {
+	if file == nil {
+		return ""
+	}
+	return file.Hash()
+}
+
+func fileHashes(files []*File) []string {
+	hashes := []string{}
+	for _, file := range files {
+		hashes = append(hashes, file.Hash())
+	}
+	return hashes
+}
+
+// DataFieldError reports the wrong value of the data field, e.g. "first_name" of the
+// "personal_details" or "document_no" of the "passport".
+func (e *Element) DataFieldError(fieldName string, message string) tgbot.PassportElementError {
+	return &tgbot.PassportElementErrorDataField{
+		Type:      e.Type,
+		FieldName: fieldName,
+		DataHash:  e.dataHash,
+		Message:   message,
+	}
+}
+
+// FrontSideError reports the problem with the front side of the document.
+func (e *Element) FrontSideError(message string) tgbot.PassportElementError {
+	return &tgbot.PassportElementErrorFrontSide{Type: e.Type, FileHash: fileHash(e.FrontSide), Message: message}
+}
+
+// ReverseSideError reports the problem with the reverse side of the document.
+func (e *Element) ReverseSideError(message string) tgbot.PassportElementError {
+	return &tgbot.PassportElementErrorReverseSide{Type: e.Type, FileHash: fileHash(e.ReverseSide), Message: message}
+}
+
+// SelfieError reports the problem with the selfie with the document.
+func (e *Element) SelfieError(message string) tgbot.PassportElementError {
+	return &tgbot.PassportElementErrorSelfie{Type: e.Type, FileHash: fileHash(e.Selfie), Message: message}
+}
+
+// FileError reports the problem with one of the scans in Files.
+func (e *Element) FileError(file *File, message string) tgbot.PassportElementError {
+	return &tgbot.PassportElementErrorFile{Type: e.Type, FileHash: fileHash(file), Message: message}
+}
+
+// FilesError reports the problem with all the scans in Files.
+func (e *Element) FilesError(message string) tgbot.PassportElementError {
+	return &tgbot.PassportElementErrorFiles{Type: e.Type, FileHashes: fileHashes(e.Files), Message: message}
+}
+
+// TranslationFileError reports the problem with one of the files in Translation.
+func (e *Element) TranslationFileError(file *File, message string) tgbot.PassportElementError {
+	return &tgbot.PassportElementErrorTranslationFile{Type: e.Type, FileHash: fileHash(file), Message: message}
+}
+
+// TranslationFilesError reports the problem with all the files in Translation.
+func (e *Element) TranslationFilesError(message string) tgbot.PassportElementError {
+	return &tgbot.PassportElementErrorTranslationFiles{
+		Type:       e.Type,
+		FileHashes: fileHashes(e.Translation),
+		Message:    message,
+	}
+}
+
+// UnspecifiedError reports the problem with the element as a whole.
+func (e *Element) UnspecifiedError(message string) tgbot.PassportElementError {
+	return &tgbot.PassportElementErrorUnspecified{Type: e.Type, ElementHash: e.Hash, Message: message}
+}
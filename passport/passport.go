@@ -0,0 +1,355 @@
+// Package passport decrypts the Telegram Passport data shared with the bot.
+//
+//	decryptor, err := passport.NewDecryptor(pemKey)
+//	data, err := decryptor.Decrypt(message.PassportData)
+//	if data.Nonce != expectedNonce { ... }
+//	details := data.PersonalDetails()
+//	front, err := data.Element("passport").FrontSide.Download(ctx, api)
+package passport
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/lanseg/tgbot"
+)
+
+// DateLayout is the layout of the dates in the decrypted data, e.g. "31.12.1990".
+const DateLayout = "02.01.2006"
+
+// PersonalDetails is the data of the "personal_details" element.
+type PersonalDetails struct {
+	FirstName            string `json:"first_name"`
+	LastName             string `json:"last_name"`
+	MiddleName           string `json:"middle_name,omitempty"`
+	BirthDate            string `json:"birth_date"`
+	Gender               string `json:"gender"`
+	CountryCode          string `json:"country_code"`
+	ResidenceCountryCode string `json:"residence_country_code"`
+	FirstNameNative      string `json:"first_name_native"`
+	LastNameNative       string `json:"last_name_native"`
+	MiddleNameNative     string `json:"middle_name_native,omitempty"`
+}
+
+// BirthTime parses the BirthDate.
+func (d *PersonalDetails) BirthTime() (time.Time, error) {
+	return time.Parse(DateLayout, d.BirthDate)
+}
+
+// IDDocumentData is the data of the "passport", "driver_license", "identity_card" and
+// "internal_passport" elements.
+type IDDocumentData struct {
+	DocumentNo string `json:"document_no"`
+	ExpiryDate string `json:"expiry_date,omitempty"`
+}
+
+// ExpiryTime parses the ExpiryDate, zero time if the document does not expire.
+func (d *IDDocumentData) ExpiryTime() (time.Time, error) {
+	if d.ExpiryDate == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(DateLayout, d.ExpiryDate)
+}
+
+// ResidentialAddress is the data of the "address" element.
+type ResidentialAddress struct {
+	StreetLine1 string `json:"street_line1"`
+	StreetLine2 string `json:"street_line2,omitempty"`
+	City        string `json:"city"`
+	State       string `json:"state,omitempty"`
+	CountryCode string `json:"country_code"`
+	PostCode    string `json:"post_code"`
+}
+
+// File is a passport file together with the credentials to decrypt it.
+type File struct {
+	*tgbot.PassportFile
+
+	hash   []byte
+	secret []byte
+}
+
+// Hash returns the base64 file hash the element errors refer to.
+func (f *File) Hash() string {
+	return base64.StdEncoding.EncodeToString(f.hash)
+}
+
+// Decrypt decrypts the contents of the downloaded file and verifies its hash.
+func (f *File) Decrypt(encrypted []byte) ([]byte, error) {
+	decrypted, err := decryptData(encrypted, f.secret, f.hash)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot decrypt file %s: %s", f.FileID, err)
+	}
+	return decrypted, nil
+}
+
+// Download downloads the file with getFile and decrypts it, usually a jpg image.
+func (f *File) Download(ctx context.Context, api *tgbot.TelegramApi) ([]byte, error) {
+	content, err := api.DownloadFile(ctx, f.FileID)
+	if err != nil {
+		return nil, err
+	}
+	defer content.Close()
+	encrypted, err := io.ReadAll(content)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot download file %s: %s", f.FileID, err)
+	}
+	return f.Decrypt(encrypted)
+}
+
+// Element is a decrypted EncryptedPassportElement, only the fields of its type are set.
+type Element struct {
+	// Type is the element type, e.g. "passport" or "utility_bill"
+	Type string
+	// Hash is the base64 element hash for the unspecified errors
+	Hash string
+
+	PersonalDetails *PersonalDetails
+	Document        *IDDocumentData
+	Address         *ResidentialAddress
+	PhoneNumber     string
+	Email           string
+
+	FrontSide   *File
+	ReverseSide *File
+	Selfie      *File
+	Files       []*File
+	Translation []*File
+
+	// dataHash is the base64 hash of the data for the data field errors
+	dataHash string
+}
+
+// Passport is the decrypted PassportData.
+type Passport struct {
+	// Nonce is the nonce of the passport request, the bot must check it is the one it sent
+	Nonce    string
+	Elements []*Element
+}
+
+// Element returns the element of the type, nil if the user did not share it.
+func (p *Passport) Element(elementType string) *Element {
+	for _, element := range p.Elements {
+		if element.Type == elementType {
+			return element
+		}
+	}
+	return nil
+}
+
+// PersonalDetails returns the data of the "personal_details" element, nil if it is not shared.
+func (p *Passport) PersonalDetails() *PersonalDetails {
+	if element := p.Element("personal_details"); element != nil {
+		return element.PersonalDetails
+	}
+	return nil
+}
+
+// Address returns the data of the "address" element, nil if it is not shared.
+func (p *Passport) Address() *ResidentialAddress {
+	if element := p.Element("address"); element != nil {
+		return element.Address
+	}
+	return nil
+}
+
+type dataCredentials struct {
+	DataHash string `json:"data_hash"`
+	Secret   string `json:"secret"`
+}
+
+type fileCredentials struct {
+	FileHash string `json:"file_hash"`
+	Secret   string `json:"secret"`
+}
+
+type secureValue struct {
+	Data        *dataCredentials   `json:"data"`
+	FrontSide   *fileCredentials   `json:"front_side"`
+	ReverseSide *fileCredentials   `json:"reverse_side"`
+	Selfie      *fileCredentials   `json:"selfie"`
+	Translation []*fileCredentials `json:"translation"`
+	Files       []*fileCredentials `json:"files"`
+}
+
+type credentials struct {
+	SecureData map[string]*secureValue `json:"secure_data"`
+	Nonce      string                  `json:"nonce"`
+}
+
+// Decryptor decrypts the passport data with the bot private key, the public key of which is
+// set with /setpublickey in @BotFather.
+type Decryptor struct {
+	key *rsa.PrivateKey
+}
+
+// NewDecryptor reads the RSA private key in the PEM format.
+func NewDecryptor(pemKey []byte) (*Decryptor, error) {
+	key, err := parsePrivateKey(pemKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Decryptor{key: key}, nil
+}
+
+// Decrypt decrypts the credentials and then the data and the file credentials of the elements,
+// failing if any hash does not match.
+func (d *Decryptor) Decrypt(data *tgbot.PassportData) (*Passport, error) {
+	if data == nil || data.Credentials == nil {
+		return nil, fmt.Errorf("Passport data has no credentials")
+	}
+	creds, err := d.decryptCredentials(data.Credentials)
+	if err != nil {
+		return nil, err
+	}
+	result := &Passport{Nonce: creds.Nonce}
+	for _, encrypted := range data.Data {
+		element, err := decryptElement(encrypted, creds.SecureData[encrypted.Type])
+		if err != nil {
+			return nil, fmt.Errorf("Cannot decrypt %s: %s", encrypted.Type, err)
+		}
+		result.Elements = append(result.Elements, element)
+	}
+	return result, nil
+}
+
+func (d *Decryptor) decryptCredentials(encrypted *tgbot.EncryptedCredentials) (*credentials, error) {
+	secret, err := decryptSecret(d.key, encrypted.Secret)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := decodeBase64("credentials hash", encrypted.Hash)
+	if err != nil {
+		return nil, err
+	}
+	data, err := decodeBase64("credentials data", encrypted.Data)
+	if err != nil {
+		return nil, err
+	}
+	decrypted, err := decryptData(data, secret, hash)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot decrypt credentials: %s", err)
+	}
+	creds := &credentials{}
+	if err := json.Unmarshal(decrypted, creds); err != nil {
+		return nil, fmt.Errorf("Cannot parse credentials: %s", err)
+	}
+	return creds, nil
+}
+
+// elementData returns the value the data of the element type is decoded into.
+func (e *Element) elementData() interface{} {
+	switch e.Type {
+	case "personal_details":
+		e.PersonalDetails = &PersonalDetails{}
+		return e.PersonalDetails
+	case "passport", "driver_license", "identity_card", "internal_passport":
+		e.Document = &IDDocumentData{}
+		return e.Document
+	case "address":
+		e.Address = &ResidentialAddress{}
+		return e.Address
+	}
+	return nil
+}
+
+func decryptElement(encrypted *tgbot.EncryptedPassportElement, creds *secureValue) (*Element, error) {
+	element := &Element{
+		Type:        encrypted.Type,
+		Hash:        encrypted.Hash,
+		PhoneNumber: encrypted.PhoneNumber,
+		Email:       encrypted.Email,
+	}
+	if creds == nil {
+		creds = &secureValue{}
+	}
+	if encrypted.Data != "" {
+		if err := element.decryptData(encrypted.Data, creds.Data); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	if element.FrontSide, err = newFile(encrypted.FrontSide, creds.FrontSide); err != nil {
+		return nil, err
+	}
+	if element.ReverseSide, err = newFile(encrypted.ReverseSide, creds.ReverseSide); err != nil {
+		return nil, err
+	}
+	if element.Selfie, err = newFile(encrypted.Selfie, creds.Selfie); err != nil {
+		return nil, err
+	}
+	if element.Files, err = newFiles(encrypted.Files, creds.Files); err != nil {
+		return nil, err
+	}
+	if element.Translation, err = newFiles(encrypted.Translation, creds.Translation); err != nil {
+		return nil, err
+	}
+	return element, nil
+}
+
+func (e *Element) decryptData(data string, creds *dataCredentials) error {
+	if creds == nil {
+		return fmt.Errorf("No credentials for the data")
+	}
+	encrypted, err := decodeBase64("data", data)
+	if err != nil {
+		return err
+	}
+	secret, err := decodeBase64("data secret", creds.Secret)
+	if err != nil {
+		return err
+	}
+	hash, err := decodeBase64("data hash", creds.DataHash)
+	if err != nil {
+		return err
+	}
+	decrypted, err := decryptData(encrypted, secret, hash)
+	if err != nil {
+		return fmt.Errorf("Cannot decrypt data: %s", err)
+	}
+	e.dataHash = creds.DataHash
+	if value := e.elementData(); value != nil {
+		if err := json.Unmarshal(decrypted, value); err != nil {
+			return fmt.Errorf("Cannot parse data: %s", err)
+		}
+	}
+	return nil
+}
+
+func newFile(file *tgbot.PassportFile, creds *fileCredentials) (*File, error) {
+	if file == nil {
+		return nil, nil
+	}
+	if creds == nil {
+		return nil, fmt.Errorf("No credentials for file %s", file.FileID)
+	}
+	secret, err := decodeBase64("file secret", creds.Secret)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := decodeBase64("file hash", creds.FileHash)
+	if err != nil {
+		return nil, err
+	}
+	return &File{PassportFile: file, hash: hash, secret: secret}, nil
+}
+
+func newFiles(files []*tgbot.PassportFile, creds []*fileCredentials) ([]*File, error) {
+	if len(files) != len(creds) {
+		return nil, fmt.Errorf("Got %d files with %d credentials", len(files), len(creds))
+	}
+	var result []*File
+	for i, file := range files {
+		decrypted, err := newFile(file, creds[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, decrypted)
+	}
+	return result, nil
+}
@@ -0,0 +1,482 @@
+package passport
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lanseg/tgbot"
+)
+
+var (
+	keyOnce sync.Once
+	testKey *rsa.PrivateKey
+)
+
+// privateKey generates the bot key once for all the tests.
+func privateKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	keyOnce.Do(func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatalf("Cannot generate key: %s", err)
+		}
+		testKey = key
+	})
+	return testKey
+}
+
+func unhex(t *testing.T, value string) []byte {
+	t.Helper()
+	decoded, err := hex.DecodeString(value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decoded
+}
+
+func encode(data []byte) string {
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// encryptPadded encrypts the data as is, the first byte must be the padding length.
+func encryptPadded(t *testing.T, padded []byte, secret []byte) (encrypted []byte, hash []byte) {
+	t.Helper()
+	dataHash := sha256.Sum256(padded)
+	secretHash := sha512.Sum512(append(append([]byte{}, secret...), dataHash[:]...))
+	block, err := aes.NewCipher(secretHash[:32])
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted = make([]byte, len(padded))
+	cipher.NewCBCEncrypter(block, secretHash[32:48]).CryptBlocks(encrypted, padded)
+	return encrypted, dataHash[:]
+}
+
+// encrypt adds the random padding of 32 to 255 bytes like Telegram does and encrypts the data with
+// a new secret.
+func encrypt(t *testing.T, data []byte) (encrypted []byte, secret []byte, hash []byte) {
+	t.Helper()
+	padding := 32 + (aes.BlockSize-(32+len(data))%aes.BlockSize)%aes.BlockSize
+	padded := make([]byte, padding+len(data))
+	secret = make([]byte, 32)
+	if _, err := rand.Read(padded[:padding]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rand.Read(secret); err != nil {
+		t.Fatal(err)
+	}
+	padded[0] = byte(padding)
+	copy(padded[padding:], data)
+	encrypted, hash = encryptPadded(t, padded, secret)
+	return encrypted, secret, hash
+}
+
+func TestDecryptDataKnownVector(t *testing.T) {
+	secret := make([]byte, 32)
+	for i := range secret {
+		secret[i] = byte(i)
+	}
+	hash := unhex(t, "f3feeaab74e521b73c7521867638a97abdcd69e22ad114100650857b3402045e")
+	encrypted := unhex(t, "d66711c253a9d62e83b35338c8618175c43903c9baed05ef9cb68ce5f4e316dd"+
+		"d322f64d2718248d9d4382b88707eaaee40c1f71df939f768eadb9bbf46bc20e")
+
+	decrypted, err := decryptData(encrypted, secret, hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(decrypted) != `{"document_no":"123"}` {
+		t.Errorf("Decrypted %q", decrypted)
+	}
+}
+
+func TestDecryptDataErrors(t *testing.T) {
+	data := []byte(`{"document_no":"123"}`)
+	encrypted, secret, hash := encrypt(t, data)
+
+	tampered := append([]byte{}, encrypted...)
+	tampered[len(tampered)-1] ^= 1
+	otherSecret := append([]byte{}, secret...)
+	otherSecret[0] ^= 1
+	// The hash matches, but the padding is shorter than 32 bytes.
+	shortPadding := append([]byte{16}, bytes.Repeat([]byte{0}, 15)...)
+	shortPadding = append(shortPadding, "0123456789abcdef"...)
+	shortEncrypted, shortHash := encryptPadded(t, shortPadding, secret)
+	// The padding is longer than the data.
+	longPadding := append([]byte{255}, bytes.Repeat([]byte{0}, 63)...)
+	longEncrypted, longHash := encryptPadded(t, longPadding, secret)
+
+	for _, tc := range []struct {
+		name      string
+		encrypted []byte
+		secret    []byte
+		hash      []byte
+		want      string
+	}{
+		{name: "tampered data", encrypted: tampered, secret: secret, hash: hash, want: "hash does not match"},
+		{name: "wrong secret", encrypted: encrypted, secret: otherSecret, hash: hash, want: "hash does not match"},
+		{name: "short padding", encrypted: shortEncrypted, secret: secret, hash: shortHash, want: "bad padding length 16"},
+		{name: "long padding", encrypted: longEncrypted, secret: secret, hash: longHash, want: "bad padding length 255"},
+		{name: "partial block", encrypted: encrypted[:20], secret: secret, hash: hash, want: "not a multiple of 16"},
+		{name: "empty", secret: secret, hash: hash, want: "not a multiple of 16"},
+	} {
+		if _, err := decryptData(tc.encrypted, tc.secret, tc.hash); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: got error %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key := privateKey(t)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPkcs8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name    string
+		pem     []byte
+		wantErr string
+	}{
+		{
+			name: "pkcs1",
+			pem:  pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		},
+		{
+			name: "pkcs8",
+			pem:  pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}),
+		},
+		{
+			name:    "ecdsa",
+			pem:     pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecPkcs8}),
+			wantErr: "not an RSA key",
+		},
+		{
+			name:    "broken key",
+			pem:     pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("key")}),
+			wantErr: "Cannot parse private key",
+		},
+		{name: "not pem", pem: []byte("key"), wantErr: "Cannot find a PEM block"},
+	} {
+		decryptor, err := NewDecryptor(tc.pem)
+		if tc.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("%s: got error %v, want %q", tc.name, err, tc.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tc.name, err)
+		} else if !decryptor.key.Equal(key) {
+			t.Errorf("%s: parsed a different key", tc.name)
+		}
+	}
+}
+
+// testPassport is the encrypted PassportData with the personal details, the passport with a front
+// side, a selfie and a translation and the utility bill with two files.
+type testPassport struct {
+	data *tgbot.PassportData
+	// files are the encrypted file contents by the file id
+	files map[string][]byte
+	// hashes are the base64 hashes of the files by the file id and of the data by the element type
+	hashes map[string]string
+}
+
+// encryptFile encrypts the file with its id as the content.
+func encryptFile(t *testing.T, p *testPassport, fileID string) (*tgbot.PassportFile, *fileCredentials) {
+	t.Helper()
+	encrypted, secret, hash := encrypt(t, []byte(fileID))
+	p.files[fileID] = encrypted
+	p.hashes[fileID] = encode(hash)
+	return &tgbot.PassportFile{FileID: fileID, FileUniqueID: fileID}, &fileCredentials{
+		FileHash: encode(hash),
+		Secret:   encode(secret),
+	}
+}
+
+func encryptElement(t *testing.T, p *testPassport, elementType string, data interface{}) (*tgbot.EncryptedPassportElement, *secureValue) {
+	t.Helper()
+	plain, err := json.Marshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, secret, hash := encrypt(t, plain)
+	p.hashes[elementType] = encode(hash)
+	return &tgbot.EncryptedPassportElement{
+		Type: elementType,
+		Data: encode(encrypted),
+		Hash: encode([]byte(elementType + " hash")),
+	}, &secureValue{
+		Data: &dataCredentials{DataHash: encode(hash), Secret: encode(secret)},
+	}
+}
+
+func newTestPassport(t *testing.T, nonce string) *testPassport {
+	t.Helper()
+	p := &testPassport{files: map[string][]byte{}, hashes: map[string]string{}}
+	details, detailsCreds := encryptElement(t, p, "personal_details", &PersonalDetails{
+		FirstName: "Ivan",
+		LastName:  "Petrov",
+		BirthDate: "31.12.1990",
+	})
+	document, documentCreds := encryptElement(t, p, "passport", &IDDocumentData{DocumentNo: "123"})
+	document.FrontSide, documentCreds.FrontSide = encryptFile(t, p, "front")
+	document.Selfie, documentCreds.Selfie = encryptFile(t, p, "selfie")
+	translation, translationCreds := encryptFile(t, p, "translation")
+	document.Translation = []*tgbot.PassportFile{translation}
+	documentCreds.Translation = []*fileCredentials{translationCreds}
+	bill := &tgbot.EncryptedPassportElement{Type: "utility_bill", Hash: encode([]byte("utility_bill hash"))}
+	billCreds := &secureValue{}
+	for _, fileID := range []string{"bill1", "bill2"} {
+		file, creds := encryptFile(t, p, fileID)
+		bill.Files = append(bill.Files, file)
+		billCreds.Files = append(billCreds.Files, creds)
+	}
+
+	plain, err := json.Marshal(&credentials{
+		SecureData: map[string]*secureValue{
+			"personal_details": detailsCreds,
+			"passport":         documentCreds,
+			"utility_bill":     billCreds,
+		},
+		Nonce: nonce,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	encrypted, secret, hash := encrypt(t, plain)
+	encryptedSecret, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, &privateKey(t).PublicKey, secret, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.data = &tgbot.PassportData{
+		Data: []*tgbot.EncryptedPassportElement{details, document, bill},
+		Credentials: &tgbot.EncryptedCredentials{
+			Data:   encode(encrypted),
+			Hash:   encode(hash),
+			Secret: encode(encryptedSecret),
+		},
+	}
+	return p
+}
+
+func testDecryptor(t *testing.T) *Decryptor {
+	t.Helper()
+	key := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey(t))})
+	decryptor, err := NewDecryptor(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return decryptor
+}
+
+func TestDecrypt(t *testing.T) {
+	p := newTestPassport(t, "nonce")
+	passport, err := testDecryptor(t).Decrypt(p.data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if passport.Nonce != "nonce" {
+		t.Errorf("Nonce is %q", passport.Nonce)
+	}
+	details := passport.PersonalDetails()
+	if details == nil || details.FirstName != "Ivan" || details.LastName != "Petrov" {
+		t.Fatalf("Personal details are %+v", details)
+	}
+	if birth, err := details.BirthTime(); err != nil || birth.Year() != 1990 {
+		t.Errorf("Birth time is %s, %v", birth, err)
+	}
+	document := passport.Element("passport")
+	if document == nil || document.Document == nil || document.Document.DocumentNo != "123" {
+		t.Fatalf("Passport element is %+v", document)
+	}
+	if passport.Address() != nil {
+		t.Errorf("Got the address that is not shared")
+	}
+
+	for _, file := range []*File{document.FrontSide, document.Selfie, document.Translation[0]} {
+		content, err := file.Decrypt(p.files[file.FileID])
+		if err != nil {
+			t.Errorf("Cannot decrypt %s: %s", file.FileID, err)
+		} else if string(content) != file.FileID {
+			t.Errorf("File %s is %q", file.FileID, content)
+		}
+	}
+	tampered := append([]byte{}, p.files["selfie"]...)
+	tampered[0] ^= 1
+	if _, err := document.Selfie.Decrypt(tampered); err == nil || !strings.Contains(err.Error(), "hash does not match") {
+		t.Errorf("Tampered file decrypted with %v", err)
+	}
+	// The files of the other element have other secrets.
+	if _, err := document.Selfie.Decrypt(p.files["front"]); err == nil {
+		t.Errorf("Decrypted the front side with the selfie credentials")
+	}
+}
+
+func TestDecryptErrors(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherDecryptor := &Decryptor{key: otherKey}
+
+	for _, tc := range []struct {
+		name      string
+		decryptor *Decryptor
+		change    func(data *tgbot.PassportData)
+		want      string
+	}{
+		{
+			name:      "other key",
+			decryptor: otherDecryptor,
+			want:      "Cannot decrypt credentials secret",
+		},
+		{
+			name: "credentials hash",
+			change: func(data *tgbot.PassportData) {
+				data.Credentials.Hash = encode(make([]byte, 32))
+			},
+			want: "Cannot decrypt credentials: Decrypted data hash does not match",
+		},
+		{
+			name: "element data",
+			change: func(data *tgbot.PassportData) {
+				data.Data[0].Data, data.Data[1].Data = data.Data[1].Data, data.Data[0].Data
+			},
+			want: "Cannot decrypt personal_details: Cannot decrypt data: Decrypted data hash does not match",
+		},
+		{
+			name: "file without credentials",
+			change: func(data *tgbot.PassportData) {
+				data.Data[1].ReverseSide = &tgbot.PassportFile{FileID: "reverse"}
+			},
+			want: "No credentials for file reverse",
+		},
+		{
+			name: "no credentials",
+			change: func(data *tgbot.PassportData) {
+				data.Credentials = nil
+			},
+			want: "Passport data has no credentials",
+		},
+	} {
+		p := newTestPassport(t, "nonce")
+		decryptor := tc.decryptor
+		if decryptor == nil {
+			decryptor = testDecryptor(t)
+		}
+		if tc.change != nil {
+			tc.change(p.data)
+		}
+		if _, err := decryptor.Decrypt(p.data); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("%s: got error %v, want %q", tc.name, err, tc.want)
+		}
+	}
+}
+
+func TestElementErrors(t *testing.T) {
+	p := newTestPassport(t, "nonce")
+	passport, err := testDecryptor(t).Decrypt(p.data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	details := passport.Element("personal_details")
+	document := passport.Element("passport")
+	bill := passport.Element("utility_bill")
+
+	errors := []tgbot.PassportElementError{
+		details.DataFieldError("first_name", "Wrong name"),
+		document.FrontSideError("Blurry"),
+		document.SelfieError("No face"),
+		document.TranslationFileError(document.Translation[0], "Wrong language"),
+		document.TranslationFilesError("Not translated"),
+		bill.FileError(bill.Files[1], "Old bill"),
+		bill.FilesError("Not a bill"),
+		bill.UnspecifiedError("Try again"),
+	}
+	want := []map[string]interface{}{
+		{
+			"source": "data", "type": "personal_details", "field_name": "first_name",
+			"data_hash": p.hashes["personal_details"], "message": "Wrong name",
+		},
+		{"source": "front_side", "type": "passport", "file_hash": p.hashes["front"], "message": "Blurry"},
+		{"source": "selfie", "type": "passport", "file_hash": p.hashes["selfie"], "message": "No face"},
+		{
+			"source": "translation_file", "type": "passport", "file_hash": p.hashes["translation"],
+			"message": "Wrong language",
+		},
+		{
+			"source": "translation_files", "type": "passport",
+			"file_hashes": []interface{}{p.hashes["translation"]}, "message": "Not translated",
+		},
+		{"source": "file", "type": "utility_bill", "file_hash": p.hashes["bill2"], "message": "Old bill"},
+		{
+			"source": "files", "type": "utility_bill",
+			"file_hashes": []interface{}{p.hashes["bill1"], p.hashes["bill2"]}, "message": "Not a bill",
+		},
+		{
+			"source": "unspecified", "type": "utility_bill", "element_hash": p.data.Data[2].Hash,
+			"message": "Try again",
+		},
+	}
+	for i, elementError := range errors {
+		encoded, err := json.Marshal(elementError)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := map[string]interface{}{}
+		if err := json.Unmarshal(encoded, &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("Error %T is %s, want %v", elementError, encoded, want[i])
+		}
+	}
+
+	// The request reads back with the same typed errors, e.g. in the mock server.
+	encoded, err := json.Marshal(NewErrorsRequest(1, errors...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	request := &tgbot.SetPassportDataErrorsRequest{}
+	if err := json.Unmarshal(encoded, request); err != nil {
+		t.Fatalf("Cannot unmarshal %s: %s", encoded, err)
+	}
+	if request.UserID != 1 || len(request.Errors) != len(errors) {
+		t.Fatalf("Unmarshaled %+v", request)
+	}
+	for i, elementError := range request.Errors {
+		if reflect.TypeOf(elementError) != reflect.TypeOf(errors[i]) {
+			t.Errorf("Error %d is %T, want %T", i, elementError, errors[i])
+		}
+	}
+	if reencoded, err := json.Marshal(request); err != nil || !bytes.Equal(reencoded, encoded) {
+		t.Errorf("Request is encoded as %s, want %s", reencoded, encoded)
+	}
+}
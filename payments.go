@@ -0,0 +1,311 @@
+package tgbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxInvoicePayloadLength is the length of the invoice payload in bytes.
+const MaxInvoicePayloadLength = 128
+
+// PreCheckoutDeadline is the time Telegram waits for the answer to a pre-checkout query.
+const PreCheckoutDeadline = 10 * time.Second
+
+// defaultPaymentTimeout leaves the time to decline the query before PreCheckoutDeadline.
+const defaultPaymentTimeout = 8 * time.Second
+
+// CurrencyExponents are the currencies supported by Telegram with the number of digits after
+// the decimal point. The list is typed in by hand on 2026-10-19 with the ISO 4217 minor units,
+// it is not generated: check it against the exp fields of
+// https://core.telegram.org/bots/payments/currencies.json when Telegram adds a currency.
+var CurrencyExponents = map[string]int{
+	"AED": 2, "AFN": 2, "ALL": 2, "AMD": 2, "ARS": 2, "AUD": 2, "AZN": 2, "BAM": 2,
+	"BDT": 2, "BGN": 2, "BND": 2, "BOB": 2, "BRL": 2, "BYN": 2, "CAD": 2, "CHF": 2,
+	"CLP": 0, "CNY": 2, "COP": 2, "CRC": 2, "CZK": 2, "DKK": 2, "DOP": 2, "DZD": 2,
+	"EGP": 2, "ETB": 2, "EUR": 2, "GBP": 2, "GEL": 2, "GTQ": 2, "HKD": 2, "HNL": 2,
+	"HRK": 2, "HUF": 2, "IDR": 2, "ILS": 2, "INR": 2, "ISK": 0, "JMD": 2, "JPY": 0,
+	"KES": 2, "KGS": 2, "KRW": 0, "KZT": 2, "LBP": 2, "LKR": 2, "MAD": 2, "MDL": 2,
+	"MNT": 2, "MUR": 2, "MVR": 2, "MXN": 2, "MYR": 2, "MZN": 2, "NGN": 2, "NIO": 2,
+	"NOK": 2, "NPR": 2, "NZD": 2, "PAB": 2, "PEN": 2, "PHP": 2, "PKR": 2, "PLN": 2,
+	"PYG": 0, "QAR": 2, "RON": 2, "RSD": 2, "RUB": 2, "SAR": 2, "SEK": 2, "SGD": 2,
+	"THB": 2, "TJS": 2, "TRY": 2, "TTD": 2, "TWD": 2, "TZS": 2, "UAH": 2, "UGX": 0,
+	"USD": 2, "UYU": 2, "UZS": 2, "VND": 0, "YER": 2, "ZAR": 2,
+}
+
+// amountPattern is the amount in the currency units with an optional sign and fraction.
+var amountPattern = regexp.MustCompile(`^(-?)([0-9]+)(?:\.([0-9]+))?$`)
+
+// CurrencyExponent returns the number of digits after the decimal point of the currency, fails
+// for the currencies Telegram does not support.
+func CurrencyExponent(currency string) (int, error) {
+	exponent, ok := CurrencyExponents[strings.ToUpper(currency)]
+	if !ok {
+		return 0, fmt.Errorf("Currency %q is not supported", currency)
+	}
+	return exponent, nil
+}
+
+// MinorUnits converts the amount in the currency units, e.g. "9.99" USD, to the smallest units
+// used by the api, 999. Fails if the amount has more digits after the point than the currency.
+func MinorUnits(currency string, amount string) (int64, error) {
+	exponent, err := CurrencyExponent(currency)
+	if err != nil {
+		return 0, err
+	}
+	parts := amountPattern.FindStringSubmatch(amount)
+	if parts == nil {
+		return 0, fmt.Errorf("Cannot parse amount %q", amount)
+	}
+	sign, units, fraction := parts[1], parts[2], parts[3]
+	if len(fraction) > exponent {
+		return 0, fmt.Errorf("Amount %s has more than %d digits after the point for %s", amount, exponent, currency)
+	}
+	value, err := strconv.ParseInt(sign+units+fraction+strings.Repeat("0", exponent-len(fraction)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Cannot parse amount %q: %s", amount, err)
+	}
+	return value, nil
+}
+
+// FormatAmount formats the amount in the smallest units of the currency, e.g. "9.99" for 999 USD.
+func FormatAmount(currency string, amount int64) (string, error) {
+	exponent, err := CurrencyExponent(currency)
+	if err != nil {
+		return "", err
+	}
+	sign, digits := "", strconv.FormatInt(amount, 10)
+	if amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	if exponent == 0 {
+		return sign + digits, nil
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:], nil
+}
+
+// NewLabeledPrice returns the price with the amount in the currency units, e.g. "9.99".
+func NewLabeledPrice(currency string, label string, amount string) (*LabeledPrice, error) {
+	minor, err := MinorUnits(currency, amount)
+	if err != nil {
+		return nil, err
+	}
+	return &LabeledPrice{Label: label, Amount: minor}, nil
+}
+
+// AddPrice adds the price component in the invoice currency units, e.g. "9.99", negative for
+// the discounts.
+func (r *SendInvoiceRequest) AddPrice(label string, amount string) error {
+	price, err := NewLabeledPrice(r.Currency, label, amount)
+	if err != nil {
+		return err
+	}
+	r.Prices = append(r.Prices, price)
+	return nil
+}
+
+// TotalAmount returns the sum of the prices in the smallest units of the currency.
+func (r *SendInvoiceRequest) TotalAmount() int64 {
+	total := int64(0)
+	for _, price := range r.Prices {
+		total += price.Amount
+	}
+	return total
+}
+
+// AddPrice adds the price component of the shipping option in the currency units.
+func (o *ShippingOption) AddPrice(currency string, label string, amount string) error {
+	price, err := NewLabeledPrice(currency, label, amount)
+	if err != nil {
+		return err
+	}
+	o.Prices = append(o.Prices, price)
+	return nil
+}
+
+// Payments sells through the bot: the invoices carry a payload of type P, e.g. an order id, and
+// the shipping queries, pre-checkout queries and successful payments are passed to the callbacks
+// with the decoded payload. The queries are answered automatically when a callback fails, panics
+// or does not return in time.
+type Payments[P any] struct {
+	api           TelegramApiInterface
+	providerToken string
+
+	// OnShipping returns the shipping options for the address of a flexible invoice, the error
+	// text is shown to the user. Shipping queries are declined if nil.
+	OnShipping func(ctx context.Context, query *ShippingQuery, payload P) ([]*ShippingOption, error)
+	// OnPreCheckout confirms that the goods are available, the error text is shown to the user.
+	// All orders are confirmed if nil.
+	OnPreCheckout func(ctx context.Context, query *PreCheckoutQuery, payload P) error
+	// OnPayment is called when the payment is received, the message has the SuccessfulPayment
+	OnPayment func(ctx context.Context, message *Message, payment *SuccessfulPayment, payload P)
+
+	// Timeout of the OnShipping and OnPreCheckout callbacks: their context is cancelled and the
+	// query is declined when it passes. 8 seconds if zero or not shorter than PreCheckoutDeadline
+	Timeout time.Duration
+	// ErrorMessage is shown to the user when a callback times out or panics
+	ErrorMessage string
+	// Logger reports the failed callbacks and answers, slog.Default() if nil
+	Logger *slog.Logger
+}
+
+// NewPayments uses the payment provider token from @BotFather for the invoices.
+func NewPayments[P any](api TelegramApiInterface, providerToken string) *Payments[P] {
+	return &Payments[P]{
+		api:           api,
+		providerToken: providerToken,
+		Timeout:       defaultPaymentTimeout,
+		ErrorMessage:  "The order cannot be processed now, please try again later",
+	}
+}
+
+func (p *Payments[P]) logger() *slog.Logger {
+	if p.Logger != nil {
+		return p.Logger
+	}
+	return slog.Default()
+}
+
+// NewInvoice returns the sendInvoice request with the payload encoded as json, the prices are
+// added with AddPrice.
+func (p *Payments[P]) NewInvoice(chatID int64, title string, description string, currency string, payload P) (*SendInvoiceRequest, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("Cannot encode invoice payload: %s", err)
+	}
+	if len(data) > MaxInvoicePayloadLength {
+		return nil, fmt.Errorf("Invoice payload is %d bytes, at most %d are allowed", len(data), MaxInvoicePayloadLength)
+	}
+	return &SendInvoiceRequest{
+		ChatID:        strconv.FormatInt(chatID, 10),
+		Title:         title,
+		Description:   description,
+		Payload:       string(data),
+		ProviderToken: p.providerToken,
+		Currency:      currency,
+	}, nil
+}
+
+func (p *Payments[P]) decode(payload string) (P, error) {
+	var value P
+	if err := json.Unmarshal([]byte(payload), &value); err != nil {
+		return value, fmt.Errorf("Cannot decode invoice payload %q: %s", payload, err)
+	}
+	return value, nil
+}
+
+func (p *Payments[P]) timeout() time.Duration {
+	if p.Timeout <= 0 || p.Timeout >= PreCheckoutDeadline {
+		return defaultPaymentTimeout
+	}
+	return p.Timeout
+}
+
+// callback runs the function with a context cancelled at the deadline, a panic or a timeout is
+// reported as the error.
+func (p *Payments[P]) callback(ctx context.Context, kind string, call func(ctx context.Context) error) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.timeout())
+	defer cancel()
+	result := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				p.logger().ErrorContext(ctx, "Payment callback panic",
+					slog.String("query", kind), slog.String("panic", fmt.Sprint(r)))
+				result <- fmt.Errorf("%s", p.ErrorMessage)
+			}
+		}()
+		result <- call(ctx)
+	}()
+	select {
+	case err := <-result:
+		return err == nil, err
+	case <-ctx.Done():
+		p.logger().ErrorContext(ctx, "Payment callback timed out", slog.String("query", kind))
+		return false, fmt.Errorf("%s", p.ErrorMessage)
+	}
+}
+
+func (p *Payments[P]) answerShipping(ctx context.Context, query *ShippingQuery) error {
+	request := &AnswerShippingQueryRequest{ShippingQueryID: query.ID}
+	payload, err := p.decode(query.InvoicePayload)
+	switch {
+	case err != nil:
+		p.logger().ErrorContext(ctx, "Cannot handle shipping query", slog.String("error", err.Error()))
+		request.ErrorMessage = p.ErrorMessage
+	case p.OnShipping == nil:
+		request.ErrorMessage = p.ErrorMessage
+	default:
+		var options []*ShippingOption
+		request.Ok, err = p.callback(ctx, "shipping", func(ctx context.Context) error {
+			var err error
+			options, err = p.OnShipping(ctx, query, payload)
+			return err
+		})
+		if request.Ok {
+			request.ShippingOptions = options
+		} else {
+			request.ErrorMessage = err.Error()
+		}
+	}
+	_, err = p.api.WithContext(ctx).AnswerShippingQuery(request)
+	return err
+}
+
+func (p *Payments[P]) answerPreCheckout(ctx context.Context, query *PreCheckoutQuery) error {
+	request := &AnswerPreCheckoutQueryRequest{PreCheckoutQueryID: query.ID, Ok: true}
+	payload, err := p.decode(query.InvoicePayload)
+	switch {
+	case err != nil:
+		p.logger().ErrorContext(ctx, "Cannot handle pre-checkout query", slog.String("error", err.Error()))
+		request.Ok, request.ErrorMessage = false, p.ErrorMessage
+	case p.OnPreCheckout != nil:
+		request.Ok, err = p.callback(ctx, "pre_checkout", func(ctx context.Context) error {
+			return p.OnPreCheckout(ctx, query, payload)
+		})
+		if !request.Ok {
+			request.ErrorMessage = err.Error()
+		}
+	}
+	_, err = p.api.WithContext(ctx).AnswerPreCheckoutQuery(request)
+	return err
+}
+
+// Handler answers the shipping and pre-checkout queries, calls OnPayment for the successful
+// payments and passes the other updates to the next handler, which may be nil.
+func (p *Payments[P]) Handler(next UpdateHandler) UpdateHandler {
+	return func(ctx context.Context, update *Update) {
+		var err error
+		switch {
+		case update.ShippingQuery != nil:
+			err = p.answerShipping(ctx, update.ShippingQuery)
+		case update.PreCheckoutQuery != nil:
+			err = p.answerPreCheckout(ctx, update.PreCheckoutQuery)
+		case update.Message != nil && update.Message.SuccessfulPayment != nil && p.OnPayment != nil:
+			payment := update.Message.SuccessfulPayment
+			var payload P
+			payload, err = p.decode(payment.InvoicePayload)
+			if err == nil {
+				p.OnPayment(ctx, update.Message, payment, payload)
+			} else if next != nil {
+				next(ctx, update)
+			}
+		case next != nil:
+			next(ctx, update)
+		}
+		if err != nil {
+			p.logger().ErrorContext(ctx, "Cannot handle payment update",
+				slog.Int64("update_id", update.UpdateID),
+				slog.String("update_type", string(update.Kind())),
+				slog.String("error", err.Error()))
+		}
+	}
+}
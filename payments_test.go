@@ -0,0 +1,260 @@
+package tgbot_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/lanseg/tgbot"
+	"github.com/lanseg/tgbot/tgbottest"
+)
+
+type testOrder struct {
+	ID int64 `json:"id"`
+}
+
+func TestMinorUnits(t *testing.T) {
+	for _, tc := range []struct {
+		currency string
+		amount   string
+		want     int64
+		fails    bool
+	}{
+		{currency: "USD", amount: "9.99", want: 999},
+		{currency: "USD", amount: "10", want: 1000},
+		{currency: "usd", amount: "1.5", want: 150},
+		{currency: "EUR", amount: "-1.5", want: -150},
+		{currency: "EUR", amount: "0.05", want: 5},
+		{currency: "JPY", amount: "1000", want: 1000},
+		{currency: "JPY", amount: "1.5", fails: true},
+		{currency: "USD", amount: "1.999", fails: true},
+		{currency: "USD", amount: "1.5.3", fails: true},
+		{currency: "USD", amount: "--1", fails: true},
+		{currency: "USD", amount: "+1", fails: true},
+		{currency: "USD", amount: "1.", fails: true},
+		{currency: "USD", amount: ".5", fails: true},
+		{currency: "USD", amount: "1e3", fails: true},
+		{currency: "USD", amount: "", fails: true},
+		{currency: "USD", amount: "99999999999999999999", fails: true},
+		{currency: "XXX", amount: "1", fails: true},
+	} {
+		got, err := tgbot.MinorUnits(tc.currency, tc.amount)
+		if (err != nil) != tc.fails || got != tc.want {
+			t.Errorf("MinorUnits(%s, %q) = %d, %v", tc.currency, tc.amount, got, err)
+		}
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	for _, tc := range []struct {
+		currency string
+		amount   int64
+		want     string
+	}{
+		{currency: "USD", amount: 999, want: "9.99"},
+		{currency: "USD", amount: 5, want: "0.05"},
+		{currency: "USD", amount: 0, want: "0.00"},
+		{currency: "EUR", amount: -150, want: "-1.50"},
+		{currency: "EUR", amount: -5, want: "-0.05"},
+		{currency: "JPY", amount: 1000, want: "1000"},
+		{currency: "KRW", amount: -7, want: "-7"},
+	} {
+		got, err := tgbot.FormatAmount(tc.currency, tc.amount)
+		if err != nil || got != tc.want {
+			t.Errorf("FormatAmount(%s, %d) = %q, %v, want %q", tc.currency, tc.amount, got, err, tc.want)
+		}
+		if back, err := tgbot.MinorUnits(tc.currency, got); err != nil || back != tc.amount {
+			t.Errorf("MinorUnits(%s, %q) = %d, %v, want %d", tc.currency, got, back, err, tc.amount)
+		}
+	}
+	if _, err := tgbot.FormatAmount("XXX", 1); err == nil {
+		t.Errorf("FormatAmount of an unknown currency succeeded")
+	}
+}
+
+func TestNewInvoice(t *testing.T) {
+	payments := tgbot.NewPayments[testOrder](nil, "provider")
+	invoice, err := payments.NewInvoice(10, "Order", "Coffee", "EUR", testOrder{ID: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := invoice.AddPrice("Coffee", "3.50"); err != nil {
+		t.Fatal(err)
+	}
+	if err := invoice.AddPrice("Discount", "-0.50"); err != nil {
+		t.Fatal(err)
+	}
+	if err := invoice.AddPrice("Tip", "1.005"); err == nil {
+		t.Errorf("AddPrice with too many decimals succeeded")
+	}
+	if invoice.Payload != `{"id":7}` || invoice.ChatID != "10" || invoice.TotalAmount() != 300 {
+		t.Errorf("Invoice %+v with total %d", invoice, invoice.TotalAmount())
+	}
+
+	long := tgbot.NewPayments[string](nil, "provider")
+	if _, err := long.NewInvoice(10, "Order", "Coffee", "EUR", string(make([]byte, tgbot.MaxInvoicePayloadLength))); err == nil {
+		t.Errorf("NewInvoice with a long payload succeeded")
+	}
+}
+
+// newTestPayments answers the payment queries on the fake server.
+func newTestPayments(t *testing.T) (*tgbottest.Server, *tgbot.Payments[testOrder]) {
+	server, api := newTestApi(t)
+	for _, method := range []string{"answerShippingQuery", "answerPreCheckoutQuery"} {
+		server.Handle(method, func(json.RawMessage) (interface{}, error) {
+			return true, nil
+		})
+	}
+	payments := tgbot.NewPayments[testOrder](api, "provider")
+	payments.ErrorMessage = "Try later"
+	payments.Logger = quietLogger
+	return server, payments
+}
+
+func preCheckoutAnswer(t *testing.T, server *tgbottest.Server) *tgbot.AnswerPreCheckoutQueryRequest {
+	t.Helper()
+	calls := server.CallsTo("answerPreCheckoutQuery")
+	if len(calls) != 1 {
+		t.Fatalf("answerPreCheckoutQuery is called %d times", len(calls))
+	}
+	answer := &tgbot.AnswerPreCheckoutQueryRequest{}
+	if err := calls[0].Decode(answer); err != nil {
+		t.Fatal(err)
+	}
+	return answer
+}
+
+func TestPaymentsPreCheckout(t *testing.T) {
+	query := &tgbot.PreCheckoutQuery{ID: "q", From: &tgbot.User{ID: 20}, Currency: "EUR", InvoicePayload: `{"id":7}`}
+	for _, tc := range []struct {
+		name     string
+		callback func(ctx context.Context, query *tgbot.PreCheckoutQuery, order testOrder) error
+		want     *tgbot.AnswerPreCheckoutQueryRequest
+	}{
+		{
+			name: "confirmed",
+			callback: func(_ context.Context, _ *tgbot.PreCheckoutQuery, order testOrder) error {
+				if order.ID != 7 {
+					return errors.New("Wrong order")
+				}
+				return nil
+			},
+			want: &tgbot.AnswerPreCheckoutQueryRequest{PreCheckoutQueryID: "q", Ok: true},
+		},
+		{
+			name: "declined",
+			callback: func(context.Context, *tgbot.PreCheckoutQuery, testOrder) error {
+				return errors.New("Sold out")
+			},
+			want: &tgbot.AnswerPreCheckoutQueryRequest{PreCheckoutQueryID: "q", ErrorMessage: "Sold out"},
+		},
+		{
+			name: "panic",
+			callback: func(context.Context, *tgbot.PreCheckoutQuery, testOrder) error {
+				panic("broken callback")
+			},
+			want: &tgbot.AnswerPreCheckoutQueryRequest{PreCheckoutQueryID: "q", ErrorMessage: "Try later"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server, payments := newTestPayments(t)
+			payments.OnPreCheckout = tc.callback
+			payments.Handler(nil)(context.Background(), &tgbot.Update{UpdateID: 1, PreCheckoutQuery: query})
+			if answer := preCheckoutAnswer(t, server); !reflect.DeepEqual(answer, tc.want) {
+				t.Errorf("Answered %+v, want %+v", answer, tc.want)
+			}
+		})
+	}
+}
+
+func TestPaymentsTimeout(t *testing.T) {
+	server, payments := newTestPayments(t)
+	payments.Timeout = 20 * time.Millisecond
+	callbackErr := make(chan error, 1)
+	payments.OnPreCheckout = func(ctx context.Context, _ *tgbot.PreCheckoutQuery, _ testOrder) error {
+		<-ctx.Done()
+		callbackErr <- ctx.Err()
+		return nil
+	}
+	start := time.Now()
+	payments.Handler(nil)(context.Background(), &tgbot.Update{UpdateID: 1, PreCheckoutQuery: &tgbot.PreCheckoutQuery{ID: "q", InvoicePayload: `{"id":7}`}})
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Query is answered after %s", elapsed)
+	}
+	want := &tgbot.AnswerPreCheckoutQueryRequest{PreCheckoutQueryID: "q", ErrorMessage: "Try later"}
+	if answer := preCheckoutAnswer(t, server); !reflect.DeepEqual(answer, want) {
+		t.Errorf("Answered %+v, want %+v", answer, want)
+	}
+	select {
+	case err := <-callbackErr:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Callback context ended with %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Callback context is not cancelled at the deadline")
+	}
+}
+
+func TestPaymentsTimeoutBeforeDeadline(t *testing.T) {
+	_, payments := newTestPayments(t)
+	for _, timeout := range []time.Duration{0, tgbot.PreCheckoutDeadline} {
+		payments.Timeout = timeout
+		var deadline time.Time
+		payments.OnPreCheckout = func(ctx context.Context, _ *tgbot.PreCheckoutQuery, _ testOrder) error {
+			deadline, _ = ctx.Deadline()
+			return nil
+		}
+		start := time.Now()
+		payments.Handler(nil)(context.Background(), &tgbot.Update{PreCheckoutQuery: &tgbot.PreCheckoutQuery{ID: "q", InvoicePayload: `{}`}})
+		if left := deadline.Sub(start); left <= 0 || left >= tgbot.PreCheckoutDeadline {
+			t.Errorf("Timeout %s: callback deadline is in %s", timeout, left)
+		}
+	}
+}
+
+func TestPaymentsShippingAndPayment(t *testing.T) {
+	server, payments := newTestPayments(t)
+	options := []*tgbot.ShippingOption{{ID: "post", Title: "Post", Prices: []*tgbot.LabeledPrice{{Label: "Post", Amount: 500}}}}
+	payments.OnShipping = func(_ context.Context, _ *tgbot.ShippingQuery, order testOrder) ([]*tgbot.ShippingOption, error) {
+		return options, nil
+	}
+	var paid testOrder
+	payments.OnPayment = func(_ context.Context, _ *tgbot.Message, _ *tgbot.SuccessfulPayment, order testOrder) {
+		paid = order
+	}
+	var passed []*tgbot.Update
+	handler := payments.Handler(func(_ context.Context, update *tgbot.Update) {
+		passed = append(passed, update)
+	})
+
+	handler(context.Background(), &tgbot.Update{ShippingQuery: &tgbot.ShippingQuery{ID: "s", InvoicePayload: `{"id":7}`}})
+	answer := &tgbot.AnswerShippingQueryRequest{}
+	if calls := server.CallsTo("answerShippingQuery"); len(calls) != 1 || calls[0].Decode(answer) != nil ||
+		!answer.Ok || !reflect.DeepEqual(answer.ShippingOptions, options) {
+		t.Errorf("Shipping query answered with %+v", answer)
+	}
+
+	handler(context.Background(), &tgbot.Update{Message: &tgbot.Message{
+		SuccessfulPayment: &tgbot.SuccessfulPayment{Currency: "EUR", TotalAmount: 300, InvoicePayload: `{"id":7}`},
+	}})
+	text := &tgbot.Update{Message: &tgbot.Message{Text: "hi"}}
+	handler(context.Background(), text)
+	if paid.ID != 7 || len(passed) != 1 || passed[0] != text {
+		t.Errorf("Paid order %+v, passed updates %v", paid, passed)
+	}
+}
+
+func TestPaymentsMockApi(t *testing.T) {
+	api := tgbottest.NewMockApi()
+	payments := tgbot.NewPayments[testOrder](api, "provider")
+	payments.Handler(nil)(context.Background(), &tgbot.Update{UpdateID: 1, PreCheckoutQuery: &tgbot.PreCheckoutQuery{
+		ID: "q", From: &tgbot.User{ID: 20}, Currency: "EUR", InvoicePayload: `{"id":7}`}})
+
+	tgbottest.AssertCalled(t, api.AnswerPreCheckoutQueryCalls(), func(r *tgbot.AnswerPreCheckoutQueryRequest) bool {
+		return r.PreCheckoutQueryID == "q" && r.Ok
+	})
+}
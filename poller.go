@@ -0,0 +1,161 @@
+package tgbot
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Poller receives the updates with getUpdates long polling and passes them to the handler one
+// by one. The offset and the recently handled updates are kept in the AckStore, so the polling
+// resumes from the last acknowledged update after a restart.
+type Poller struct {
+	api     TelegramApiInterface
+	handler UpdateHandler
+
+	// Acks keeps the offset, in memory by default
+	Acks AckStore
+	// Mode is AckAfterHandling (at-least-once) by default
+	Mode AckMode
+	// Timeout of the long polling, 60 seconds by default
+	Timeout time.Duration
+	// Limit is the number of updates per getUpdates call, zero for the server default of 100
+	Limit int64
+	// AllowedUpdates are the update kinds to receive, e.g. "message", empty for the server default
+	AllowedUpdates []string
+	// RetryDelay is the pause after a failed getUpdates call
+	RetryDelay time.Duration
+	// Logger reports the failed calls, slog.Default() if nil
+	Logger *slog.Logger
+	// Executor runs the handler for the updates in parallel, nil to handle them one by one. The
+	// next batch is requested as soon as the updates are queued, so a slow chat does not hold
+	// back the others. The getUpdates offset then confirms the updates still being handled:
+	// after a crash they are not received again, even in the AckAfterHandling mode.
+	Executor *Executor
+}
+
+func NewPoller(api TelegramApiInterface, handler UpdateHandler) *Poller {
+	return &Poller{
+		api:        api,
+		handler:    handler,
+		Acks:       NewMemoryAckStore(),
+		Mode:       AckAfterHandling,
+		Timeout:    60 * time.Second,
+		RetryDelay: 5 * time.Second,
+	}
+}
+
+func (p *Poller) logger() *slog.Logger {
+	if p.Logger != nil {
+		return p.Logger
+	}
+	return slog.Default()
+}
+
+// Run polls until the context is cancelled, the result is the context error or the error of the
+// AckStore. With the Executor Run waits for the submitted updates before returning.
+func (p *Poller) Run(ctx context.Context) error {
+	acker, err := NewUpdateAcker(ctx, p.Acks, p.Mode, 0)
+	if err != nil {
+		return err
+	}
+	state := &pollerState{acker: acker, ackErrs: make(chan error, 1)}
+	defer state.wg.Wait()
+	api := p.api.WithContext(ctx)
+	for ctx.Err() == nil {
+		select {
+		case err := <-state.ackErrs:
+			return err
+		default:
+		}
+		request := &GetUpdatesRequest{
+			Offset:         max(acker.Offset(), state.next),
+			Limit:          p.Limit,
+			AllowedUpdates: p.AllowedUpdates,
+		}
+		request.SetTimeout(p.Timeout)
+		response, err := api.GetUpdates(request)
+		if err != nil {
+			if ctx.Err() != nil {
+				break
+			}
+			p.logger().ErrorContext(ctx, "Cannot get updates", slog.String("error", err.Error()))
+			select {
+			case <-ctx.Done():
+			case <-time.After(p.RetryDelay):
+			}
+			continue
+		}
+		if err := p.handleBatch(ctx, state, response.Result); err != nil {
+			return err
+		}
+	}
+	state.wg.Wait()
+	select {
+	case err := <-state.ackErrs:
+		return err
+	default:
+	}
+	return ctx.Err()
+}
+
+// pollerState tracks the updates submitted to the Executor.
+type pollerState struct {
+	acker *UpdateAcker
+	// next is the offset after the received updates, some of them may be still handled
+	next int64
+	wg   sync.WaitGroup
+	// ackErrs keeps the first error of the acknowledgements made by the workers
+	ackErrs chan error
+}
+
+func (s *pollerState) ack(ctx context.Context, updateID int64) {
+	if err := s.acker.Ack(ctx, updateID); err != nil {
+		select {
+		case s.ackErrs <- err:
+		default:
+		}
+	}
+}
+
+func (p *Poller) handleBatch(ctx context.Context, state *pollerState, updates []*Update) error {
+	acker := state.acker
+	if p.Executor == nil {
+		for _, update := range updates {
+			if _, err := acker.Handle(ctx, update, p.handler); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for _, update := range updates {
+		state.next = max(state.next, update.UpdateID+1)
+		if !acker.start(update.UpdateID) {
+			continue
+		}
+		if p.Mode == AckBeforeHandling {
+			if err := acker.Ack(ctx, update.UpdateID); err != nil {
+				acker.finish(update.UpdateID)
+				return err
+			}
+		}
+		updateID := update.UpdateID
+		done := func() {
+			defer state.wg.Done()
+			defer acker.finish(updateID)
+			if p.Mode == AckAfterHandling {
+				// The update is handled, it is acknowledged even if Run is being cancelled
+				state.ack(context.WithoutCancel(ctx), updateID)
+			}
+		}
+		state.wg.Add(1)
+		if err := p.Executor.Submit(ctx, update, p.handler, done); err != nil {
+			acker.finish(updateID)
+			state.wg.Done()
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package tgbot_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lanseg/tgbot"
+)
+
+// runPoller polls until the handler cancels the context.
+func runPoller(ctx context.Context, t *testing.T, poller *tgbot.Poller) {
+	t.Helper()
+	done := make(chan error, 1)
+	go func() {
+		done <- poller.Run(ctx)
+	}()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Run returned %v, want context.Canceled", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Poller did not get the last update")
+	}
+}
+
+func TestPoller(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		executor bool
+	}{
+		{name: "one by one"},
+		{name: "executor", executor: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server, api := newTestApi(t)
+			user := &tgbot.User{ID: 1, FirstName: "User"}
+			chat := &tgbot.Chat{ID: 1, Type: "private"}
+			for _, text := range []string{"one", "two", "three", "stop"} {
+				server.SendUserMessage(chat, user, text)
+			}
+			// The second update was handled before the restart.
+			store := tgbot.NewMemoryAckStore()
+			store.SaveAcks(context.Background(), &tgbot.AckState{Handled: []int64{2}})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var mu sync.Mutex
+			var texts []string
+			poller := tgbot.NewPoller(api, func(_ context.Context, update *tgbot.Update) {
+				mu.Lock()
+				defer mu.Unlock()
+				texts = append(texts, update.Message.Text)
+				if update.Message.Text == "stop" {
+					cancel()
+				}
+			})
+			poller.Acks = store
+			poller.Timeout = time.Second
+			if tc.executor {
+				poller.Executor = tgbot.NewExecutor(2, 10, tgbot.ChatOrder, nil)
+				defer poller.Executor.Close()
+			}
+			runPoller(ctx, t, poller)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !reflect.DeepEqual(texts, []string{"one", "three", "stop"}) {
+				t.Errorf("Handled %q, want all updates except the handled one", texts)
+			}
+			if state, _ := store.LoadAcks(context.Background()); state.Offset != 5 {
+				t.Errorf("Saved offset %d, want 5", state.Offset)
+			}
+		})
+	}
+}
+
+func TestPollerSlowChat(t *testing.T) {
+	server, api := newTestApi(t)
+	user := &tgbot.User{ID: 1, FirstName: "User"}
+	slow := &tgbot.Chat{ID: 1, Type: "private"}
+	fast := &tgbot.Chat{ID: 2, Type: "private"}
+	server.SendUserMessage(slow, user, "slow")
+	server.SendUserMessage(fast, user, "fast")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	// The slow chat is handled only after the fast one, which comes in the next getUpdates batch.
+	release := make(chan struct{})
+	poller := tgbot.NewPoller(api, func(_ context.Context, update *tgbot.Update) {
+		switch update.Message.Text {
+		case "slow":
+			<-release
+			cancel()
+		case "fast":
+			close(release)
+		}
+	})
+	poller.Limit = 1
+	poller.Timeout = time.Second
+	poller.Executor = tgbot.NewExecutor(2, 10, tgbot.ChatOrder, quietLogger)
+	defer poller.Executor.Close()
+	runPoller(ctx, t, poller)
+
+	if state, _ := poller.Acks.LoadAcks(context.Background()); state.Offset != 3 {
+		t.Errorf("Saved offset %d, want 3", state.Offset)
+	}
+}
@@ -28,3 +28,5 @@ then
 else
   echo "Not doing static analysis (pylint not found)"
 fi
+
+(cd scripts && python3 -m unittest discover --pattern "*_test.py")
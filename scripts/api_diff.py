@@ -0,0 +1,136 @@
+#!/usr/bin/env python3
+"""Difference between two versions of the telegram bot api docs.
+
+Used to review what has changed in the api before regenerating the code.
+"""
+
+from dataclasses import asdict, dataclass, field
+import api_parser
+
+
+@dataclass
+class FieldChange:
+    """A change of a single struct field or method parameter."""
+
+    token: str
+    name: str
+    change: str
+    old: str = ""
+    new: str = ""
+
+
+@dataclass
+class Report:
+    """All changes between the two docs."""
+
+    addedTypes: list[str] = field(default_factory=list)
+    removedTypes: list[str] = field(default_factory=list)
+    addedMethods: list[str] = field(default_factory=list)
+    removedMethods: list[str] = field(default_factory=list)
+    fields: list[FieldChange] = field(default_factory=list)
+
+    def empty(self) -> bool:
+        """True if the docs describe the same api."""
+        return not (
+            self.addedTypes
+            or self.removedTypes
+            or self.addedMethods
+            or self.removedMethods
+            or self.fields
+        )
+
+    def asDict(self) -> dict:
+        """The report as a json-compatible dict."""
+        return asdict(self)
+
+
+def isDeprecated(param: api_parser.Param) -> bool:
+    """Telegram marks the fields that are going to be removed as deprecated."""
+    return "deprecated" in param.description.lower()
+
+
+def diffParams(old: api_parser.Token, new: api_parser.Token) -> list[FieldChange]:
+    """Field changes of the token that exists in both docs."""
+    oldParams = {param.name: param for param in old.params}
+    newParams = {param.name: param for param in new.params}
+    changes = []
+    for name, param in newParams.items():
+        if name not in oldParams:
+            required = "required" if api_parser.isRequired(new, param) else "optional"
+            changes.append(FieldChange(new.name, name, "added", "", required))
+            continue
+        oldParam = oldParams[name]
+        if oldParam.typeName != param.typeName:
+            changes.append(
+                FieldChange(new.name, name, "type", oldParam.typeName, param.typeName)
+            )
+        required = api_parser.isRequired(new, param)
+        if api_parser.isRequired(old, oldParam) != required:
+            changes.append(
+                FieldChange(
+                    new.name,
+                    name,
+                    "required" if required else "optional",
+                )
+            )
+        if not isDeprecated(oldParam) and isDeprecated(param):
+            changes.append(FieldChange(new.name, name, "deprecated"))
+    for name in oldParams:
+        if name not in newParams:
+            changes.append(FieldChange(old.name, name, "removed"))
+    return changes
+
+
+def diffTokens(
+    oldTokens: list[api_parser.Token], newTokens: list[api_parser.Token]
+) -> Report:
+    """Compares the tokens parsed from two versions of the docs."""
+    old = {token.name: token for token in oldTokens}
+    new = {token.name: token for token in newTokens}
+    report = Report()
+    for name, token in new.items():
+        if name not in old:
+            if api_parser.isMethod(token):
+                report.addedMethods.append(name)
+            else:
+                report.addedTypes.append(name)
+        else:
+            report.fields.extend(diffParams(old[name], token))
+    for name, token in old.items():
+        if name not in new:
+            if api_parser.isMethod(token):
+                report.removedMethods.append(name)
+            else:
+                report.removedTypes.append(name)
+    return report
+
+
+FIELD_CHANGES: dict[str, str] = {
+    "added": "Added {token}.{name} ({new})",
+    "removed": "Removed {token}.{name}",
+    "type": "Changed type of {token}.{name}: {old} -> {new}",
+    "required": "{token}.{name} is now required",
+    "optional": "{token}.{name} is now optional",
+    "deprecated": "{token}.{name} is deprecated",
+}
+
+
+def formatMarkdown(report: Report) -> str:
+    """Human readable report for the review."""
+    if report.empty():
+        return "# Bot API changes\n\nNo changes.\n"
+    lines = ["# Bot API changes", ""]
+    for title, names in [
+        ("Added types", report.addedTypes),
+        ("Removed types", report.removedTypes),
+        ("Added methods", report.addedMethods),
+        ("Removed methods", report.removedMethods),
+    ]:
+        if names:
+            lines += [f"## {title}", ""] + [f"* `{name}`" for name in names] + [""]
+    if report.fields:
+        lines += ["## Changed fields", ""]
+        for change in report.fields:
+            lines.append("* " + FIELD_CHANGES[change.change].format(**asdict(change)))
+        lines.append("")
+    return "\n".join(lines)
@@ -0,0 +1,81 @@
+#!/usr/bin/env python3
+"""Tests for the api docs diff.
+
+Run from the scripts directory: python3 -m unittest api_diff_test
+"""
+
+import copy
+import unittest
+
+import api_diff
+from api_parser import Param, Token
+from fetch_types import parseFile
+from generator_test import EXCERPT
+
+
+def findToken(tokens: list[Token], name: str) -> Token:
+    """Token with the given name."""
+    return next(token for token in tokens if token.name == name)
+
+
+class ApiDiffTest(unittest.TestCase):
+    """Diff of the docs excerpt and its edited copy."""
+
+    def setUp(self):
+        self.old = parseFile(EXCERPT).tokens
+        self.new = copy.deepcopy(self.old)
+
+    def testSameDocs(self):
+        report = api_diff.diffTokens(self.old, self.new)
+        self.assertTrue(report.empty())
+        self.assertEqual(
+            api_diff.formatMarkdown(report), "# Bot API changes\n\nNo changes.\n"
+        )
+
+    def testAddedAndRemovedTokens(self):
+        self.new = [
+            token for token in self.new if token.name not in ["Chat", "sendMessage"]
+        ]
+        self.new.append(Token("Story", "This object represents a story.", []))
+        self.new.append(Token("getMe", "Returns basic information about the bot.", []))
+
+        report = api_diff.diffTokens(self.old, self.new)
+        self.assertEqual(report.addedTypes, ["Story"])
+        self.assertEqual(report.removedTypes, ["Chat"])
+        self.assertEqual(report.addedMethods, ["getMe"])
+        self.assertEqual(report.removedMethods, ["sendMessage"])
+
+    def testFieldChanges(self):
+        user = findToken(self.new, "User")
+        user.params[3].description = "Optional. Deprecated. Use usernames instead."
+        user.params.append(
+            Param("is_premium", "True", "", "Optional. True, if this user is premium")
+        )
+        ban = findToken(self.new, "banChatMember")
+        ban.params[1].typeName = "Integer or String"
+        ban.params[2].required = "Yes"
+        message = findToken(self.new, "Message")
+        message.params = [param for param in message.params if param.name != "photo"]
+
+        report = api_diff.diffTokens(self.old, self.new)
+        self.assertEqual(
+            [(change.token, change.name, change.change) for change in report.fields],
+            [
+                ("User", "username", "deprecated"),
+                ("User", "is_premium", "added"),
+                ("Message", "photo", "removed"),
+                ("banChatMember", "user_id", "type"),
+                ("banChatMember", "until_date", "required"),
+            ],
+        )
+        markdown = api_diff.formatMarkdown(report)
+        self.assertIn("* Added User.is_premium (optional)", markdown)
+        self.assertIn(
+            "* Changed type of banChatMember.user_id: Integer -> Integer or String",
+            markdown,
+        )
+        self.assertIn("* banChatMember.until_date is now required", markdown)
+
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,216 @@
+#!/usr/bin/env python3
+"""Json intermediate representation of the parsed telegram bot api docs.
+
+The representation is stable between the generator versions (see IR_VERSION) and is meant to
+be used by the other generators. The golang formatter reads the api from it too.
+"""
+
+import re
+import api_parser
+
+# Bump when the format of the representation changes incompatibly
+IR_VERSION = 1
+
+DOCS_URL = "https://core.telegram.org/bots/api"
+
+# Telegram primitive type names to the ir primitive names
+PRIMITIVES: dict[str, str] = {
+    "Integer": "integer",
+    "Float": "float",
+    "Float number": "float",
+    "String": "string",
+    "Boolean": "boolean",
+    "True": "true",
+    "False": "false",
+}
+
+# Descriptions of the fields with a fixed set of values: “private”, “group”, etc
+ENUM_MARKERS: list[str] = ["always", "can be", "one of", "either"]
+
+# Descriptions of the union types followed by the list of the members
+UNION_MARKERS: list[str] = ["one of", "the following"]
+
+
+def typeRef(typeName: str) -> dict:
+    """Structured type: primitive, reference to a type, array or union."""
+    if " or " in typeName:
+        return {
+            "kind": "union",
+            "of": [typeRef(name.strip()) for name in typeName.split(" or ")],
+        }
+    if typeName.startswith("Array of "):
+        return {"kind": "array", "of": typeRef(typeName[len("Array of ") :])}
+    if typeName in PRIMITIVES:
+        return {"kind": "primitive", "name": PRIMITIVES[typeName]}
+    return {"kind": "ref", "name": typeName}
+
+
+def enumValues(param: api_parser.Param) -> list[str]:
+    """Values of a string field if its description lists them."""
+    if param.typeName != "String":
+        return []
+    if not any(marker in param.description for marker in ENUM_MARKERS):
+        return []
+    return re.findall("“([^”]+)”", param.description)
+
+
+def unionMembers(token: api_parser.Token, typeNames: set[str]) -> list[str]:
+    """Types of a union type, "It can be one of" or "the following types" and the list."""
+    if token.params or not any(m in token.description for m in UNION_MARKERS):
+        return []
+    members = []
+    for word in token.description.split():
+        word = word.strip(".,")
+        if word in typeNames and word != token.name and word not in members:
+            members.append(word)
+    return members
+
+
+def returnType(token: api_parser.Token, structNames: dict) -> dict | None:
+    """Result of the method, None if the description is unclear."""
+    returns = api_parser.getResultType(token, structNames)
+    if len(returns) == 1:
+        return typeRef(returns[0])
+    if "Returns True on success" in token.description:
+        return typeRef("True")
+    return None
+
+
+def paramIR(token: api_parser.Token, param: api_parser.Param) -> dict:
+    """A struct field or a method parameter."""
+    return {
+        "name": param.name,
+        "type": param.typeName,
+        "typeRef": typeRef(param.typeName),
+        "required": api_parser.isRequired(token, param),
+        "description": param.description,
+        "addedIn": param.addedIn,
+        "enum": enumValues(param),
+    }
+
+
+def toIR(docParser: api_parser.Parser) -> dict:
+    """Converts the parsed docs into a json-compatible dict."""
+    tokens = docParser.tokens
+    typeNames = {token.name for token in tokens if not api_parser.isMethod(token)}
+    structNames = {
+        token.name.lower(): token for token in tokens if not api_parser.isMethod(token)
+    }
+    types = []
+    methods = []
+    for token in tokens:
+        item = {
+            "name": token.name,
+            "anchor": f"{DOCS_URL}#{token.name.lower()}",
+            "description": token.description,
+            "addedIn": token.addedIn,
+        }
+        if api_parser.isMethod(token):
+            item["params"] = [paramIR(token, param) for param in token.params]
+            item["returns"] = returnType(token, structNames)
+            methods.append(item)
+        else:
+            item["fields"] = [paramIR(token, param) for param in token.params]
+            item["oneOf"] = unionMembers(token, typeNames)
+            types.append(item)
+    return {
+        "irVersion": IR_VERSION,
+        "apiVersion": docParser.apiVersion,
+        "apiDate": docParser.apiDate,
+        "types": types,
+        "methods": methods,
+    }
+
+
+def fromIR(ir: dict) -> list[api_parser.Token]:
+    """Restores the parser tokens from the representation, types go before methods."""
+    if ir["irVersion"] != IR_VERSION:
+        raise ValueError(f"Unsupported ir version {ir['irVersion']}")
+    tokens = []
+    for item in ir["types"]:
+        params = [
+            api_parser.Param(
+                field["name"], field["type"], "", field["description"], field["addedIn"]
+            )
+            for field in item["fields"]
+        ]
+        tokens.append(
+            api_parser.Token(item["name"], item["description"], params, item["addedIn"])
+        )
+    for item in ir["methods"]:
+        params = [
+            api_parser.Param(
+                param["name"],
+                param["type"],
+                "Yes" if param["required"] else "Optional",
+                param["description"],
+                param["addedIn"],
+            )
+            for param in item["params"]
+        ]
+        tokens.append(
+            api_parser.Token(item["name"], item["description"], params, item["addedIn"])
+        )
+    return tokens
+
+
+def schemaType(ref: dict, typeNames: set[str]) -> dict:
+    """Json schema of the type reference, types missing in the docs accept anything."""
+    if ref["kind"] == "union":
+        return {"anyOf": [schemaType(member, typeNames) for member in ref["of"]]}
+    if ref["kind"] == "array":
+        return {"type": "array", "items": schemaType(ref["of"], typeNames)}
+    if ref["kind"] == "primitive":
+        return {
+            "integer": {"type": "integer"},
+            "float": {"type": "number"},
+            "string": {"type": "string"},
+            "boolean": {"type": "boolean"},
+            "true": {"const": True},
+            "false": {"const": False},
+        }[ref["name"]]
+    if ref["name"] in typeNames:
+        return {"$ref": f"#/$defs/{ref['name']}"}
+    return {"title": ref["name"]}
+
+
+def schemaObject(description: str, params: list[dict], typeNames: set[str]) -> dict:
+    """Json schema of a struct or a method request."""
+    properties = {}
+    for param in params:
+        schema = schemaType(param["typeRef"], typeNames)
+        if param["enum"]:
+            schema["enum"] = param["enum"]
+        schema["description"] = " ".join(param["description"].split())
+        properties[param["name"]] = schema
+    return {
+        "type": "object",
+        "description": " ".join(description.split()),
+        "properties": properties,
+        "required": [param["name"] for param in params if param["required"]],
+    }
+
+
+def toJSONSchema(ir: dict) -> dict:
+    """Json schema of the Update, all types and method requests, e.g. SendMessageRequest."""
+    typeNames = {item["name"] for item in ir["types"]}
+    definitions = {}
+    for item in ir["types"]:
+        if item["oneOf"]:
+            definitions[item["name"]] = {
+                "description": " ".join(item["description"].split()),
+                "anyOf": [{"$ref": f"#/$defs/{name}"} for name in item["oneOf"]],
+            }
+        else:
+            definitions[item["name"]] = schemaObject(
+                item["description"], item["fields"], typeNames
+            )
+    for item in ir["methods"]:
+        name = item["name"][0].upper() + item["name"][1:] + "Request"
+        definitions[name] = schemaObject(item["description"], item["params"], typeNames)
+    return {
+        "$schema": "https://json-schema.org/draft/2020-12/schema",
+        "title": f"Telegram Bot API {ir['apiVersion']}",
+        "$ref": "#/$defs/Update",
+        "$defs": definitions,
+    }
@@ -47,6 +47,7 @@ class Param:
     typeName: str
     required: bool = False
     description: str = ""
+    addedIn: str = ""
 
 
 @dataclass
@@ -56,6 +57,57 @@ class Token:
     name: str
     description: str
     params: list[Param]
+    addedIn: str = ""
+
+
+@dataclass
+class Change:
+    """A list item of the "Recent changes": linked types or methods and mentioned fields."""
+
+    version: str
+    links: list[tuple[str, str]]  # (name, last kind word before the link, e.g. "class")
+    fields: list[str]
+
+
+RECENT_CHANGES = "Recent changes"
+
+# Words in the change description that tell what the next link is
+CHANGE_KINDS: set[str] = set(
+    ["class", "classes", "method", "methods", "field", "fields", "type", "types"]
+)
+
+
+def isMethod(token: Token) -> bool:
+    """Methods start with a lowercase letter, types with an uppercase."""
+    return token.name[:1].islower()
+
+
+def isRequired(token: Token, param: Param) -> bool:
+    """Method params have the Required column, optional fields say so in the description."""
+    if isMethod(token):
+        return param.required == "Yes"
+    return not param.description.startswith("Optional")
+
+
+def getResultType(token: Token, allTypes: dict[str, str]) -> list[str]:
+    """Tries to get information about the method result from a method description."""
+
+    maybeTypes = map(
+        lambda x: x.split(),
+        filter(
+            lambda x: x.find("is returned") != -1 or x.find("returns an ") != -1,
+            token.description.lower().split("."),
+        ),
+    )
+    result = []
+    for maybeType in {
+        allTypes[t].name for mt in maybeTypes for t in mt if t in allTypes.keys()
+    }:
+        if token.description.find(f"rray of {maybeType}") != -1:
+            result.append(f"Array of {maybeType}")
+        else:
+            result.append(maybeType)
+    return result
 
 
 class State(Enum):
@@ -84,7 +136,75 @@ class Parser(parser.HTMLParser):
         self.tableRow = []
         self.tableCell = ""
 
+        # Bot API version and date of the docs, the first entry of the "Recent changes"
+        self.apiVersion = ""
+        self.apiDate = ""
+        self.changes = []
+        self.change = None
+        self.changeVersion = ""
+        self.changeDate = ""
+        self.changeKind = ""
+        self.changeTag = ""
+
+    def addToken(self):
+        """Finishes the current subsection."""
+        token = Token(self.subsection, " ".join(self.description), self.table)
+        self.setAddedIn(token)
+        self.tokens.append(token)
+        self.subsection = ""
+        self.description = []
+        self.table = []
+
+    def setAddedIn(self, token: Token):
+        """Finds the oldest version that added the token or its fields in the recent changes.
+
+        A linked type or method is added by the change unless the change adds some of its
+        fields: "Added the field <em>x</em> to the class <a>Chat</a>" adds Chat.x, not Chat.
+        """
+        params = {param.name: param for param in token.params}
+        for change in self.changes:
+            kinds = [kind for name, kind in change.links if name == token.name]
+            if not kinds:
+                continue
+            fields = [params[name] for name in change.fields if name in params]
+            for param in fields:
+                param.addedIn = change.version
+            if not fields and kinds[0] in ["class", "classes", "method", "methods"]:
+                token.addedIn = change.version
+
+    def handleChangeStart(self, tag, attrs):
+        """Collects the "Recent changes" list items."""
+        self.changeTag = tag
+        if tag == "li" and self.changeVersion:
+            self.change = Change(self.changeVersion, [], [])
+        elif tag == "a" and self.change is not None:
+            href = dict(attrs).get("href") or ""
+            if href.startswith("#"):
+                self.changeTag = "link"
+
+    def handleChangeData(self, data):
+        """Remembers the versions, linked tokens and fields of the changes."""
+        if self.state == State.SUBSECTION:
+            self.changeDate = data
+        elif self.changeTag == "strong" and data.startswith("Bot API "):
+            self.changeVersion = data[len("Bot API ") :].strip()
+            if not self.apiVersion:
+                self.apiVersion = self.changeVersion
+                self.apiDate = self.changeDate
+        elif self.change is None:
+            return
+        elif self.changeTag == "link":
+            self.change.links.append((data, self.changeKind))
+        elif self.changeTag == "em":
+            self.change.fields.append(data)
+        else:
+            for word in data.replace(",", " ").split():
+                if word in CHANGE_KINDS:
+                    self.changeKind = word
+
     def handle_starttag(self, tag, attrs):
+        if self.section == RECENT_CHANGES:
+            self.handleChangeStart(tag, attrs)
         self.state = {
             "h3": State.SECTION,
             "h4": State.SUBSECTION,
@@ -98,14 +218,15 @@ class Parser(parser.HTMLParser):
             and self.section not in SKIP_SECTIONS
             and self.subsection not in SKIP_SECTIONS
         ):
-            self.tokens.append(
-                Token(self.subsection, " ".join(self.description), self.table)
-            )
-            self.subsection = ""
-            self.description = []
-            self.table = []
+            self.addToken()
 
     def handle_endtag(self, tag):
+        if self.section == RECENT_CHANGES:
+            self.changeTag = ""
+            if tag == "li" and self.change is not None:
+                self.changes.append(self.change)
+                self.change = None
+                self.changeKind = ""
         oldstate = self.state
         self.state = (
             {
@@ -135,18 +256,15 @@ class Parser(parser.HTMLParser):
                 self.table.append(Param(row[0], row[1], row[2], row[3]))
             self.tableRow = []
         elif oldstate == State.TABLE and self.state == State.DOCUMENT:
-            self.tokens.append(
-                Token(self.subsection, " ".join(self.description), self.table)
-            )
-            self.subsection = ""
-            self.description = []
-            self.table = []
+            self.addToken()
 
     def handle_data(self, data):
         if self.state == State.SECTION:
             self.section = data
         elif self.state == State.SUBSECTION:
             self.subsection = data
+        if self.section == RECENT_CHANGES:
+            self.handleChangeData(data)
 
         if self.section in SKIP_SECTIONS or self.subsection in SKIP_SECTIONS:
             return
@@ -3,13 +3,54 @@
 
 API definition taken from here: https://core.telegram.org/bots/api
 """
+import json
 import sys
+import api_diff
+import api_ir
 import api_parser
 from format import golang
 
 
-if __name__ == "__main__":
-    with open(sys.argv[1], encoding="utf-8") as api:
+def parseFile(fileName: str) -> api_parser.Parser:
+    """Parses the html docs into tokens."""
+    with open(fileName, encoding="utf-8") as api:
         docParser = api_parser.Parser()
         docParser.feed(api.read())
-        print(golang.formatTokens(docParser.tokens))
+        return docParser
+
+
+def readIR(fileName: str) -> dict:
+    """Reads the api from the html docs or from the json ir printed with --ir."""
+    if fileName.endswith(".json"):
+        with open(fileName, encoding="utf-8") as api:
+            return json.load(api)
+    return api_ir.toIR(parseFile(fileName))
+
+
+def printJSON(value: dict):
+    """Prints a json value for the other tools."""
+    print(json.dumps(value, indent=2, ensure_ascii=False))
+
+
+if __name__ == "__main__":
+    if sys.argv[1] == "--diff":
+        # fetch_types.py --diff old.html new.html [--json]
+        report = api_diff.diffTokens(
+            api_ir.fromIR(readIR(sys.argv[2])), api_ir.fromIR(readIR(sys.argv[3]))
+        )
+        if "--json" in sys.argv[4:]:
+            printJSON(report.asDict())
+        else:
+            print(api_diff.formatMarkdown(report))
+    else:
+        # fetch_types.py api.html|api.json [--ir|--schema|--mock]
+        ir = readIR(sys.argv[1])
+        if "--ir" in sys.argv[2:]:
+            printJSON(ir)
+        elif "--schema" in sys.argv[2:]:
+            printJSON(api_ir.toJSONSchema(ir))
+        elif "--mock" in sys.argv[2:]:
+            print(golang.formatMock(api_ir.fromIR(ir)))
+        else:
+            tokens = api_ir.fromIR(ir)
+            print(golang.formatTokens(tokens, ir["apiVersion"], ir["apiDate"]))
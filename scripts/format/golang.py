@@ -4,6 +4,8 @@
 API definition taken from here: https://core.telegram.org/bots/api
 """
 
+import re
+import sys
 import textwrap
 import api_parser
 
@@ -38,6 +40,82 @@ ONEOF_TYPES: dict[str, list[str]] = {
         "ReactionTypeEmoji",
         "ReactionTypeCustomEmoji",
     ],
+    "ChatBoostSource": [
+        "ChatBoostSourcePremium",
+        "ChatBoostSourceGiftCode",
+        "ChatBoostSourceGiveaway",
+    ],
+}
+
+# A type that can be one of the following, generated as an interface that each of them
+# implements, so the members stay separate types with their own fields
+INTERFACE_TYPES: dict[str, list[str]] = {
+    "InlineQueryResult": [
+        "InlineQueryResultCachedAudio",
+        "InlineQueryResultCachedDocument",
+        "InlineQueryResultCachedGif",
+        "InlineQueryResultCachedMpeg4Gif",
+        "InlineQueryResultCachedPhoto",
+        "InlineQueryResultCachedSticker",
+        "InlineQueryResultCachedVideo",
+        "InlineQueryResultCachedVoice",
+        "InlineQueryResultArticle",
+        "InlineQueryResultAudio",
+        "InlineQueryResultContact",
+        "InlineQueryResultGame",
+        "InlineQueryResultDocument",
+        "InlineQueryResultGif",
+        "InlineQueryResultLocation",
+        "InlineQueryResultMpeg4Gif",
+        "InlineQueryResultPhoto",
+        "InlineQueryResultVenue",
+        "InlineQueryResultVideo",
+        "InlineQueryResultVoice",
+    ],
+    "InputMessageContent": [
+        "InputTextMessageContent",
+        "InputLocationMessageContent",
+        "InputVenueMessageContent",
+        "InputContactMessageContent",
+        "InputInvoiceMessageContent",
+    ],
+    "MaybeInaccessibleMessage": [
+        "Message",
+        "InaccessibleMessage",
+    ],
+    "PassportElementError": [
+        "PassportElementErrorDataField",
+        "PassportElementErrorFrontSide",
+        "PassportElementErrorReverseSide",
+        "PassportElementErrorSelfie",
+        "PassportElementErrorFile",
+        "PassportElementErrorFiles",
+        "PassportElementErrorTranslationFile",
+        "PassportElementErrorTranslationFiles",
+        "PassportElementErrorUnspecified",
+    ],
+}
+
+# Fields of the interface members with the constant value that tells the members apart
+DISCRIMINATOR_FIELDS = ["type", "source"]
+
+# Conditions on the json fields of the interface members that have no discriminator field
+MEMBER_CONDITIONS: dict[str, list[str]] = {
+    # Inaccessible messages have the fields of the regular ones, but the date is always 0
+    "Message": ['!fields.isZero("date")'],
+    "InaccessibleMessage": ['fields.isZero("date")'],
+}
+
+# Fields of all interface members available as interface methods: field -> method name
+INTERFACE_GETTERS: dict[str, dict[str, str]] = {
+    "InlineQueryResult": {"id": "ResultID"},
+}
+
+# Integer fields with these description markers get time.Time or time.Duration accessors:
+# marker -> (go type, accessor suffix, getter converter, setter converter)
+TIME_FIELDS: dict[str, tuple[str, str, str, str]] = {
+    "unix time": ("time.Time", "Time", "unixToTime", "timeToUnix"),
+    "in seconds": ("time.Duration", "Duration", "secondsToDuration", "durationToSeconds"),
 }
 
 
@@ -67,23 +145,141 @@ def formatComment(comment: str, offset: int = 0, maxWidth: int = 95) -> str:
         comment.strip(), maxWidth, initial_indent=indent, subsequent_indent=indent
     )
 
+# Fields of the update payloads with the effective user and sender chat, in order of preference
+UPDATE_USER_FIELDS: list[str] = ["from", "user"]
+UPDATE_SENDER_CHAT_FIELDS: list[str] = ["sender_chat", "actor_chat", "voter_chat"]
+
+# Message fields that describe the message rather than its content
+MESSAGE_METADATA_FIELDS: list[str] = [
+    "message_id",
+    "message_thread_id",
+    "from",
+    "sender_chat",
+    "date",
+    "chat",
+    "forward_origin",
+    "is_topic_message",
+    "is_automatic_forward",
+    "reply_to_message",
+    "external_reply",
+    "quote",
+    "via_bot",
+    "edit_date",
+    "has_protected_content",
+    "media_group_id",
+    "author_signature",
+    "entities",
+    "link_preview_options",
+    "caption",
+    "caption_entities",
+    "has_media_spoiler",
+    "reply_markup",
+]
+
+# Message fields with the content of the regular messages
+MESSAGE_CONTENT_FIELDS: list[str] = [
+    "text",
+    "animation",
+    "audio",
+    "document",
+    "photo",
+    "sticker",
+    "story",
+    "video",
+    "video_note",
+    "voice",
+    "contact",
+    "dice",
+    "game",
+    "poll",
+    "venue",
+    "location",
+    "invoice",
+    "giveaway",
+    "giveaway_winners",
+]
+
+# Service message fields, besides the ones described as "Service message" in the docs
+MESSAGE_SERVICE_FIELDS: list[str] = [
+    "new_chat_members",
+    "left_chat_member",
+    "new_chat_title",
+    "new_chat_photo",
+    "migrate_to_chat_id",
+    "migrate_from_chat_id",
+    "pinned_message",
+    "connected_website",
+    "passport_data",
+]
+
+
+def withAddedIn(description: str, addedIn: str) -> str:
+    """Appends the Bot API version that added the type, method or field to its description."""
+    if not addedIn:
+        return description
+    description = description.strip()
+    if description and not description.endswith("."):
+        description += "."
+    return f"{description} Added in Bot API {addedIn}."
+
+
+def formatTimeAccessors(token: api_parser.Token, setters: bool = False) -> str:
+    """Generates time.Time and time.Duration accessors for "Unix time" and "in seconds" fields."""
+
+    structName = toCamelCase(token.name)
+    receiver = structName[0].lower()
+    result = []
+    for param in token.params:
+        if formatType(param.typeName) != "int64":
+            continue
+        description = param.description.lower()
+        marker = next((m for m in TIME_FIELDS if m in description), None)
+        if marker is None:
+            continue
+        goType, suffix, getter, setter = TIME_FIELDS[marker]
+        field = toCamelCase(param.name)
+        result.append(
+            textwrap.dedent(
+                f"""
+            // {field}As{suffix} returns {field} as {goType}
+            func ({receiver} *{structName}) {field}As{suffix}() {goType} {{
+              return {getter}({receiver}.{field})
+            }}"""
+            )
+        )
+        if setters:
+            result.append(
+                textwrap.dedent(
+                    f"""
+                // Set{field} sets {field} from {goType}
+                func ({receiver} *{structName}) Set{field}(value {goType}) {{
+                  {receiver}.{field} = {setter}(value)
+                }}"""
+                )
+            )
+    return "\n".join(result)
+
 
-def formatStruct(token: api_parser.Token) -> str:
-    """Formats token as a golang struct definition."""
+def formatStruct(token: api_parser.Token, setters: bool = False) -> str:
+    """Formats token as a golang struct definition with its time accessors."""
 
     result = []
     for param in token.params:
         result.extend(
             [
-                formatComment(param.description, 2),
+                formatComment(withAddedIn(param.description, param.addedIn), 2),
                 f'  {toCamelCase(param.name)} {formatType(param.typeName)} `json:"{param.name},omitempty"`\n',
             ]
         )
 
     return "\n".join(
-        [formatComment(token.description), f"type {toCamelCase(token.name)} struct {{"]
+        [
+            formatComment(withAddedIn(token.description, token.addedIn)),
+            f"type {toCamelCase(token.name)} struct {{",
+        ]
         + result
-        + ["}"]
+        + ["}", formatTimeAccessors(token, setters)]
+        + ([unmarshal] if (unmarshal := formatUnmarshal(token)) else [])
     )
 
 
@@ -94,36 +290,15 @@ def formatType(tgType: str) -> str:
         return "interface{}"
     maybeArray = tgType.split("Array of ")
     tgType = PRIMITIVE_TYPES.get(maybeArray[-1], toCamelCase(maybeArray[-1]))
-    if tgType not in PRIMITIVE_TYPES.values():
+    if tgType not in PRIMITIVE_TYPES.values() and tgType not in INTERFACE_TYPES:
         tgType = "*" + tgType
     return "[]" * (len(maybeArray) - 1) + tgType
 
 
-def getResultType(token: api_parser.Token, allTypes: dict[str, str]) -> list[str]:
-    """Tries to get information about the method result from a method description."""
-
-    maybeTypes = map(
-        lambda x: x.split(),
-        filter(
-            lambda x: x.find("is returned") != -1 or x.find("returns an ") != -1,
-            token.description.lower().split("."),
-        ),
-    )
-    result = []
-    for maybeType in {
-        allTypes[t].name for mt in maybeTypes for t in mt if t in allTypes.keys()
-    }:
-        if token.description.find(f"rray of {maybeType}") != -1:
-            result.append(f"Array of {maybeType}")
-        else:
-            result.append(maybeType)
-    return result
-
-
 def formatRequestResponse(token: api_parser.Token, allTypes: dict[str, str]) -> str:
     """Generates request and response structs for the method token."""
 
-    ret = getResultType(token, allTypes)
+    ret = api_parser.getResultType(token, allTypes)
     methodResult = [
         api_parser.Param("raw", "Array of byte", False, "Raw response from the server")
     ]
@@ -141,7 +316,8 @@ def formatRequestResponse(token: api_parser.Token, allTypes: dict[str, str]) ->
                     token.name + "Request",
                     f"Request for API call '{token.name}'",
                     token.params,
-                )
+                ),
+                True,
             ),
             formatStruct(
                 api_parser.Token(
@@ -157,8 +333,8 @@ def formatRequestResponse(token: api_parser.Token, allTypes: dict[str, str]) ->
 def formatMethod(token: api_parser.Token, allTypes: dict[str, str]) -> str:
     """Formats token as a golang method (member of a TelegramApi struct)."""
     name = toCamelCase(token.name)
-    maybeReturnType = getResultType(token, allTypes)
-    result = formatComment(token.description)
+    maybeReturnType = api_parser.getResultType(token, allTypes)
+    result = formatComment(withAddedIn(token.description, token.addedIn))
     if len(maybeReturnType) != 1:
         return result + textwrap.dedent(
             f"""
@@ -184,27 +360,495 @@ def formatMethod(token: api_parser.Token, allTypes: dict[str, str]) -> str:
     )
 
 
-def formatTokens(tokens: list[api_parser.Token]) -> str:
+def formatVersion(apiVersion: str, apiDate: str) -> str:
+    """Formats the version constants of the docs the code is generated from."""
+
+    return textwrap.dedent(
+        f"""
+        // Bot API version and release date of the docs the types are generated from
+        const (
+          APIVersion = "{apiVersion}"
+          APIDate = "{apiDate}"
+        )"""
+    )
+
+
+def formatInterfaceType(
+    token: api_parser.Token, tokenByName: dict[str, api_parser.Token]
+) -> str:
+    """Formats the token as an interface that its members implement."""
+
+    name = toCamelCase(token.name)
+    result = [
+        formatComment(withAddedIn(token.description, token.addedIn)),
+        f"type {name} interface {{",
+        f"  is{name}()",
+    ]
+    member = next(tokenByName[m] for m in INTERFACE_TYPES[token.name] if m in tokenByName)
+    for fieldName, method in INTERFACE_GETTERS.get(token.name, {}).items():
+        param = next(p for p in member.params if p.name == fieldName)
+        result.extend(
+            [
+                f"  // {method} returns {toCamelCase(fieldName)} of the member",
+                f"  {method}() {formatType(param.typeName)}",
+            ]
+        )
+    result.append("}")
+    return "\n".join(result)
+
+
+def discriminator(token: api_parser.Token) -> tuple[str, str] | None:
+    """Returns the field and its constant value that tell the interface member apart."""
+
+    for param in token.params:
+        value = re.search(r"must be (\w+)", param.description)
+        if param.name in DISCRIMINATOR_FIELDS and value:
+            return param.name, value.group(1)
+    return None
+
+
+def formatInterfaceMember(interface: str, token: api_parser.Token) -> str:
+    """Implements the interface by the member, the member kind is set when it is marshalled."""
+
+    name = toCamelCase(token.name)
+    receiver = name[0].lower()
+    result = [f"func (*{name}) is{interface}() {{}}"]
+    for fieldName, method in INTERFACE_GETTERS.get(interface, {}).items():
+        param = next(p for p in token.params if p.name == fieldName)
+        field = toCamelCase(fieldName)
+        result.append(
+            textwrap.dedent(
+                f"""
+                // {method} returns {field}
+                func ({receiver} *{name}) {method}() {formatType(param.typeName)} {{
+                  return {receiver}.{field}
+                }}"""
+            )
+        )
+    kind = discriminator(token)
+    if kind:
+        field = toCamelCase(kind[0])
+        result.append(
+            textwrap.dedent(
+                f"""
+                // MarshalJSON sets {field} to "{kind[1]}"
+                func ({receiver} *{name}) MarshalJSON() ([]byte, error) {{
+                  type plain {name}
+                  value := plain(*{receiver})
+                  value.{field} = "{kind[1]}"
+                  return json.Marshal(&value)
+                }}"""
+            )
+        )
+    return "\n".join(result)
+
+
+def memberCondition(token: api_parser.Token, others: list[api_parser.Token]) -> list[str]:
+    """Returns the conditions on the json fields that the object is the member: the
+    discriminator value and the required fields that not all other members with the same
+    value require."""
+
+    def required(member: api_parser.Token) -> set[str]:
+        return {p.name for p in member.params if api_parser.isRequired(member, p)}
+
+    if token.name in MEMBER_CONDITIONS:
+        return MEMBER_CONDITIONS[token.name]
+    kind = discriminator(token)
+    conditions = [f'fields.is("{kind[0]}", "{kind[1]}")'] if kind else []
+    others = [o for o in others if o is not token and discriminator(o) == kind]
+    if others:
+        fields = required(token) - set.intersection(*[required(o) for o in others])
+    else:
+        # The only member without a discriminator is told by all its required fields
+        fields = set() if kind else required(token)
+    for param in token.params:
+        if param.name in fields:
+            conditions.append(f'fields.has("{param.name}")')
+    return conditions
+
+
+def formatMemberChooser(interface: str, members: list[api_parser.Token]) -> str:
+    """Generates the function returning the interface member to unmarshal a json object into."""
+
+    cases = [(memberCondition(member, members), member) for member in members]
+    # The members requiring more fields go first, e.g. a venue before a location
+    cases.sort(key=lambda case: -len(case[0]))
+    result = [
+        "",
+        formatComment(
+            f"choose{interface} returns the {interface} member for the json object, nil if it"
+            " is none of them"
+        ),
+        f"func choose{interface}(fields jsonObject) {interface} {{",
+        "  switch {",
+    ]
+    for conditions, member in cases:
+        result.extend(
+            [
+                f"  case {' && '.join(conditions)}:",
+                f"    return &{toCamelCase(member.name)}{{}}",
+            ]
+        )
+    result.extend(["  }", "  return nil", "}", ""])
+    return "\n".join(result)
+
+
+def interfaceType(typeName: str) -> str | None:
+    """Returns the interface type of the field type, e.g. of "Array of InlineQueryResult"."""
+
+    name = typeName.split("Array of ")[-1]
+    return name if name in INTERFACE_TYPES else None
+
+
+def formatUnmarshal(token: api_parser.Token) -> str:
+    """Generates UnmarshalJSON that decodes the interface fields into their members."""
+
+    params = [p for p in token.params if interfaceType(p.typeName)]
+    if not params:
+        return ""
+    structName = toCamelCase(token.name)
+    receiver = structName[0].lower()
+    fields = []
+    decoders = []
+    for param in params:
+        field = toCamelCase(param.name)
+        interface = interfaceType(param.typeName)
+        decode = "unmarshalMembers" if param.typeName.startswith("Array of ") else "unmarshalMember"
+        fields.append(f'    {field} json.RawMessage `json:"{param.name}"`')
+        decoders.extend(
+            [
+                f'  if {receiver}.{field}, err = {decode}(value.{field}, "{interface}", choose{interface}); err != nil {{',
+                "    return err",
+                "  }",
+            ]
+        )
+    return "\n".join(
+        [
+            "",
+            f"// UnmarshalJSON decodes {', '.join(toCamelCase(p.name) for p in params)} into the"
+            " members of the interface",
+            f"func ({receiver} *{structName}) UnmarshalJSON(data []byte) error {{",
+            f"  type plain {structName}",
+            "  value := struct {",
+            "    *plain",
+        ]
+        + fields
+        + [
+            f"  }}{{plain: (*plain)({receiver})}}",
+            "  err := json.Unmarshal(data, &value)",
+            "  if err != nil {",
+            "    return err",
+            "  }",
+        ]
+        + decoders
+        + ["  return nil", "}"]
+    )
+
+
+def formatSwitch(
+    receiver: str,
+    method: str,
+    returnType: str,
+    comment: str,
+    cases: list[tuple[str, str]],
+) -> str:
+    """Formats a method returning the value of the first matching case, nil or unknown otherwise."""
+
+    result = [formatComment(comment), f"func ({receiver}) {method}() {returnType} {{"]
+    if cases:
+        result.append("  switch {")
+        for condition, value in cases:
+            result.extend([f"  case {condition}:", f"    return {value}"])
+        result.append("  }")
+    zero = "nil" if returnType.startswith("*") else returnType + "Unknown"
+    result.extend([f"  return {zero}", "}"])
+    return "\n".join(result)
+
+
+def formatUpdateAccessors(tokenByName: dict[str, api_parser.Token]) -> str:
+    """Generates Update.Kind and the effective chat, user, sender chat and message accessors."""
+
+    update = tokenByName.get("Update")
+    if update is None:
+        return ""
+    kinds = []
+    cases = {"chat": [], "user": [], "senderChat": [], "message": []}
+    for param in update.params:
+        payload = tokenByName.get(param.typeName)
+        if payload is None:
+            continue
+        kind = "UpdateKind" + toCamelCase(param.name)
+        field = "u." + toCamelCase(param.name)
+        kinds.append((kind, param.name, f"{field} != nil"))
+        params = {p.name: p.typeName for p in payload.params}
+
+        if params.get("chat") == "Chat":
+            cases["chat"].append((f"{field} != nil", f"{field}.Chat"))
+        elif params.get("message") == "Message":
+            cases["chat"].append(
+                (f"{field} != nil && {field}.Message != nil", f"{field}.Message.Chat")
+            )
+        elif params.get("message") == "MaybeInaccessibleMessage":
+            cases["chat"].append(
+                (
+                    f"{field} != nil && {field}.Message != nil",
+                    f"maybeMessageChat({field}.Message)",
+                )
+            )
+        user = next((n for n in UPDATE_USER_FIELDS if params.get(n) == "User"), None)
+        if user:
+            cases["user"].append((f"{field} != nil", f"{field}.{toCamelCase(user)}"))
+        elif params.get("source") == "ChatBoostSource":
+            source = f"{field}.Source"
+            cases["user"].append((f"{field} != nil && {source} != nil", f"{source}.User"))
+        elif params.get("boost") == "ChatBoost":
+            source = f"{field}.Boost.Source"
+            cases["user"].append(
+                (
+                    f"{field} != nil && {field}.Boost != nil && {source} != nil",
+                    f"{source}.User",
+                )
+            )
+        senderChat = next(
+            (n for n in UPDATE_SENDER_CHAT_FIELDS if params.get(n) == "Chat"), None
+        )
+        if senderChat:
+            cases["senderChat"].append(
+                (f"{field} != nil", f"{field}.{toCamelCase(senderChat)}")
+            )
+        if param.typeName == "Message":
+            cases["message"].append((f"{field} != nil", field))
+        elif params.get("message") == "Message":
+            cases["message"].append((f"{field} != nil", f"{field}.Message"))
+        elif params.get("message") == "MaybeInaccessibleMessage":
+            cases["message"].append(
+                (f"{field} != nil", f"accessibleMessage({field}.Message)")
+            )
+
+    result = [
+        "// UpdateKind is the json name of the Update field that is set",
+        "type UpdateKind string",
+        "const (",
+    ]
+    for kind, name, _ in kinds:
+        result.append(f'  {kind} UpdateKind = "{name}"')
+    result.extend(
+        [
+            "  // UpdateKindUnknown is an update of a kind added after the API version",
+            '  UpdateKindUnknown UpdateKind = "unknown"',
+            ")",
+        ]
+    )
+    receiver = "u *Update"
+    accessors = [
+        formatSwitch(
+            receiver,
+            "Kind",
+            "UpdateKind",
+            "Kind returns the kind of the update, e.g. UpdateKindMessage",
+            [(condition, kind) for kind, _, condition in kinds],
+        ),
+        formatSwitch(
+            receiver,
+            "EffectiveChat",
+            "*Chat",
+            "EffectiveChat returns the chat the update happened in, for callback queries the chat"
+            " of the message with the button. Nil if there is no chat, e.g. for inline queries",
+            cases["chat"],
+        ),
+        formatSwitch(
+            receiver,
+            "EffectiveUser",
+            "*User",
+            "EffectiveUser returns the user who caused the update, the booster for the chat"
+            " boosts. Nil if it is unknown, e.g. for channel posts or unclaimed giveaway boosts",
+            cases["user"],
+        ),
+        formatSwitch(
+            receiver,
+            "EffectiveSenderChat",
+            "*Chat",
+            "EffectiveSenderChat returns the chat on behalf of which the message was sent, the"
+            " reaction was changed or the poll was answered, nil if the user acted as themselves",
+            cases["senderChat"],
+        ),
+        formatSwitch(
+            receiver,
+            "EffectiveMessage",
+            "*Message",
+            "EffectiveMessage returns the new or edited message or post, or the message with the"
+            " pressed button. Nil for the other updates and the inaccessible messages",
+            cases["message"],
+        ),
+    ]
+    return "\n".join(result + accessors)
+
+
+def formatCondition(field: str, typeName: str) -> str:
+    """Formats the go condition that the optional field is set."""
+
+    goType = formatType(typeName)
+    if goType.startswith("[]"):
+        return f"len({field}) != 0"
+    if goType.startswith("*") or goType == "interface{}" or goType in INTERFACE_TYPES:
+        return f"{field} != nil"
+    if goType == "bool":
+        return field
+    if goType == "string":
+        return f'{field} != ""'
+    return f"{field} != 0"
+
+
+def isFileType(token: api_parser.Token) -> bool:
+    """Reports if the type describes a file that can be downloaded with its file_id."""
+    return token.name != "File" and "file_id" in [p.name for p in token.params]
+
+
+def formatFileAccessor(token: api_parser.Token) -> str:
+    """Generates the File accessor of a type with file_id, e.g. Audio or PhotoSize."""
+
+    structName = toCamelCase(token.name)
+    receiver = structName[0].lower()
+    fields = [
+        f"{toCamelCase(p.name)}: {receiver}.{toCamelCase(p.name)}"
+        for p in token.params
+        if p.name in ["file_id", "file_unique_id", "file_size"]
+    ]
+    return textwrap.dedent(
+        f"""
+        // File returns the {structName} file for getFile and DownloadFile
+        func ({receiver} *{structName}) File() *File {{
+          return &File{{ {", ".join(fields)} }}
+        }}"""
+    )
+
+
+def formatMessageAccessors(tokenByName: dict[str, api_parser.Token]) -> str:
+    """Generates Message.ContentType, IsService and File from the Message fields."""
+
+    message = tokenByName.get("Message")
+    if message is None:
+        return ""
+    kinds = []
+    service = []
+    files = []
+    for param in message.params:
+        if param.name in MESSAGE_METADATA_FIELDS:
+            continue
+        isService = (
+            param.name in MESSAGE_SERVICE_FIELDS
+            or "service message" in param.description.lower()
+        )
+        if not isService and param.name not in MESSAGE_CONTENT_FIELDS:
+            print(
+                f"Message field {param.name} is not classified, add it to MESSAGE_*_FIELDS",
+                file=sys.stderr,
+            )
+            continue
+        kind = "ContentType" + toCamelCase(param.name)
+        field = "m." + toCamelCase(param.name)
+        kinds.append((kind, param.name, formatCondition(field, param.typeName)))
+        if isService:
+            service.append(kind)
+            continue
+        fileType = tokenByName.get(param.typeName)
+        if fileType is not None and isFileType(fileType):
+            files.append((f"{field} != nil", f"{field}.File()"))
+        elif param.typeName == "Array of PhotoSize":
+            files.append((f"len({field}) != 0", "m.LargestPhoto().File()"))
+
+    result = [
+        "// ContentType is the json name of the Message field with the content",
+        "type ContentType string",
+        "const (",
+    ]
+    for kind, name, _ in kinds:
+        result.append(f'  {kind} ContentType = "{name}"')
+    result.extend(
+        [
+            "  // ContentTypeUnknown is a message with a content added after the API version",
+            '  ContentTypeUnknown ContentType = "unknown"',
+            ")",
+            formatSwitch(
+                "m *Message",
+                "ContentType",
+                "ContentType",
+                "ContentType returns the type of the message content or of the service message,"
+                " e.g. ContentTypePhoto or ContentTypeNewChatMembers",
+                [(condition, kind) for kind, _, condition in kinds],
+            ),
+            formatComment(
+                "IsService reports if the message is a service message, e.g. about a new"
+                " chat member or a pinned message"
+            ),
+            "func (m *Message) IsService() bool {",
+        ]
+    )
+    if service:
+        result.extend(
+            [
+                "  switch m.ContentType() {",
+                "  case " + ",\n    ".join(service) + ":",
+                "    return true",
+                "  }",
+            ]
+        )
+    result.extend(
+        [
+            "  return false",
+            "}",
+            formatSwitch(
+                "m *Message",
+                "File",
+                "*File",
+                "File returns the file of the message media, for photos the largest size. Nil"
+                " if the message has no file",
+                files,
+            ),
+        ]
+    )
+    for token in tokenByName.values():
+        if token.name[0].isupper() and isFileType(token):
+            result.append(formatFileAccessor(token))
+    return "\n".join(result)
+
+
+def sectionComment(text: str) -> str:
+    """Comment of a section, followed by a blank line to keep it out of the next doc comment."""
+
+    return f"// {text}\n"
+
+
+def formatTokens(
+    tokens: list[api_parser.Token], apiVersion: str = "", apiDate: str = ""
+) -> str:
     """Formats all tokens (types, methods, etc) to a golang file."""
 
-    tokenByName = {}
+    tokenByName = {tok.name: tok for tok in tokens}
     structNames = {}
     result = [
         "// Telegram bot API classes and enpoint",
         "package tgbot",
+        'import (\n"context"\n"encoding/json"\n"time"\n)',
+        formatVersion(apiVersion, apiDate),
     ]
     for tok in tokens:
-        tokenByName[tok.name] = tok
         if tok.name[0].islower() or tok.name in ONEOF_TYPES:
             continue
         structNames[tok.name.lower()] = tok
-        result.append(formatStruct(tok))
+        if tok.name in INTERFACE_TYPES:
+            result.append(formatInterfaceType(tok, tokenByName))
+        else:
+            result.append(formatStruct(tok))
 
-    result.append("// Oneof type fields are merged into one")
+    result.append(sectionComment("Oneof type fields are merged into one"))
     for typeName, memberTypes in ONEOF_TYPES.items():
+        members = [tokenByName[m] for m in memberTypes if m in tokenByName]
+        if not members:
+            continue
         names = []
         fields = {}
-        for oneof in [tokenByName[memberType] for memberType in memberTypes]:
+        for oneof in members:
             names.append(oneof.name)
             for param in oneof.params:
                 fields[param.name] = api_parser.Param(
@@ -218,7 +862,21 @@ def formatTokens(tokens: list[api_parser.Token]) -> str:
             )
         )
 
-    result.append("// Bot request and response types")
+    result.append(sectionComment("Interface types are implemented by their members"))
+    for typeName, memberTypes in INTERFACE_TYPES.items():
+        members = [tokenByName[m] for m in memberTypes if m in tokenByName]
+        for member in members:
+            result.append(formatInterfaceMember(typeName, member))
+        if members:
+            result.append(formatMemberChooser(typeName, members))
+
+    result.append(sectionComment("Update kind and the effective chat, user and message"))
+    result.append(formatUpdateAccessors(tokenByName))
+
+    result.append(sectionComment("Message content type and files"))
+    result.append(formatMessageAccessors(tokenByName))
+
+    result.append(sectionComment("Bot request and response types"))
     for tok in tokens:
         if tok.name[0].isupper() or tok.name in api_parser.SKIP_METHODS:
             continue
@@ -244,4 +902,70 @@ def formatTokens(tokens: list[api_parser.Token]) -> str:
             continue
         result.append(formatMethod(tok, structNames))
         result.append("")
+    result.append(formatInterface(tokens))
+    return "\n".join(result)
+
+
+def apiMethods(tokens: list[api_parser.Token]) -> list[str]:
+    """Names of the generated TelegramApi methods."""
+
+    return [
+        toCamelCase(tok.name)
+        for tok in tokens
+        if tok.name[0].islower() and tok.name not in api_parser.SKIP_METHODS
+    ]
+
+
+def formatInterface(tokens: list[api_parser.Token]) -> str:
+    """Formats an interface with all TelegramApi methods, so the api can be mocked."""
+
+    result = [
+        "// TelegramApiInterface has all Bot API methods of TelegramApi",
+        "type TelegramApiInterface interface {",
+        "  // WithContext returns the api that makes all calls with the context",
+        "  WithContext(ctx context.Context) TelegramApiInterface",
+        "  // DownloadFile downloads the file by its id",
+        "  DownloadFile(ctx context.Context, fileID string) (*FileContent, error)",
+    ]
+    for name in apiMethods(tokens):
+        result.append(f"  {name}(request *{name}Request) (*{name}Response, error)")
+    result.append("}")
+    result.append("")
+    result.append("var _ TelegramApiInterface = (*TelegramApi)(nil)")
+    return "\n".join(result)
+
+
+def formatMock(tokens: list[api_parser.Token]) -> str:
+    """Formats MockApi methods of the tgbottest package: call, stub and recorded calls."""
+
+    result = [
+        "// Telegram bot API mock methods",
+        "package tgbottest",
+        'import "github.com/lanseg/tgbot"',
+    ]
+    for name in apiMethods(tokens):
+        request, response = f"tgbot.{name}Request", f"tgbot.{name}Response"
+        result.append(
+            textwrap.dedent(
+                f"""
+            // {name} records the call and returns the stubbed response
+            func (m *MockApi) {name}(request *{request}) (*{response}, error) {{
+              return mockCall[{response}](m, "{name}", request)
+            }}
+
+            // On{name} stubs the {name} calls
+            func (m *MockApi) On{name}(stub func(request *{request}) (*{response}, error)) {{
+              m.Stub("{name}", func(request interface{{}}) (interface{{}}, error) {{
+                return stub(request.(*{request}))
+              }})
+            }}
+
+            // {name}Calls returns requests of all {name} calls
+            func (m *MockApi) {name}Calls() []*{request} {{
+              return mockCalls[{request}](m, "{name}")
+            }}"""
+            )
+        )
+    result.append("")
+    result.append("var _ tgbot.TelegramApiInterface = (*MockApi)(nil)")
     return "\n".join(result)
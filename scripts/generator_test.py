@@ -0,0 +1,217 @@
+#!/usr/bin/env python3
+"""Golden tests for the generator.
+
+Run from the scripts directory: python3 -m unittest generator_test
+Set UPDATE_GOLDEN=1 to rewrite the golden files after an intended change of the output.
+"""
+
+import json
+import os
+import unittest
+
+import api_ir
+import api_parser
+from fetch_types import parseFile
+from format import golang
+
+TESTDATA = os.path.join(os.path.dirname(os.path.abspath(__file__)), "testdata")
+
+# Small hand-picked part of the docs, stable between the API releases
+EXCERPT = os.path.join(TESTDATA, "api_excerpt.html")
+
+
+def typeToken(name: str, fields: dict[str, str]) -> api_parser.Token:
+    """Builds a type with the fields and their descriptions."""
+    return api_parser.Token(
+        name,
+        "",
+        [
+            api_parser.Param(field, "String", description=text)
+            for field, text in fields.items()
+        ],
+    )
+
+
+class GoldenTest(unittest.TestCase):
+    """Compares the generator output with the expected files."""
+
+    def assertGolden(self, actual: str, goldenFile: str):
+        """Fails if the actual text is not the same as the golden file."""
+        if os.environ.get("UPDATE_GOLDEN"):
+            with open(goldenFile, "w", encoding="utf-8") as golden:
+                golden.write(actual)
+            return
+        with open(goldenFile, encoding="utf-8") as golden:
+            self.assertEqual(
+                golden.read(),
+                actual,
+                f"Output differs from {goldenFile}, run with UPDATE_GOLDEN=1 if intended",
+            )
+
+    def testExcerptTypes(self):
+        docParser = parseFile(EXCERPT)
+        self.assertGolden(
+            golang.formatTokens(
+                docParser.tokens, docParser.apiVersion, docParser.apiDate
+            )
+            + "\n",
+            os.path.join(TESTDATA, "api_excerpt.go.golden"),
+        )
+
+    def testExcerptMock(self):
+        tokens = parseFile(EXCERPT).tokens
+        self.assertGolden(
+            golang.formatMock(tokens) + "\n",
+            os.path.join(TESTDATA, "api_excerpt_mock.go.golden"),
+        )
+
+    def testExcerptTokens(self):
+        tokens = {token.name: token for token in parseFile(EXCERPT).tokens}
+        self.assertNotIn("December 29, 2023", tokens)
+        self.assertNotIn("Do I need a Local Bot API Server", tokens)
+        self.assertEqual(
+            [param.name for param in tokens["banChatMember"].params],
+            ["chat_id", "user_id", "until_date"],
+        )
+        self.assertEqual(tokens["sendMessage"].params[1].required, "Yes")
+
+    def testExcerptRecentChanges(self):
+        docParser = parseFile(EXCERPT)
+        self.assertEqual(docParser.apiVersion, "7.0")
+        self.assertEqual(docParser.apiDate, "December 29, 2023")
+
+        tokens = {token.name: token for token in docParser.tokens}
+        self.assertEqual(tokens["setMessageReaction"].addedIn, "7.0")
+        self.assertEqual(tokens["ReactionTypeEmoji"].addedIn, "7.0")
+        # Only the fields were added to these
+        self.assertEqual(tokens["Message"].addedIn, "")
+        self.assertEqual(tokens["Message"].params[4].addedIn, "7.0")
+        self.assertEqual(tokens["Chat"].addedIn, "")
+        self.assertEqual(tokens["Chat"].params[2].addedIn, "6.9")
+        self.assertEqual(tokens["banChatMember"].params[2].addedIn, "6.9")
+
+    def testExcerptIR(self):
+        ir = api_ir.toIR(parseFile(EXCERPT))
+        self.assertGolden(
+            json.dumps(ir, indent=2, ensure_ascii=False) + "\n",
+            os.path.join(TESTDATA, "api_excerpt.ir.json"),
+        )
+        self.assertGolden(
+            json.dumps(api_ir.toJSONSchema(ir), indent=2, ensure_ascii=False) + "\n",
+            os.path.join(TESTDATA, "api_excerpt.schema.json"),
+        )
+
+    def testExcerptFromIR(self):
+        docParser = parseFile(EXCERPT)
+        ir = json.loads(json.dumps(api_ir.toIR(docParser)))
+        self.assertEqual(
+            golang.formatTokens(api_ir.fromIR(ir), ir["apiVersion"], ir["apiDate"]),
+            golang.formatTokens(
+                docParser.tokens, docParser.apiVersion, docParser.apiDate
+            ),
+        )
+
+    def testSectionComments(self):
+        output = golang.formatTokens(parseFile(EXCERPT).tokens)
+        # Godoc would join a section comment directly above a type with its doc comment
+        for section, typeDoc in [
+            ("Update kind and the effective chat, user and message", "// UpdateKind "),
+            ("Message content type and files", "// ContentType "),
+        ]:
+            self.assertIn(f"// {section}\n\n{typeDoc}", output)
+
+    def testTypeRef(self):
+        self.assertEqual(
+            api_ir.typeRef("Array of Array of PhotoSize"),
+            {
+                "kind": "array",
+                "of": {"kind": "array", "of": {"kind": "ref", "name": "PhotoSize"}},
+            },
+        )
+        self.assertEqual(
+            api_ir.typeRef("Integer or String"),
+            {
+                "kind": "union",
+                "of": [
+                    {"kind": "primitive", "name": "integer"},
+                    {"kind": "primitive", "name": "string"},
+                ],
+            },
+        )
+
+    def testMemberCondition(self):
+        photo = typeToken(
+            "InlineQueryResultPhoto",
+            {
+                "type": "Type of the result, must be photo",
+                "id": "Unique identifier for this result",
+                "photo_url": "A valid URL of the photo",
+                "caption": "Optional. Caption of the photo",
+            },
+        )
+        cachedPhoto = typeToken(
+            "InlineQueryResultCachedPhoto",
+            {
+                "type": "Type of the result, must be photo",
+                "id": "Unique identifier for this result",
+                "photo_file_id": "A valid file identifier of the photo",
+            },
+        )
+        article = typeToken(
+            "InlineQueryResultArticle",
+            {
+                "type": "Type of the result, must be article",
+                "id": "Unique identifier for this result",
+                "title": "Title of the result",
+            },
+        )
+        results = [photo, cachedPhoto, article]
+        # Cached and linked photos have the same type
+        self.assertEqual(
+            golang.memberCondition(cachedPhoto, results),
+            ['fields.is("type", "photo")', 'fields.has("photo_file_id")'],
+        )
+        self.assertEqual(
+            golang.memberCondition(article, results),
+            ['fields.is("type", "article")'],
+        )
+
+        text = typeToken(
+            "InputTextMessageContent", {"message_text": "Text of the message"}
+        )
+        location = typeToken(
+            "InputLocationMessageContent",
+            {
+                "latitude": "Latitude of the location",
+                "longitude": "Longitude of the location",
+                "horizontal_accuracy": "Optional. The radius of uncertainty",
+            },
+        )
+        venue = typeToken(
+            "InputVenueMessageContent",
+            {
+                "latitude": "Latitude of the venue",
+                "longitude": "Longitude of the venue",
+                "title": "Name of the venue",
+                "address": "Address of the venue",
+            },
+        )
+        contents = [text, location, venue]
+        self.assertEqual(
+            golang.memberCondition(venue, contents),
+            [
+                'fields.has("latitude")',
+                'fields.has("longitude")',
+                'fields.has("title")',
+                'fields.has("address")',
+            ],
+        )
+        chooser = golang.formatMemberChooser("InputMessageContent", contents)
+        self.assertLess(
+            chooser.index("&InputVenueMessageContent{}"),
+            chooser.index("&InputLocationMessageContent{}"),
+        )
+
+
+if __name__ == "__main__":
+    unittest.main()
@@ -0,0 +1,19 @@
+#!/usr/bin/bash
+# Pins a fresh copy of the Bot API docs and regenerates the checked in code from it.
+# Review the diff of telegram_types.go and tgbottest/mock_api.go before committing.
+
+set -euo pipefail
+
+cd "$(dirname "$0")"
+
+curl --fail --silent --show-error https://core.telegram.org/bots/api > testdata/api.html.new
+if [ -f testdata/api.html ]
+then
+  python3 fetch_types.py --diff testdata/api.html testdata/api.html.new
+fi
+mv testdata/api.html.new testdata/api.html
+python3 fetch_types.py testdata/api.html | gofmt -s > ../telegram_types.go
+python3 fetch_types.py testdata/api.html --mock | gofmt -s > ../tgbottest/mock_api.go
+python3 -m unittest generator_test
+
+git diff --stat -- testdata/api.html ../telegram_types.go ../tgbottest/mock_api.go
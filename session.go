@@ -0,0 +1,311 @@
+package tgbot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ErrSessionConflict is returned by CompareAndSwap when the session was changed by someone else.
+var ErrSessionConflict = errors.New("Session was changed concurrently")
+
+// SessionEntry is a stored session value: json with its version, which grows with every write
+// and is used by CompareAndSwap. Versions of a key never repeat, even after the session is
+// deleted or expires, so a stale version never matches a new session.
+type SessionEntry struct {
+	Value   json.RawMessage `json:"value"`
+	Version int64           `json:"version"`
+	// Expires is zero for the sessions without ttl
+	Expires time.Time `json:"expires,omitempty"`
+}
+
+func (e *SessionEntry) expired(now time.Time) bool {
+	return !e.Expires.IsZero() && !now.Before(e.Expires)
+}
+
+func sessionExpires(now time.Time, ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(ttl)
+}
+
+// SessionStore keeps small json values between the updates and restarts, e.g. user settings.
+// Expired sessions are never returned.
+type SessionStore interface {
+	// Get returns the session, nil without error if there is none.
+	Get(ctx context.Context, key string) (*SessionEntry, error)
+	// Set writes the session, ttl <= 0 keeps it forever.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// CompareAndSwap writes the session only if its version is still the same, version 0 means
+	// that there must be no session. Fails with ErrSessionConflict otherwise.
+	CompareAndSwap(ctx context.Context, key string, version int64, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, key string) error
+}
+
+type memorySessionStore struct {
+	mu       sync.Mutex
+	sessions map[string]*SessionEntry
+	// version is the last version given to a session, shared by all keys
+	version int64
+	now     func() time.Time
+}
+
+// NewMemorySessionStore returns a SessionStore that keeps the sessions in memory.
+func NewMemorySessionStore() SessionStore {
+	return newMemorySessionStore()
+}
+
+func newMemorySessionStore() *memorySessionStore {
+	return &memorySessionStore{
+		sessions: map[string]*SessionEntry{},
+		now:      time.Now,
+	}
+}
+
+// get returns the session that is not expired, must be called with the lock held.
+func (s *memorySessionStore) get(key string) *SessionEntry {
+	entry, ok := s.sessions[key]
+	if !ok {
+		return nil
+	}
+	if entry.expired(s.now()) {
+		delete(s.sessions, key)
+		return nil
+	}
+	return entry
+}
+
+func (s *memorySessionStore) Get(_ context.Context, key string) (*SessionEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry := s.get(key)
+	if entry == nil {
+		return nil, nil
+	}
+	result := *entry
+	return &result, nil
+}
+
+// put writes the session with the next version, must be called with the lock held.
+func (s *memorySessionStore) put(key string, value []byte, ttl time.Duration) {
+	s.version++
+	s.sessions[key] = &SessionEntry{
+		Value:   append(json.RawMessage{}, value...),
+		Version: s.version,
+		Expires: sessionExpires(s.now(), ttl),
+	}
+}
+
+func (s *memorySessionStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.put(key, value, ttl)
+	return nil
+}
+
+func (s *memorySessionStore) CompareAndSwap(_ context.Context, key string, version int64, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current := int64(0)
+	if entry := s.get(key); entry != nil {
+		current = entry.Version
+	}
+	if current != version {
+		return ErrSessionConflict
+	}
+	s.put(key, value, ttl)
+	return nil
+}
+
+func (s *memorySessionStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sessions, key)
+	return nil
+}
+
+// GetSession reads the session value, returns false if there is no session.
+func GetSession[T any](ctx context.Context, store SessionStore, key string) (T, bool, error) {
+	var value T
+	entry, err := store.Get(ctx, key)
+	if err != nil || entry == nil {
+		return value, false, err
+	}
+	if err := json.Unmarshal(entry.Value, &value); err != nil {
+		return value, false, fmt.Errorf("Cannot decode session %q: %s", key, err)
+	}
+	return value, true, nil
+}
+
+// SetSession writes the session value, ttl <= 0 keeps it forever.
+func SetSession[T any](ctx context.Context, store SessionStore, key string, value T, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("Cannot encode session %q: %s", key, err)
+	}
+	return store.Set(ctx, key, data, ttl)
+}
+
+// maxSessionRetries limits the UpdateSession attempts under a high contention.
+const maxSessionRetries = 10
+
+// UpdateSession changes the session value with compare-and-swap, retrying if it was changed
+// concurrently. The value is zero if there is no session yet.
+func UpdateSession[T any](ctx context.Context, store SessionStore, key string, ttl time.Duration, update func(value *T) error) error {
+	for attempt := 0; attempt < maxSessionRetries; attempt++ {
+		var value T
+		version := int64(0)
+		entry, err := store.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if entry != nil {
+			version = entry.Version
+			if err := json.Unmarshal(entry.Value, &value); err != nil {
+				return fmt.Errorf("Cannot decode session %q: %s", key, err)
+			}
+		}
+		if err := update(&value); err != nil {
+			return err
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("Cannot encode session %q: %s", key, err)
+		}
+		err = store.CompareAndSwap(ctx, key, version, data, ttl)
+		if !errors.Is(err, ErrSessionConflict) {
+			return err
+		}
+	}
+	return ErrSessionConflict
+}
+
+// SessionScope defines whose session it is.
+type SessionScope int
+
+const (
+	// ChatSession is shared by everyone in the chat
+	ChatSession SessionScope = iota
+	// UserSession follows the user across the chats
+	UserSession
+	// ChatUserSession is separate for each user in each chat
+	ChatUserSession
+)
+
+// SessionKey returns the key of the named session of the update chat or user, e.g.
+// "user:42:settings", false if the update has no such chat or user.
+func SessionKey(scope SessionScope, update *Update, name string) (string, bool) {
+	chatID, userID := updateChatID(update), updateUserID(update)
+	chat, user := strconv.FormatInt(chatID, 10), strconv.FormatInt(userID, 10)
+	switch {
+	case scope == ChatSession && chatID != 0:
+		return "chat:" + chat + ":" + name, true
+	case scope == UserSession && userID != 0:
+		return "user:" + user + ":" + name, true
+	case scope == ChatUserSession && chatID != 0 && userID != 0:
+		return "chat:" + chat + ":user:" + user + ":" + name, true
+	}
+	return "", false
+}
+
+type sessionContextKey struct{}
+
+type updateSessions struct {
+	store  SessionStore
+	ttl    time.Duration
+	update *Update
+}
+
+// SessionHandler makes the store available to the handler through LoadSession, SaveSession and
+// ModifySession. Sessions are written with the ttl, <= 0 to keep them forever.
+func SessionHandler(store SessionStore, ttl time.Duration, handler UpdateHandler) UpdateHandler {
+	return func(ctx context.Context, update *Update) {
+		handler(context.WithValue(ctx, sessionContextKey{}, &updateSessions{
+			store:  store,
+			ttl:    ttl,
+			update: update,
+		}), update)
+	}
+}
+
+func contextSessionKey(ctx context.Context, scope SessionScope, name string) (*updateSessions, string, error) {
+	sessions, ok := ctx.Value(sessionContextKey{}).(*updateSessions)
+	if !ok {
+		return nil, "", fmt.Errorf("No sessions in the context, use SessionHandler")
+	}
+	key, ok := SessionKey(scope, sessions.update, name)
+	if !ok {
+		return nil, "", fmt.Errorf("Update %d has no chat or user for session %q", sessions.update.UpdateID, name)
+	}
+	return sessions, key, nil
+}
+
+// LoadSession reads the named session of the current update, zero value if there is none.
+func LoadSession[T any](ctx context.Context, scope SessionScope, name string) (T, error) {
+	var value T
+	sessions, key, err := contextSessionKey(ctx, scope, name)
+	if err != nil {
+		return value, err
+	}
+	value, _, err = GetSession[T](ctx, sessions.store, key)
+	return value, err
+}
+
+// SaveSession writes the named session of the current update.
+func SaveSession[T any](ctx context.Context, scope SessionScope, name string, value T) error {
+	sessions, key, err := contextSessionKey(ctx, scope, name)
+	if err != nil {
+		return err
+	}
+	return SetSession(ctx, sessions.store, key, value, sessions.ttl)
+}
+
+// ModifySession changes the named session of the current update with UpdateSession.
+func ModifySession[T any](ctx context.Context, scope SessionScope, name string, update func(value *T) error) error {
+	sessions, key, err := contextSessionKey(ctx, scope, name)
+	if err != nil {
+		return err
+	}
+	return UpdateSession(ctx, sessions.store, key, sessions.ttl, update)
+}
+
+type sessionConversationStore struct {
+	store SessionStore
+	ttl   time.Duration
+}
+
+// NewSessionConversationStore keeps the conversations in the session store, they expire with
+// the ttl, so Conversations.ExpireStale finds nothing and OnTimeout is called only when the user
+// writes before the ttl.
+func NewSessionConversationStore(store SessionStore, ttl time.Duration) ConversationStore {
+	return &sessionConversationStore{store: store, ttl: ttl}
+}
+
+func conversationSessionKey(key ConversationKey) string {
+	return fmt.Sprintf("conversation:%d:%d", key.ChatID, key.UserID)
+}
+
+func (s *sessionConversationStore) Get(ctx context.Context, key ConversationKey) (*ConversationState, error) {
+	state, ok, err := GetSession[*ConversationState](ctx, s.store, conversationSessionKey(key))
+	if !ok {
+		return nil, err
+	}
+	return state, err
+}
+
+func (s *sessionConversationStore) Set(ctx context.Context, state *ConversationState) error {
+	return SetSession(ctx, s.store, conversationSessionKey(state.Key), state, s.ttl)
+}
+
+func (s *sessionConversationStore) Delete(ctx context.Context, key ConversationKey) error {
+	return s.store.Delete(ctx, conversationSessionKey(key))
+}
+
+func (s *sessionConversationStore) Expire(context.Context, time.Time) ([]*ConversationState, error) {
+	return nil, nil
+}
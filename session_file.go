@@ -0,0 +1,135 @@
+package tgbot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileSessionStore is a SessionStore that keeps all sessions in memory and writes them to a json
+// file after every change, for the bots with a few thousands of users and no database.
+type FileSessionStore struct {
+	*memorySessionStore
+	path string
+}
+
+// sessionFile is the contents of the FileSessionStore file.
+type sessionFile struct {
+	// Version is the last version given to a session, so the versions do not repeat after restart
+	Version  int64                    `json:"version"`
+	Sessions map[string]*SessionEntry `json:"sessions"`
+}
+
+// NewFileSessionStore reads the sessions from the file, the file is created on the first write.
+func NewFileSessionStore(path string) (*FileSessionStore, error) {
+	store := &FileSessionStore{
+		memorySessionStore: newMemorySessionStore(),
+		path:               path,
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return store, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read sessions: %s", err)
+	}
+	file := &sessionFile{}
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("Cannot parse sessions file %s: %s", path, err)
+	}
+	store.version = file.Version
+	if file.Sessions != nil {
+		store.sessions = file.Sessions
+	}
+	return store, nil
+}
+
+// save drops the expired sessions and writes the file, must be called with the lock held.
+func (s *FileSessionStore) save() error {
+	now := s.now()
+	for key, entry := range s.sessions {
+		if entry.expired(now) {
+			delete(s.sessions, key)
+		}
+	}
+	data, err := json.Marshal(&sessionFile{Version: s.version, Sessions: s.sessions})
+	if err != nil {
+		return err
+	}
+	if err := writeFileAtomically(s.path, data); err != nil {
+		return fmt.Errorf("Cannot save sessions: %s", err)
+	}
+	return nil
+}
+
+// writeFileAtomically writes the data to a temporary file, syncs it to the disk and renames it,
+// so the file is never half written.
+func writeFileAtomically(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// change changes the session of the key and saves the file, the session is restored if the file
+// cannot be written. Must be called with the lock held.
+func (s *FileSessionStore) change(key string, apply func()) error {
+	previous, existed := s.sessions[key]
+	apply()
+	if err := s.save(); err != nil {
+		if existed {
+			s.sessions[key] = previous
+		} else {
+			delete(s.sessions, key)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *FileSessionStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.change(key, func() {
+		s.put(key, value, ttl)
+	})
+}
+
+func (s *FileSessionStore) CompareAndSwap(ctx context.Context, key string, version int64, value []byte, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	current := int64(0)
+	if entry := s.get(key); entry != nil {
+		current = entry.Version
+	}
+	if current != version {
+		return ErrSessionConflict
+	}
+	return s.change(key, func() {
+		s.put(key, value, ttl)
+	})
+}
+
+func (s *FileSessionStore) Delete(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.change(key, func() {
+		delete(s.sessions, key)
+	})
+}
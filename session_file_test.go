@@ -0,0 +1,84 @@
+package tgbot_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lanseg/tgbot"
+)
+
+func newFileSessionStore(t *testing.T, path string) *tgbot.FileSessionStore {
+	t.Helper()
+	store, err := tgbot.NewFileSessionStore(path)
+	if err != nil {
+		t.Fatalf("Cannot open sessions: %s", err)
+	}
+	return store
+}
+
+func TestFileSessionStore(t *testing.T) {
+	testSessionStore(t, newFileSessionStore(t, filepath.Join(t.TempDir(), "sessions.json")))
+}
+
+func TestFileSessionStoreReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	store := newFileSessionStore(t, path)
+	if err := store.Set(ctx, "kept", []byte(`"value"`), 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Set(ctx, "deleted", []byte(`"value"`), 0); err != nil {
+		t.Fatal(err)
+	}
+	deleted := getVersion(t, store, "deleted")
+	if err := store.Delete(ctx, "deleted"); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened := newFileSessionStore(t, path)
+	if entry, err := reopened.Get(ctx, "kept"); err != nil || entry == nil || string(entry.Value) != `"value"` {
+		t.Errorf("Reopened store has session %+v, %v", entry, err)
+	}
+	if err := reopened.Set(ctx, "deleted", []byte(`"again"`), 0); err != nil {
+		t.Fatal(err)
+	}
+	if version := getVersion(t, reopened, "deleted"); version <= deleted {
+		t.Errorf("Session written after reopen has version %d, the deleted one had %d", version, deleted)
+	}
+}
+
+func TestFileSessionStoreSaveFails(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "sessions.json")
+	store := newFileSessionStore(t, path)
+	if err := store.Set(ctx, "key", []byte(`"saved"`), 0); err != nil {
+		t.Fatal(err)
+	}
+	saved := getVersion(t, store, "key")
+	// The file cannot be replaced by a directory, so every save fails.
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(path, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Set(ctx, "key", []byte(`"lost"`), 0); err == nil {
+		t.Fatalf("Set succeeded without saving the file")
+	}
+	if err := store.Set(ctx, "new", []byte(`"lost"`), 0); err == nil {
+		t.Fatalf("Set of a new key succeeded without saving the file")
+	}
+	if err := store.Delete(ctx, "key"); err == nil {
+		t.Fatalf("Delete succeeded without saving the file")
+	}
+	entry, err := store.Get(ctx, "key")
+	if err != nil || entry == nil || string(entry.Value) != `"saved"` || entry.Version != saved {
+		t.Errorf("Failed writes changed the session to %+v, %v", entry, err)
+	}
+	if entry, _ := store.Get(ctx, "new"); entry != nil {
+		t.Errorf("Failed write added the session %+v", entry)
+	}
+}
@@ -0,0 +1,229 @@
+package tgbot
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SQLSessionStore is a SessionStore in a database/sql table, which is created by CreateTable:
+//
+//	CREATE TABLE sessions (
+//	  session_key VARCHAR(255) PRIMARY KEY,
+//	  value TEXT NOT NULL,
+//	  version BIGINT NOT NULL,
+//	  expires BIGINT NOT NULL
+//	)
+//
+// expires is the unix time in milliseconds, zero for the sessions without ttl, the deletion time
+// for the deleted ones. Deleted and expired sessions stay in the table as tombstones, so the
+// versions of their keys do not repeat, until DeleteExpired purges them after TombstoneTTL.
+type SQLSessionStore struct {
+	db    *sql.DB
+	table string
+	now   func() time.Time
+
+	// TombstoneTTL is how long the tombstones are kept, DefaultTombstoneTTL by default, zero
+	// keeps them forever. A CompareAndSwap with a version read before the session was deleted
+	// or expired is only guaranteed to conflict while its tombstone is kept.
+	TombstoneTTL time.Duration
+
+	// Placeholder returns the n-th query parameter, starting from 1: "?" by default, use
+	// PostgresPlaceholder for PostgreSQL
+	Placeholder func(n int) string
+}
+
+// NewSQLSessionStore uses the table of the database, the driver is chosen by the caller.
+func NewSQLSessionStore(db *sql.DB, table string) *SQLSessionStore {
+	return &SQLSessionStore{
+		db:           db,
+		table:        table,
+		now:          time.Now,
+		TombstoneTTL: DefaultTombstoneTTL,
+		Placeholder: func(int) string {
+			return "?"
+		},
+	}
+}
+
+// DefaultTombstoneTTL keeps the tombstones much longer than any session handler runs.
+const DefaultTombstoneTTL = 24 * time.Hour
+
+// PostgresPlaceholder is the Placeholder for PostgreSQL: $1, $2, etc.
+func PostgresPlaceholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+// query replaces "{table}" with the table name and every "?" with the placeholder.
+func (s *SQLSessionStore) query(text string) string {
+	text = strings.ReplaceAll(text, "{table}", s.table)
+	parts := strings.Split(text, "?")
+	result := parts[0]
+	for i, part := range parts[1:] {
+		result += s.Placeholder(i+1) + part
+	}
+	return result
+}
+
+func (s *SQLSessionStore) expires(ttl time.Duration) int64 {
+	if ttl <= 0 {
+		return 0
+	}
+	return s.now().Add(ttl).UnixMilli()
+}
+
+// CreateTable creates the session table if it does not exist.
+func (s *SQLSessionStore) CreateTable(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, s.query(`CREATE TABLE IF NOT EXISTS {table} (
+		session_key VARCHAR(255) PRIMARY KEY,
+		value TEXT NOT NULL,
+		version BIGINT NOT NULL,
+		expires BIGINT NOT NULL
+	)`))
+	if err != nil {
+		return fmt.Errorf("Cannot create session table: %s", err)
+	}
+	return nil
+}
+
+func (s *SQLSessionStore) Get(ctx context.Context, key string) (*SessionEntry, error) {
+	var value string
+	var version, expires int64
+	err := s.db.QueryRowContext(ctx,
+		s.query("SELECT value, version, expires FROM {table} WHERE session_key = ?"), key,
+	).Scan(&value, &version, &expires)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Cannot read session %q: %s", key, err)
+	}
+	entry := &SessionEntry{Value: []byte(value), Version: version}
+	if expires != 0 {
+		entry.Expires = time.UnixMilli(expires)
+	}
+	if entry.expired(s.now()) {
+		return nil, nil
+	}
+	return entry, nil
+}
+
+// update overwrites the row of the key, returns false if there is no row.
+func (s *SQLSessionStore) update(ctx context.Context, key string, value []byte, ttl time.Duration) (bool, error) {
+	result, err := s.db.ExecContext(ctx,
+		s.query("UPDATE {table} SET value = ?, version = version + 1, expires = ? WHERE session_key = ?"),
+		string(value), s.expires(ttl), key)
+	if err != nil {
+		return false, fmt.Errorf("Cannot write session %q: %s", key, err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return updated > 0, nil
+}
+
+func (s *SQLSessionStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if updated, err := s.update(ctx, key, value, ttl); err != nil || updated {
+		return err
+	}
+	err := s.insert(ctx, key, value, ttl)
+	if !errors.Is(err, ErrSessionConflict) {
+		return err
+	}
+	// The row was inserted concurrently, it is overwritten as if it was there before
+	updated, err := s.update(ctx, key, value, ttl)
+	if err == nil && !updated {
+		err = fmt.Errorf("Cannot write session %q: the row is removed concurrently", key)
+	}
+	return err
+}
+
+// insert adds the row of a new key, fails with ErrSessionConflict if there is a row already.
+func (s *SQLSessionStore) insert(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	_, err := s.db.ExecContext(ctx,
+		s.query("INSERT INTO {table} (session_key, value, version, expires) VALUES (?, ?, 1, ?)"),
+		key, string(value), s.expires(ttl))
+	if err == nil {
+		return nil
+	}
+	// Most likely the row was inserted concurrently
+	var version int64
+	getErr := s.db.QueryRowContext(ctx,
+		s.query("SELECT version FROM {table} WHERE session_key = ?"), key,
+	).Scan(&version)
+	if getErr == nil {
+		return ErrSessionConflict
+	}
+	return fmt.Errorf("Cannot write session %q: %s", key, err)
+}
+
+func (s *SQLSessionStore) CompareAndSwap(ctx context.Context, key string, version int64, value []byte, ttl time.Duration) error {
+	now := s.now().UnixMilli()
+	if version == 0 {
+		// A tombstone of a deleted or expired session keeps counting the versions
+		result, err := s.db.ExecContext(ctx,
+			s.query(`UPDATE {table} SET value = ?, version = version + 1, expires = ?
+				WHERE session_key = ? AND expires <> 0 AND expires <= ?`),
+			string(value), s.expires(ttl), key, now)
+		if err != nil {
+			return fmt.Errorf("Cannot write session %q: %s", key, err)
+		}
+		if updated, err := result.RowsAffected(); err != nil || updated > 0 {
+			return err
+		}
+		return s.insert(ctx, key, value, ttl)
+	}
+	result, err := s.db.ExecContext(ctx,
+		s.query(`UPDATE {table} SET value = ?, version = version + 1, expires = ?
+			WHERE session_key = ? AND version = ? AND (expires = 0 OR expires > ?)`),
+		string(value), s.expires(ttl), key, version, now)
+	if err != nil {
+		return fmt.Errorf("Cannot write session %q: %s", key, err)
+	}
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrSessionConflict
+	}
+	return nil
+}
+
+// Delete turns the session into a tombstone that expires now.
+func (s *SQLSessionStore) Delete(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx,
+		s.query("UPDATE {table} SET value = '', expires = ? WHERE session_key = ?"), s.now().UnixMilli(), key)
+	if err != nil {
+		return fmt.Errorf("Cannot delete session %q: %s", key, err)
+	}
+	return nil
+}
+
+// DeleteExpired clears the values of the expired sessions, they are never returned but take the
+// space in the table, and removes the tombstones older than TombstoneTTL. Call it periodically,
+// otherwise the table keeps a row for every key ever used.
+func (s *SQLSessionStore) DeleteExpired(ctx context.Context) error {
+	now := s.now()
+	_, err := s.db.ExecContext(ctx,
+		s.query("UPDATE {table} SET value = '' WHERE expires <> 0 AND expires <= ? AND value <> ''"),
+		now.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("Cannot delete expired sessions: %s", err)
+	}
+	if s.TombstoneTTL <= 0 {
+		return nil
+	}
+	_, err = s.db.ExecContext(ctx,
+		s.query("DELETE FROM {table} WHERE expires <> 0 AND expires <= ?"),
+		now.Add(-s.TombstoneTTL).UnixMilli())
+	if err != nil {
+		return fmt.Errorf("Cannot delete session tombstones: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,243 @@
+package tgbot_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lanseg/tgbot"
+)
+
+type fakeSessionRow struct {
+	value   string
+	version int64
+	expires int64
+}
+
+// fakeSessionDB is a database/sql driver that runs the queries of SQLSessionStore on a map.
+type fakeSessionDB struct {
+	mu   sync.Mutex
+	rows map[string]*fakeSessionRow
+	// beforeInsert is called before an insert without the lock, e.g. to insert the row concurrently
+	beforeInsert func(key string)
+}
+
+func newFakeSessionDB(t *testing.T) (*fakeSessionDB, *tgbot.SQLSessionStore) {
+	fake := &fakeSessionDB{rows: map[string]*fakeSessionRow{}}
+	db := sql.OpenDB(fake)
+	t.Cleanup(func() { db.Close() })
+	store := tgbot.NewSQLSessionStore(db, "sessions")
+	store.Placeholder = tgbot.PostgresPlaceholder
+	if err := store.CreateTable(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return fake, store
+}
+
+func (db *fakeSessionDB) Connect(context.Context) (driver.Conn, error) {
+	return &fakeSessionConn{db: db}, nil
+}
+
+func (db *fakeSessionDB) Driver() driver.Driver {
+	return nil
+}
+
+type fakeSessionConn struct {
+	db *fakeSessionDB
+}
+
+func (c *fakeSessionConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("Prepare is not supported")
+}
+
+func (c *fakeSessionConn) Close() error {
+	return nil
+}
+
+func (c *fakeSessionConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("Begin is not supported")
+}
+
+func queryArgs(named []driver.NamedValue) []interface{} {
+	args := make([]interface{}, len(named))
+	for i, arg := range named {
+		args[i] = arg.Value
+	}
+	return args
+}
+
+func (c *fakeSessionConn) ExecContext(_ context.Context, query string, named []driver.NamedValue) (driver.Result, error) {
+	query = strings.Join(strings.Fields(query), " ")
+	args := queryArgs(named)
+	if strings.HasPrefix(query, "INSERT") && c.db.beforeInsert != nil {
+		c.db.beforeInsert(args[0].(string))
+	}
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	switch {
+	case strings.HasPrefix(query, "CREATE TABLE IF NOT EXISTS sessions"):
+		return driver.RowsAffected(0), nil
+	case query == "INSERT INTO sessions (session_key, value, version, expires) VALUES ($1, $2, 1, $3)":
+		key := args[0].(string)
+		if _, ok := c.db.rows[key]; ok {
+			return nil, fmt.Errorf("duplicate key %q", key)
+		}
+		c.db.rows[key] = &fakeSessionRow{value: args[1].(string), version: 1, expires: args[2].(int64)}
+		return driver.RowsAffected(1), nil
+	case query == "UPDATE sessions SET value = $1, version = version + 1, expires = $2 WHERE session_key = $3":
+		return c.db.update(args[2].(string), args[0].(string), args[1].(int64), func(*fakeSessionRow) bool {
+			return true
+		}), nil
+	case query == "UPDATE sessions SET value = $1, version = version + 1, expires = $2 WHERE session_key = $3 AND expires <> 0 AND expires <= $4":
+		return c.db.update(args[2].(string), args[0].(string), args[1].(int64), func(row *fakeSessionRow) bool {
+			return row.expires != 0 && row.expires <= args[3].(int64)
+		}), nil
+	case query == "UPDATE sessions SET value = $1, version = version + 1, expires = $2 WHERE session_key = $3 AND version = $4 AND (expires = 0 OR expires > $5)":
+		return c.db.update(args[2].(string), args[0].(string), args[1].(int64), func(row *fakeSessionRow) bool {
+			return row.version == args[3].(int64) && (row.expires == 0 || row.expires > args[4].(int64))
+		}), nil
+	case query == "UPDATE sessions SET value = '', expires = $1 WHERE session_key = $2":
+		if row, ok := c.db.rows[args[1].(string)]; ok {
+			row.value, row.expires = "", args[0].(int64)
+			return driver.RowsAffected(1), nil
+		}
+		return driver.RowsAffected(0), nil
+	case query == "UPDATE sessions SET value = '' WHERE expires <> 0 AND expires <= $1 AND value <> ''":
+		updated := int64(0)
+		for _, row := range c.db.rows {
+			if row.expires != 0 && row.expires <= args[0].(int64) && row.value != "" {
+				row.value = ""
+				updated++
+			}
+		}
+		return driver.RowsAffected(updated), nil
+	case query == "DELETE FROM sessions WHERE expires <> 0 AND expires <= $1":
+		deleted := int64(0)
+		for key, row := range c.db.rows {
+			if row.expires != 0 && row.expires <= args[0].(int64) {
+				delete(c.db.rows, key)
+				deleted++
+			}
+		}
+		return driver.RowsAffected(deleted), nil
+	}
+	return nil, fmt.Errorf("Unexpected query %q", query)
+}
+
+// update sets the value of the matching row with the next version, must be called with the lock.
+func (db *fakeSessionDB) update(key string, value string, expires int64, match func(row *fakeSessionRow) bool) driver.Result {
+	row, ok := db.rows[key]
+	if !ok || !match(row) {
+		return driver.RowsAffected(0)
+	}
+	row.value, row.version, row.expires = value, row.version+1, expires
+	return driver.RowsAffected(1)
+}
+
+func (c *fakeSessionConn) QueryContext(_ context.Context, query string, named []driver.NamedValue) (driver.Rows, error) {
+	query = strings.Join(strings.Fields(query), " ")
+	args := queryArgs(named)
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	row, ok := c.db.rows[args[0].(string)]
+	switch query {
+	case "SELECT value, version, expires FROM sessions WHERE session_key = $1":
+		rows := &fakeSessionRows{columns: []string{"value", "version", "expires"}}
+		if ok {
+			rows.values = [][]driver.Value{{row.value, row.version, row.expires}}
+		}
+		return rows, nil
+	case "SELECT version FROM sessions WHERE session_key = $1":
+		rows := &fakeSessionRows{columns: []string{"version"}}
+		if ok {
+			rows.values = [][]driver.Value{{row.version}}
+		}
+		return rows, nil
+	}
+	return nil, fmt.Errorf("Unexpected query %q", query)
+}
+
+type fakeSessionRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (r *fakeSessionRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeSessionRows) Close() error {
+	return nil
+}
+
+func (r *fakeSessionRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func TestSQLSessionStore(t *testing.T) {
+	fake, store := newFakeSessionDB(t)
+	testSessionStore(t, store)
+
+	if err := store.DeleteExpired(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if row := fake.rows["key"]; row == nil || row.value == "" {
+		t.Errorf("DeleteExpired changed the live session: %+v", row)
+	}
+	if err := store.Delete(context.Background(), "key"); err != nil {
+		t.Fatal(err)
+	}
+	if row := fake.rows["key"]; row == nil || row.value != "" {
+		t.Errorf("Deleted session is not a tombstone: %+v", row)
+	}
+
+	// The tombstone is kept for TombstoneTTL.
+	if err := store.DeleteExpired(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if fake.rows["key"] == nil {
+		t.Errorf("Fresh tombstone is removed")
+	}
+	fake.rows["key"].expires = time.Now().Add(-tgbot.DefaultTombstoneTTL - time.Minute).UnixMilli()
+	if err := store.DeleteExpired(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if row := fake.rows["key"]; row != nil {
+		t.Errorf("Old tombstone is not removed: %+v", row)
+	}
+}
+
+func TestSQLSessionStoreInsertRace(t *testing.T) {
+	fake, store := newFakeSessionDB(t)
+	// Another writer inserts the row between the update and the insert.
+	fake.beforeInsert = func(key string) {
+		fake.mu.Lock()
+		defer fake.mu.Unlock()
+		fake.rows[key] = &fakeSessionRow{value: `"other"`, version: 1}
+	}
+
+	if err := store.Set(context.Background(), "set", []byte(`"mine"`), 0); err != nil {
+		t.Fatalf("Set failed when the row was inserted concurrently: %s", err)
+	}
+	entry, err := store.Get(context.Background(), "set")
+	if err != nil || entry == nil || string(entry.Value) != `"mine"` || entry.Version != 2 {
+		t.Errorf("Got session %+v, %v, want \"mine\" overwriting the concurrent insert", entry, err)
+	}
+
+	err = store.CompareAndSwap(context.Background(), "swapped", 0, []byte(`"mine"`), 0)
+	if !errors.Is(err, tgbot.ErrSessionConflict) {
+		t.Errorf("CompareAndSwap of a concurrently inserted session = %v, want conflict", err)
+	}
+}
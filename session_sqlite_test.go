@@ -0,0 +1,125 @@
+package tgbot_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/lanseg/tgbot"
+)
+
+// newSQLiteSessionStore creates the session table in a new SQLite database, the test is skipped
+// if the driver is built without cgo.
+func newSQLiteSessionStore(t *testing.T) (*sql.DB, *tgbot.SQLSessionStore) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "sessions.db")
+	db, err := sql.Open("sqlite3", "file:"+path+"?_busy_timeout=10000&_journal_mode=WAL")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if err := db.Ping(); err != nil {
+		if strings.Contains(err.Error(), "cgo") {
+			t.Skipf("SQLite is not available: %s", err)
+		}
+		t.Fatal(err)
+	}
+	store := tgbot.NewSQLSessionStore(db, "sessions")
+	if err := store.CreateTable(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	return db, store
+}
+
+func countSessionRows(t *testing.T, db *sql.DB) int {
+	t.Helper()
+	count := 0
+	if err := db.QueryRow("SELECT COUNT(*) FROM sessions").Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	return count
+}
+
+func TestSQLiteSessionStore(t *testing.T) {
+	db, store := newSQLiteSessionStore(t)
+	testSessionStore(t, store)
+
+	ctx := context.Background()
+	if err := store.Delete(ctx, "key"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteExpired(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if count := countSessionRows(t, db); count != 1 {
+		t.Errorf("Got %d rows, want the tombstone", count)
+	}
+	store.TombstoneTTL = time.Millisecond
+	time.Sleep(10 * time.Millisecond)
+	if err := store.DeleteExpired(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if count := countSessionRows(t, db); count != 0 {
+		t.Errorf("Got %d rows after the tombstone ttl", count)
+	}
+}
+
+func TestSQLiteSessionStoreConcurrentWrites(t *testing.T) {
+	_, store := newSQLiteSessionStore(t)
+	ctx := context.Background()
+	const writers = 8
+
+	// All writers find no row, all but one insert fails and is retried as an update.
+	var wg sync.WaitGroup
+	start := make(chan struct{})
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			if err := store.Set(ctx, "set", []byte(`"value"`), 0); err != nil {
+				t.Errorf("Concurrent Set failed: %s", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	if version := getVersion(t, store, "set"); version != writers {
+		t.Errorf("Session has version %d after %d writes", version, writers)
+	}
+
+	// Only one of the writers creates the session.
+	var mu sync.Mutex
+	created, conflicts := 0, 0
+	start = make(chan struct{})
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			err := store.CompareAndSwap(ctx, "swapped", 0, []byte(`"value"`), 0)
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				created++
+			case errors.Is(err, tgbot.ErrSessionConflict):
+				conflicts++
+			default:
+				t.Errorf("Concurrent CompareAndSwap failed: %s", err)
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	if created != 1 || conflicts != writers-1 {
+		t.Errorf("Created the session %d times with %d conflicts", created, conflicts)
+	}
+}
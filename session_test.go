@@ -0,0 +1,143 @@
+package tgbot_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lanseg/tgbot"
+)
+
+// sessionTTL is short enough to wait for the sessions to expire.
+const sessionTTL = 20 * time.Millisecond
+
+func getVersion(t *testing.T, store tgbot.SessionStore, key string) int64 {
+	t.Helper()
+	entry, err := store.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get(%q) failed: %s", key, err)
+	}
+	if entry == nil {
+		return 0
+	}
+	return entry.Version
+}
+
+func compareAndSwap(t *testing.T, store tgbot.SessionStore, version int64, value string, wantErr error) {
+	t.Helper()
+	err := store.CompareAndSwap(context.Background(), "key", version, []byte(value), 0)
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("CompareAndSwap(%d, %s) = %v, want %v", version, value, err, wantErr)
+	}
+}
+
+// testSessionStore checks the SessionStore contract, including that a version read before a
+// delete or an expiry never matches the session written after it.
+func testSessionStore(t *testing.T, store tgbot.SessionStore) {
+	ctx := context.Background()
+	if entry, err := store.Get(ctx, "key"); entry != nil || err != nil {
+		t.Fatalf("Get of a missing session = %+v, %v", entry, err)
+	}
+
+	compareAndSwap(t, store, 0, `"created"`, nil)
+	created := getVersion(t, store, "key")
+	compareAndSwap(t, store, 0, `"again"`, tgbot.ErrSessionConflict)
+	if err := store.Set(ctx, "key", []byte(`"set"`), 0); err != nil {
+		t.Fatal(err)
+	}
+	set := getVersion(t, store, "key")
+	if created <= 0 || set <= created {
+		t.Errorf("Versions of the writes are %d, %d, want them to grow", created, set)
+	}
+	compareAndSwap(t, store, created, `"stale"`, tgbot.ErrSessionConflict)
+	compareAndSwap(t, store, set, `"swapped"`, nil)
+	swapped := getVersion(t, store, "key")
+	entry, err := store.Get(ctx, "key")
+	if err != nil || string(entry.Value) != `"swapped"` {
+		t.Fatalf("Get = %+v, %v", entry, err)
+	}
+
+	if err := store.Delete(ctx, "key"); err != nil {
+		t.Fatal(err)
+	}
+	if version := getVersion(t, store, "key"); version != 0 {
+		t.Fatalf("Deleted session has version %d", version)
+	}
+	compareAndSwap(t, store, swapped, `"after delete"`, tgbot.ErrSessionConflict)
+	compareAndSwap(t, store, 0, `"recreated"`, nil)
+	recreated := getVersion(t, store, "key")
+	if recreated <= swapped {
+		t.Errorf("Recreated session has version %d, the deleted one had %d", recreated, swapped)
+	}
+
+	if err := store.Set(ctx, "key", []byte(`"expiring"`), sessionTTL); err != nil {
+		t.Fatal(err)
+	}
+	expiring := getVersion(t, store, "key")
+	time.Sleep(3 * sessionTTL)
+	if version := getVersion(t, store, "key"); version != 0 {
+		t.Fatalf("Expired session has version %d", version)
+	}
+	compareAndSwap(t, store, expiring, `"after expiry"`, tgbot.ErrSessionConflict)
+	if err := store.Set(ctx, "key", []byte(`"renewed"`), 0); err != nil {
+		t.Fatal(err)
+	}
+	if renewed := getVersion(t, store, "key"); renewed <= expiring {
+		t.Errorf("Renewed session has version %d, the expired one had %d", renewed, expiring)
+	}
+}
+
+func TestMemorySessionStore(t *testing.T) {
+	testSessionStore(t, tgbot.NewMemorySessionStore())
+}
+
+func TestUpdateSessionConcurrently(t *testing.T) {
+	store := tgbot.NewMemorySessionStore()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := tgbot.UpdateSession(context.Background(), store, "counter", 0, func(value *int) error {
+				*value++
+				return nil
+			})
+			if err != nil {
+				t.Errorf("UpdateSession failed: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+	value, ok, err := tgbot.GetSession[int](context.Background(), store, "counter")
+	if value != 5 || !ok || err != nil {
+		t.Errorf("GetSession = %d, %v, %v, want 5", value, ok, err)
+	}
+}
+
+func TestSessionHandler(t *testing.T) {
+	store := tgbot.NewMemorySessionStore()
+	handler := tgbot.SessionHandler(store, 0, func(ctx context.Context, update *tgbot.Update) {
+		err := tgbot.ModifySession(ctx, tgbot.ChatUserSession, "visits", func(visits *int) error {
+			*visits++
+			return nil
+		})
+		if err != nil {
+			t.Errorf("ModifySession failed: %s", err)
+		}
+	})
+	handler(context.Background(), textUpdate("hello"))
+	handler(context.Background(), textUpdate("again"))
+
+	key, ok := tgbot.SessionKey(tgbot.ChatUserSession, textUpdate(""), "visits")
+	if key != "chat:10:user:20:visits" || !ok {
+		t.Fatalf("SessionKey = %q, %v", key, ok)
+	}
+	if visits, _, err := tgbot.GetSession[int](context.Background(), store, key); visits != 2 || err != nil {
+		t.Errorf("Got %d visits, %v, want 2", visits, err)
+	}
+	if _, err := tgbot.LoadSession[int](context.Background(), tgbot.UserSession, "visits"); err == nil {
+		t.Errorf("LoadSession succeeded without SessionHandler")
+	}
+}
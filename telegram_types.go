@@ -1,6 +1,18 @@
 // Telegram bot API classes and enpoint
 package tgbot
 
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Bot API version and release date of the docs the types are generated from
+const (
+	APIVersion = "7.0"
+	APIDate    = "December 29, 2023"
+)
+
 // Telegram Bot API                      Twitter   Home  FAQ  Apps  API  Protocol  Schema
 // Telegram Bots Telegram Bot API  Telegram Bot API    The Bot API is an HTTP-based interface
 // created for developers keen on building bots for Telegram. To learn how to create and set up
@@ -123,6 +135,16 @@ type WebhookInfo struct {
 	AllowedUpdates []string `json:"allowed_updates,omitempty"`
 }
 
+// LastErrorDateAsTime returns LastErrorDate as time.Time
+func (w *WebhookInfo) LastErrorDateAsTime() time.Time {
+	return unixToTime(w.LastErrorDate)
+}
+
+// LastSynchronizationErrorDateAsTime returns LastSynchronizationErrorDate as time.Time
+func (w *WebhookInfo) LastSynchronizationErrorDateAsTime() time.Time {
+	return unixToTime(w.LastSynchronizationErrorDate)
+}
+
 // All types used in the Bot API responses are represented as JSON-objects.  It is safe to use
 // 32-bit signed integers for storing all Integer fields unless otherwise noted.   Optional
 // fields may be not returned when irrelevant.    This object represents a Telegram user or
@@ -303,6 +325,27 @@ type Chat struct {
 	Location *ChatLocation `json:"location,omitempty"`
 }
 
+// EmojiStatusExpirationDateAsTime returns EmojiStatusExpirationDate as time.Time
+func (c *Chat) EmojiStatusExpirationDateAsTime() time.Time {
+	return unixToTime(c.EmojiStatusExpirationDate)
+}
+
+// SlowModeDelayAsDuration returns SlowModeDelay as time.Duration
+func (c *Chat) SlowModeDelayAsDuration() time.Duration {
+	return secondsToDuration(c.SlowModeDelay)
+}
+
+// MessageAutoDeleteTimeAsDuration returns MessageAutoDeleteTime as time.Duration
+func (c *Chat) MessageAutoDeleteTimeAsDuration() time.Duration {
+	return secondsToDuration(c.MessageAutoDeleteTime)
+}
+
+// This object describes a message that can be inaccessible to the bot. It can be one of
+// Message  InaccessibleMessage
+type MaybeInaccessibleMessage interface {
+	isMaybeInaccessibleMessage()
+}
+
 // This object represents a message.
 type Message struct {
 	// Unique message identifier inside this chat
@@ -487,7 +530,7 @@ type Message struct {
 
 	// Optional. Specified message was pinned. Note that the Message object in this field will
 	// not contain further reply_to_message fields even if it itself is a reply.
-	PinnedMessage *MaybeInaccessibleMessage `json:"pinned_message,omitempty"`
+	PinnedMessage MaybeInaccessibleMessage `json:"pinned_message,omitempty"`
 
 	// Optional. Message is an invoice for a payment, information about the invoice. More about
 	// payments »
@@ -569,6 +612,33 @@ type Message struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 }
 
+// DateAsTime returns Date as time.Time
+func (m *Message) DateAsTime() time.Time {
+	return unixToTime(m.Date)
+}
+
+// EditDateAsTime returns EditDate as time.Time
+func (m *Message) EditDateAsTime() time.Time {
+	return unixToTime(m.EditDate)
+}
+
+// UnmarshalJSON decodes PinnedMessage into the members of the interface
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type plain Message
+	value := struct {
+		*plain
+		PinnedMessage json.RawMessage `json:"pinned_message"`
+	}{plain: (*plain)(m)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if m.PinnedMessage, err = unmarshalMember(value.PinnedMessage, "MaybeInaccessibleMessage", chooseMaybeInaccessibleMessage); err != nil {
+		return err
+	}
+	return nil
+}
+
 // This object represents a unique message identifier.
 type MessageId struct {
 	// Unique message identifier
@@ -587,11 +657,6 @@ type InaccessibleMessage struct {
 	Date int64 `json:"date,omitempty"`
 }
 
-// This object describes a message that can be inaccessible to the bot. It can be one of
-// Message  InaccessibleMessage
-type MaybeInaccessibleMessage struct {
-}
-
 // This object represents one special entity in a text message. For example, hashtags,
 // usernames, URLs, etc.
 type MessageEntity struct {
@@ -763,6 +828,11 @@ type MessageOriginUser struct {
 	SenderUser *User `json:"sender_user,omitempty"`
 }
 
+// DateAsTime returns Date as time.Time
+func (m *MessageOriginUser) DateAsTime() time.Time {
+	return unixToTime(m.Date)
+}
+
 // The message was originally sent by an unknown user.
 type MessageOriginHiddenUser struct {
 	// Type of the message origin, always “hidden_user”
@@ -775,6 +845,11 @@ type MessageOriginHiddenUser struct {
 	SenderUserName string `json:"sender_user_name,omitempty"`
 }
 
+// DateAsTime returns Date as time.Time
+func (m *MessageOriginHiddenUser) DateAsTime() time.Time {
+	return unixToTime(m.Date)
+}
+
 // The message was originally sent on behalf of a chat to a group chat.
 type MessageOriginChat struct {
 	// Type of the message origin, always “chat”
@@ -791,6 +866,11 @@ type MessageOriginChat struct {
 	AuthorSignature string `json:"author_signature,omitempty"`
 }
 
+// DateAsTime returns Date as time.Time
+func (m *MessageOriginChat) DateAsTime() time.Time {
+	return unixToTime(m.Date)
+}
+
 // The message was originally sent to a channel chat.
 type MessageOriginChannel struct {
 	// Type of the message origin, always “channel”
@@ -809,6 +889,11 @@ type MessageOriginChannel struct {
 	AuthorSignature string `json:"author_signature,omitempty"`
 }
 
+// DateAsTime returns Date as time.Time
+func (m *MessageOriginChannel) DateAsTime() time.Time {
+	return unixToTime(m.Date)
+}
+
 // This object represents one size of a photo or a file / sticker thumbnail.
 type PhotoSize struct {
 	// Identifier for this file, which can be used to download or reuse the file
@@ -862,6 +947,11 @@ type Animation struct {
 	FileSize int64 `json:"file_size,omitempty"`
 }
 
+// DurationAsDuration returns Duration as time.Duration
+func (a *Animation) DurationAsDuration() time.Duration {
+	return secondsToDuration(a.Duration)
+}
+
 // This object represents an audio file to be treated as music by the Telegram clients.
 type Audio struct {
 	// Identifier for this file, which can be used to download or reuse the file
@@ -896,6 +986,11 @@ type Audio struct {
 	Thumbnail *PhotoSize `json:"thumbnail,omitempty"`
 }
 
+// DurationAsDuration returns Duration as time.Duration
+func (a *Audio) DurationAsDuration() time.Duration {
+	return secondsToDuration(a.Duration)
+}
+
 // This object represents a general file (as opposed to photos , voice messages and audio files
 // ).
 type Document struct {
@@ -961,6 +1056,11 @@ type Video struct {
 	FileSize int64 `json:"file_size,omitempty"`
 }
 
+// DurationAsDuration returns Duration as time.Duration
+func (v *Video) DurationAsDuration() time.Duration {
+	return secondsToDuration(v.Duration)
+}
+
 // This object represents a video message (available in Telegram apps as of v.4.0 ).
 type VideoNote struct {
 	// Identifier for this file, which can be used to download or reuse the file
@@ -983,6 +1083,11 @@ type VideoNote struct {
 	FileSize int64 `json:"file_size,omitempty"`
 }
 
+// DurationAsDuration returns Duration as time.Duration
+func (v *VideoNote) DurationAsDuration() time.Duration {
+	return secondsToDuration(v.Duration)
+}
+
 // This object represents a voice note.
 type Voice struct {
 	// Identifier for this file, which can be used to download or reuse the file
@@ -1005,6 +1110,11 @@ type Voice struct {
 	FileSize int64 `json:"file_size,omitempty"`
 }
 
+// DurationAsDuration returns Duration as time.Duration
+func (v *Voice) DurationAsDuration() time.Duration {
+	return secondsToDuration(v.Duration)
+}
+
 // This object represents a phone contact.
 type Contact struct {
 	// Contact's phone number
@@ -1106,6 +1216,16 @@ type Poll struct {
 	CloseDate int64 `json:"close_date,omitempty"`
 }
 
+// OpenPeriodAsDuration returns OpenPeriod as time.Duration
+func (p *Poll) OpenPeriodAsDuration() time.Duration {
+	return secondsToDuration(p.OpenPeriod)
+}
+
+// CloseDateAsTime returns CloseDate as time.Time
+func (p *Poll) CloseDateAsTime() time.Time {
+	return unixToTime(p.CloseDate)
+}
+
 // This object represents a point on the map.
 type Location struct {
 	// Longitude as defined by sender
@@ -1130,6 +1250,11 @@ type Location struct {
 	ProximityAlertRadius int64 `json:"proximity_alert_radius,omitempty"`
 }
 
+// LivePeriodAsDuration returns LivePeriod as time.Duration
+func (l *Location) LivePeriodAsDuration() time.Duration {
+	return secondsToDuration(l.LivePeriod)
+}
+
 // This object represents a venue.
 type Venue struct {
 	// Venue location. Can't be a live location
@@ -1184,6 +1309,11 @@ type MessageAutoDeleteTimerChanged struct {
 	MessageAutoDeleteTime int64 `json:"message_auto_delete_time,omitempty"`
 }
 
+// MessageAutoDeleteTimeAsDuration returns MessageAutoDeleteTime as time.Duration
+func (m *MessageAutoDeleteTimerChanged) MessageAutoDeleteTimeAsDuration() time.Duration {
+	return secondsToDuration(m.MessageAutoDeleteTime)
+}
+
 // This object represents a service message about a new forum topic created in the chat.
 type ForumTopicCreated struct {
 	// Name of the topic
@@ -1279,6 +1409,11 @@ type VideoChatScheduled struct {
 	StartDate int64 `json:"start_date,omitempty"`
 }
 
+// StartDateAsTime returns StartDate as time.Time
+func (v *VideoChatScheduled) StartDateAsTime() time.Time {
+	return unixToTime(v.StartDate)
+}
+
 // This object represents a service message about a video chat started in the chat. Currently
 // holds no information.
 type VideoChatStarted struct {
@@ -1290,6 +1425,11 @@ type VideoChatEnded struct {
 	Duration int64 `json:"duration,omitempty"`
 }
 
+// DurationAsDuration returns Duration as time.Duration
+func (v *VideoChatEnded) DurationAsDuration() time.Duration {
+	return secondsToDuration(v.Duration)
+}
+
 // This object represents a service message about new members invited to a video chat.
 type VideoChatParticipantsInvited struct {
 	// New members that were invited to the video chat
@@ -1333,6 +1473,11 @@ type Giveaway struct {
 	PremiumSubscriptionMonthCount int64 `json:"premium_subscription_month_count,omitempty"`
 }
 
+// WinnersSelectionDateAsTime returns WinnersSelectionDate as time.Time
+func (g *Giveaway) WinnersSelectionDateAsTime() time.Time {
+	return unixToTime(g.WinnersSelectionDate)
+}
+
 // This object represents a message about the completion of a giveaway with public winners.
 type GiveawayWinners struct {
 	// The chat that created the giveaway
@@ -1372,6 +1517,11 @@ type GiveawayWinners struct {
 	PrizeDescription string `json:"prize_description,omitempty"`
 }
 
+// WinnersSelectionDateAsTime returns WinnersSelectionDate as time.Time
+func (g *GiveawayWinners) WinnersSelectionDateAsTime() time.Time {
+	return unixToTime(g.WinnersSelectionDate)
+}
+
 // This object represents a service message about the completion of a giveaway without public
 // winners.
 type GiveawayCompleted struct {
@@ -1723,7 +1873,7 @@ type CallbackQuery struct {
 	From *User `json:"from,omitempty"`
 
 	// Optional. Message sent by the bot with the callback button that originated the query
-	Message *MaybeInaccessibleMessage `json:"message,omitempty"`
+	Message MaybeInaccessibleMessage `json:"message,omitempty"`
 
 	// Optional. Identifier of the message sent via the bot in inline mode, that originated the
 	// query.
@@ -1742,6 +1892,23 @@ type CallbackQuery struct {
 	GameShortName string `json:"game_short_name,omitempty"`
 }
 
+// UnmarshalJSON decodes Message into the members of the interface
+func (c *CallbackQuery) UnmarshalJSON(data []byte) error {
+	type plain CallbackQuery
+	value := struct {
+		*plain
+		Message json.RawMessage `json:"message"`
+	}{plain: (*plain)(c)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if c.Message, err = unmarshalMember(value.Message, "MaybeInaccessibleMessage", chooseMaybeInaccessibleMessage); err != nil {
+		return err
+	}
+	return nil
+}
+
 // NOTE: After the user presses a callback button, Telegram clients will display a progress bar
 // until you call answerCallbackQuery . It is, therefore, necessary to react by calling
 // answerCallbackQuery even if no notification to the user is needed (e.g., without specifying
@@ -1823,6 +1990,11 @@ type ChatInviteLink struct {
 	PendingJoinRequestCount int64 `json:"pending_join_request_count,omitempty"`
 }
 
+// ExpireDateAsTime returns ExpireDate as time.Time
+func (c *ChatInviteLink) ExpireDateAsTime() time.Time {
+	return unixToTime(c.ExpireDate)
+}
+
 // Represents the rights of an administrator in a chat.
 type ChatAdministratorRights struct {
 	// True, if the user's presence in the chat is hidden
@@ -1905,6 +2077,11 @@ type ChatMemberUpdated struct {
 	ViaChatFolderInviteLink bool `json:"via_chat_folder_invite_link,omitempty"`
 }
 
+// DateAsTime returns Date as time.Time
+func (c *ChatMemberUpdated) DateAsTime() time.Time {
+	return unixToTime(c.Date)
+}
+
 // This object contains information about one member of a chat. Currently, the following 6
 // types of chat members are supported:   ChatMemberOwner  ChatMemberAdministrator
 // ChatMemberMember  ChatMemberRestricted  ChatMemberLeft  ChatMemberBanned
@@ -2063,6 +2240,11 @@ type ChatMemberRestricted struct {
 	UntilDate int64 `json:"until_date,omitempty"`
 }
 
+// UntilDateAsTime returns UntilDate as time.Time
+func (c *ChatMemberRestricted) UntilDateAsTime() time.Time {
+	return unixToTime(c.UntilDate)
+}
+
 // Represents a chat member that isn't currently a member of the chat, but may join it
 // themselves.
 type ChatMemberLeft struct {
@@ -2087,6 +2269,11 @@ type ChatMemberBanned struct {
 	UntilDate int64 `json:"until_date,omitempty"`
 }
 
+// UntilDateAsTime returns UntilDate as time.Time
+func (c *ChatMemberBanned) UntilDateAsTime() time.Time {
+	return unixToTime(c.UntilDate)
+}
+
 // Represents a join request sent to a chat.
 type ChatJoinRequest struct {
 	// Chat to which the request was sent
@@ -2113,6 +2300,11 @@ type ChatJoinRequest struct {
 	InviteLink *ChatInviteLink `json:"invite_link,omitempty"`
 }
 
+// DateAsTime returns Date as time.Time
+func (c *ChatJoinRequest) DateAsTime() time.Time {
+	return unixToTime(c.Date)
+}
+
 // Describes actions that a non-administrator user is allowed to take in a chat.
 type ChatPermissions struct {
 	// Optional. True, if the user is allowed to send text messages, contacts, giveaways,
@@ -2225,6 +2417,11 @@ type MessageReactionUpdated struct {
 	NewReaction []*ReactionType `json:"new_reaction,omitempty"`
 }
 
+// DateAsTime returns Date as time.Time
+func (m *MessageReactionUpdated) DateAsTime() time.Time {
+	return unixToTime(m.Date)
+}
+
 // This object represents reaction changes on a message with anonymous reactions.
 type MessageReactionCountUpdated struct {
 	// The chat containing the message
@@ -2240,6 +2437,11 @@ type MessageReactionCountUpdated struct {
 	Reactions []*ReactionCount `json:"reactions,omitempty"`
 }
 
+// DateAsTime returns Date as time.Time
+func (m *MessageReactionCountUpdated) DateAsTime() time.Time {
+	return unixToTime(m.Date)
+}
+
 // This object represents a forum topic.
 type ForumTopic struct {
 	// Unique identifier of the forum topic
@@ -2383,11 +2585,6 @@ type MenuButtonDefault struct {
 	Type string `json:"type,omitempty"`
 }
 
-// This object describes the source of a chat boost. It can be one of   ChatBoostSourcePremium
-// ChatBoostSourceGiftCode  ChatBoostSourceGiveaway
-type ChatBoostSource struct {
-}
-
 // The boost was obtained by subscribing to Telegram Premium or by gifting a Telegram Premium
 // subscription to another user.
 type ChatBoostSourcePremium struct {
@@ -2442,6 +2639,16 @@ type ChatBoost struct {
 	Source *ChatBoostSource `json:"source,omitempty"`
 }
 
+// AddDateAsTime returns AddDate as time.Time
+func (c *ChatBoost) AddDateAsTime() time.Time {
+	return unixToTime(c.AddDate)
+}
+
+// ExpirationDateAsTime returns ExpirationDate as time.Time
+func (c *ChatBoost) ExpirationDateAsTime() time.Time {
+	return unixToTime(c.ExpirationDate)
+}
+
 // This object represents a boost added to a chat or changed.
 type ChatBoostUpdated struct {
 	// Chat which was boosted
@@ -2466,6 +2673,11 @@ type ChatBoostRemoved struct {
 	Source *ChatBoostSource `json:"source,omitempty"`
 }
 
+// RemoveDateAsTime returns RemoveDate as time.Time
+func (c *ChatBoostRemoved) RemoveDateAsTime() time.Time {
+	return unixToTime(c.RemoveDate)
+}
+
 // This object represents a list of boosts added to a chat by a user.
 type UserChatBoosts struct {
 	// The list of boosts added to the chat by the user
@@ -2562,6 +2774,11 @@ type InputMediaVideo struct {
 	HasSpoiler bool `json:"has_spoiler,omitempty"`
 }
 
+// DurationAsDuration returns Duration as time.Duration
+func (i *InputMediaVideo) DurationAsDuration() time.Duration {
+	return secondsToDuration(i.Duration)
+}
+
 // Represents an animation file (GIF or H.264/MPEG-4 AVC video without sound) to be sent.
 type InputMediaAnimation struct {
 	// Type of the result, must be animation
@@ -2605,6 +2822,11 @@ type InputMediaAnimation struct {
 	HasSpoiler bool `json:"has_spoiler,omitempty"`
 }
 
+// DurationAsDuration returns Duration as time.Duration
+func (i *InputMediaAnimation) DurationAsDuration() time.Duration {
+	return secondsToDuration(i.Duration)
+}
+
 // Represents an audio file to be treated as music to be sent.
 type InputMediaAudio struct {
 	// Type of the result, must be audio
@@ -2645,6 +2867,11 @@ type InputMediaAudio struct {
 	Title string `json:"title,omitempty"`
 }
 
+// DurationAsDuration returns Duration as time.Duration
+func (i *InputMediaAudio) DurationAsDuration() time.Duration {
+	return secondsToDuration(i.Duration)
+}
+
 // Represents a general file to be sent.
 type InputMediaDocument struct {
 	// Type of the result, must be document
@@ -2864,7 +3091,10 @@ type InlineQueryResultsButton struct {
 // InlineQueryResultPhoto  InlineQueryResultVenue  InlineQueryResultVideo
 // InlineQueryResultVoice   Note: All URLs passed in inline query results will be available to
 // end users and therefore must be assumed to be public .
-type InlineQueryResult struct {
+type InlineQueryResult interface {
+	isInlineQueryResult()
+	// ResultID returns ID of the member
+	ResultID() string
 }
 
 // Represents a link to an article or web page.
@@ -2879,7 +3109,7 @@ type InlineQueryResultArticle struct {
 	Title string `json:"title,omitempty"`
 
 	// Content of the message to be sent
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
 
 	// Optional. Inline keyboard attached to the message
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
@@ -2903,6 +3133,23 @@ type InlineQueryResultArticle struct {
 	ThumbnailHeight int64 `json:"thumbnail_height,omitempty"`
 }
 
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultArticle) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultArticle
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
+}
+
 // Represents a link to a photo. By default, this photo will be sent by the user with optional
 // caption. Alternatively, you can use input_message_content to send a message with the
 // specified content instead of the photo.
@@ -2946,7 +3193,24 @@ type InlineQueryResultPhoto struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the photo
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultPhoto) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultPhoto
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Represents a link to an animated GIF file. By default, this animated GIF file will be sent
@@ -2996,7 +3260,29 @@ type InlineQueryResultGif struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the GIF animation
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+// GifDurationAsDuration returns GifDuration as time.Duration
+func (i *InlineQueryResultGif) GifDurationAsDuration() time.Duration {
+	return secondsToDuration(i.GifDuration)
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultGif) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultGif
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Represents a link to a video animation (H.264/MPEG-4 AVC video without sound). By default,
@@ -3047,7 +3333,29 @@ type InlineQueryResultMpeg4Gif struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the video animation
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+// Mpeg4DurationAsDuration returns Mpeg4Duration as time.Duration
+func (i *InlineQueryResultMpeg4Gif) Mpeg4DurationAsDuration() time.Duration {
+	return secondsToDuration(i.Mpeg4Duration)
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultMpeg4Gif) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultMpeg4Gif
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Represents a link to a page containing an embedded video player or a video file. By default,
@@ -3103,7 +3411,29 @@ type InlineQueryResultVideo struct {
 	// Optional. Content of the message to be sent instead of the video. This field is required
 	// if InlineQueryResultVideo is used to send an HTML-page as a result (e.g., a YouTube
 	// video).
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+// VideoDurationAsDuration returns VideoDuration as time.Duration
+func (i *InlineQueryResultVideo) VideoDurationAsDuration() time.Duration {
+	return secondsToDuration(i.VideoDuration)
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultVideo) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultVideo
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Represents a link to an MP3 audio file. By default, this audio file will be sent by the
@@ -3143,7 +3473,29 @@ type InlineQueryResultAudio struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the audio
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+// AudioDurationAsDuration returns AudioDuration as time.Duration
+func (i *InlineQueryResultAudio) AudioDurationAsDuration() time.Duration {
+	return secondsToDuration(i.AudioDuration)
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultAudio) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultAudio
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Represents a link to a voice recording in an .OGG container encoded with OPUS. By default,
@@ -3181,7 +3533,29 @@ type InlineQueryResultVoice struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the voice recording
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+// VoiceDurationAsDuration returns VoiceDuration as time.Duration
+func (i *InlineQueryResultVoice) VoiceDurationAsDuration() time.Duration {
+	return secondsToDuration(i.VoiceDuration)
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultVoice) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultVoice
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Represents a link to a file. By default, this file will be sent by the user with an optional
@@ -3222,7 +3596,7 @@ type InlineQueryResultDocument struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the file
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
 
 	// Optional. URL of the thumbnail (JPEG only) for the file
 	ThumbnailURL string `json:"thumbnail_url,omitempty"`
@@ -3234,6 +3608,23 @@ type InlineQueryResultDocument struct {
 	ThumbnailHeight int64 `json:"thumbnail_height,omitempty"`
 }
 
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultDocument) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultDocument
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
+}
+
 // Represents a location on a map. By default, the location will be sent by the user.
 // Alternatively, you can use input_message_content to send a message with the specified
 // content instead of the location.
@@ -3272,7 +3663,7 @@ type InlineQueryResultLocation struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the location
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
 
 	// Optional. Url of the thumbnail for the result
 	ThumbnailURL string `json:"thumbnail_url,omitempty"`
@@ -3284,6 +3675,28 @@ type InlineQueryResultLocation struct {
 	ThumbnailHeight int64 `json:"thumbnail_height,omitempty"`
 }
 
+// LivePeriodAsDuration returns LivePeriod as time.Duration
+func (i *InlineQueryResultLocation) LivePeriodAsDuration() time.Duration {
+	return secondsToDuration(i.LivePeriod)
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultLocation) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultLocation
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
+}
+
 // Represents a venue. By default, the venue will be sent by the user. Alternatively, you can
 // use input_message_content to send a message with the specified content instead of the venue.
 type InlineQueryResultVenue struct {
@@ -3322,7 +3735,7 @@ type InlineQueryResultVenue struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the venue
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
 
 	// Optional. Url of the thumbnail for the result
 	ThumbnailURL string `json:"thumbnail_url,omitempty"`
@@ -3334,6 +3747,23 @@ type InlineQueryResultVenue struct {
 	ThumbnailHeight int64 `json:"thumbnail_height,omitempty"`
 }
 
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultVenue) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultVenue
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
+}
+
 // Represents a contact with a phone number. By default, this contact will be sent by the user.
 // Alternatively, you can use input_message_content to send a message with the specified
 // content instead of the contact.
@@ -3360,7 +3790,7 @@ type InlineQueryResultContact struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the contact
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
 
 	// Optional. Url of the thumbnail for the result
 	ThumbnailURL string `json:"thumbnail_url,omitempty"`
@@ -3372,6 +3802,23 @@ type InlineQueryResultContact struct {
 	ThumbnailHeight int64 `json:"thumbnail_height,omitempty"`
 }
 
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultContact) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultContact
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
+}
+
 // Represents a Game .
 type InlineQueryResultGame struct {
 	// Type of the result, must be game
@@ -3421,7 +3868,24 @@ type InlineQueryResultCachedPhoto struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the photo
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultCachedPhoto) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultCachedPhoto
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Represents a link to an animated GIF file stored on the Telegram servers. By default, this
@@ -3455,7 +3919,24 @@ type InlineQueryResultCachedGif struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the GIF animation
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultCachedGif) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultCachedGif
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Represents a link to a video animation (H.264/MPEG-4 AVC video without sound) stored on the
@@ -3490,7 +3971,24 @@ type InlineQueryResultCachedMpeg4Gif struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the video animation
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultCachedMpeg4Gif) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultCachedMpeg4Gif
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Represents a link to a sticker stored on the Telegram servers. By default, this sticker will
@@ -3510,7 +4008,24 @@ type InlineQueryResultCachedSticker struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the sticker
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultCachedSticker) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultCachedSticker
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Represents a link to a file stored on the Telegram servers. By default, this file will be
@@ -3547,7 +4062,24 @@ type InlineQueryResultCachedDocument struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the file
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultCachedDocument) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultCachedDocument
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Represents a link to a video file stored on the Telegram servers. By default, this video
@@ -3584,7 +4116,24 @@ type InlineQueryResultCachedVideo struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the video
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultCachedVideo) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultCachedVideo
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Represents a link to a voice message stored on the Telegram servers. By default, this voice
@@ -3618,7 +4167,24 @@ type InlineQueryResultCachedVoice struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the voice message
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultCachedVoice) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultCachedVoice
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Represents a link to an MP3 audio file stored on the Telegram servers. By default, this
@@ -3649,14 +4215,32 @@ type InlineQueryResultCachedAudio struct {
 	ReplyMarkup *InlineKeyboardMarkup `json:"reply_markup,omitempty"`
 
 	// Optional. Content of the message to be sent instead of the audio
-	InputMessageContent *InputMessageContent `json:"input_message_content,omitempty"`
+	InputMessageContent InputMessageContent `json:"input_message_content,omitempty"`
+}
+
+// UnmarshalJSON decodes InputMessageContent into the members of the interface
+func (i *InlineQueryResultCachedAudio) UnmarshalJSON(data []byte) error {
+	type plain InlineQueryResultCachedAudio
+	value := struct {
+		*plain
+		InputMessageContent json.RawMessage `json:"input_message_content"`
+	}{plain: (*plain)(i)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if i.InputMessageContent, err = unmarshalMember(value.InputMessageContent, "InputMessageContent", chooseInputMessageContent); err != nil {
+		return err
+	}
+	return nil
 }
 
 // This object represents the content of a message to be sent as a result of an inline query.
 // Telegram clients currently support the following 5 types:   InputTextMessageContent
 // InputLocationMessageContent  InputVenueMessageContent  InputContactMessageContent
 // InputInvoiceMessageContent
-type InputMessageContent struct {
+type InputMessageContent interface {
+	isInputMessageContent()
 }
 
 // Represents the content of a text message to be sent as the result of an inline query.
@@ -3700,6 +4284,11 @@ type InputLocationMessageContent struct {
 	ProximityAlertRadius int64 `json:"proximity_alert_radius,omitempty"`
 }
 
+// LivePeriodAsDuration returns LivePeriod as time.Duration
+func (i *InputLocationMessageContent) LivePeriodAsDuration() time.Duration {
+	return secondsToDuration(i.LivePeriod)
+}
+
 // Represents the content of a venue message to be sent as the result of an inline query.
 type InputVenueMessageContent struct {
 	// Latitude of the venue in degrees
@@ -4026,6 +4615,11 @@ type PassportFile struct {
 	FileDate int64 `json:"file_date,omitempty"`
 }
 
+// FileDateAsTime returns FileDate as time.Time
+func (p *PassportFile) FileDateAsTime() time.Time {
+	return unixToTime(p.FileDate)
+}
+
 // Describes documents or other Telegram Passport elements shared with the bot by the user.
 type EncryptedPassportElement struct {
 	// Element type. One of “personal_details”, “passport”, “driver_license”, “identity_card”,
@@ -4098,7 +4692,8 @@ type EncryptedCredentials struct {
 // PassportElementErrorFrontSide  PassportElementErrorReverseSide  PassportElementErrorSelfie
 // PassportElementErrorFile  PassportElementErrorFiles  PassportElementErrorTranslationFile
 // PassportElementErrorTranslationFiles  PassportElementErrorUnspecified
-type PassportElementError struct {
+type PassportElementError interface {
+	isPassportElementError()
 }
 
 // Represents an issue in one of the data fields that was provided by the user. The error is
@@ -4302,6 +4897,7 @@ type GameHighScore struct {
 }
 
 // Oneof type fields are merged into one
+
 // Merged fields of MessageOriginUser, MessageOriginHiddenUser, MessageOriginChat,
 // MessageOriginChannel
 type MessageOrigin struct {
@@ -4330,6 +4926,11 @@ type MessageOrigin struct {
 	MessageID int64 `json:"message_id,omitempty"`
 }
 
+// DateAsTime returns Date as time.Time
+func (m *MessageOrigin) DateAsTime() time.Time {
+	return unixToTime(m.Date)
+}
+
 // Merged fields of ReactionTypeEmoji, ReactionTypeCustomEmoji
 type ReactionType struct {
 	// Type of the reaction, always “custom_emoji”
@@ -4345,7 +4946,964 @@ type ReactionType struct {
 	CustomEmojiID string `json:"custom_emoji_id,omitempty"`
 }
 
+// Merged fields of ChatBoostSourcePremium, ChatBoostSourceGiftCode, ChatBoostSourceGiveaway
+type ChatBoostSource struct {
+	// Source of the boost, always “giveaway”
+	Source string `json:"source,omitempty"`
+
+	// Optional. User that won the prize in the giveaway if any
+	User *User `json:"user,omitempty"`
+
+	// Identifier of a message in the chat with the giveaway; the message could have been deleted
+	// already. May be 0 if the message isn't sent yet.
+	GiveawayMessageID int64 `json:"giveaway_message_id,omitempty"`
+
+	// Optional. True, if the giveaway was completed, but there was no user to win the prize
+	IsUnclaimed bool `json:"is_unclaimed,omitempty"`
+}
+
+// Interface types are implemented by their members
+
+func (*InlineQueryResultCachedAudio) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultCachedAudio) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "audio"
+func (i *InlineQueryResultCachedAudio) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultCachedAudio
+	value := plain(*i)
+	value.Type = "audio"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultCachedDocument) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultCachedDocument) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "document"
+func (i *InlineQueryResultCachedDocument) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultCachedDocument
+	value := plain(*i)
+	value.Type = "document"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultCachedGif) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultCachedGif) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "gif"
+func (i *InlineQueryResultCachedGif) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultCachedGif
+	value := plain(*i)
+	value.Type = "gif"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultCachedMpeg4Gif) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultCachedMpeg4Gif) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "mpeg4_gif"
+func (i *InlineQueryResultCachedMpeg4Gif) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultCachedMpeg4Gif
+	value := plain(*i)
+	value.Type = "mpeg4_gif"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultCachedPhoto) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultCachedPhoto) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "photo"
+func (i *InlineQueryResultCachedPhoto) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultCachedPhoto
+	value := plain(*i)
+	value.Type = "photo"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultCachedSticker) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultCachedSticker) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "sticker"
+func (i *InlineQueryResultCachedSticker) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultCachedSticker
+	value := plain(*i)
+	value.Type = "sticker"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultCachedVideo) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultCachedVideo) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "video"
+func (i *InlineQueryResultCachedVideo) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultCachedVideo
+	value := plain(*i)
+	value.Type = "video"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultCachedVoice) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultCachedVoice) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "voice"
+func (i *InlineQueryResultCachedVoice) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultCachedVoice
+	value := plain(*i)
+	value.Type = "voice"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultArticle) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultArticle) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "article"
+func (i *InlineQueryResultArticle) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultArticle
+	value := plain(*i)
+	value.Type = "article"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultAudio) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultAudio) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "audio"
+func (i *InlineQueryResultAudio) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultAudio
+	value := plain(*i)
+	value.Type = "audio"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultContact) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultContact) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "contact"
+func (i *InlineQueryResultContact) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultContact
+	value := plain(*i)
+	value.Type = "contact"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultGame) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultGame) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "game"
+func (i *InlineQueryResultGame) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultGame
+	value := plain(*i)
+	value.Type = "game"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultDocument) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultDocument) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "document"
+func (i *InlineQueryResultDocument) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultDocument
+	value := plain(*i)
+	value.Type = "document"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultGif) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultGif) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "gif"
+func (i *InlineQueryResultGif) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultGif
+	value := plain(*i)
+	value.Type = "gif"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultLocation) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultLocation) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "location"
+func (i *InlineQueryResultLocation) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultLocation
+	value := plain(*i)
+	value.Type = "location"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultMpeg4Gif) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultMpeg4Gif) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "mpeg4_gif"
+func (i *InlineQueryResultMpeg4Gif) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultMpeg4Gif
+	value := plain(*i)
+	value.Type = "mpeg4_gif"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultPhoto) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultPhoto) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "photo"
+func (i *InlineQueryResultPhoto) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultPhoto
+	value := plain(*i)
+	value.Type = "photo"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultVenue) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultVenue) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "venue"
+func (i *InlineQueryResultVenue) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultVenue
+	value := plain(*i)
+	value.Type = "venue"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultVideo) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultVideo) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "video"
+func (i *InlineQueryResultVideo) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultVideo
+	value := plain(*i)
+	value.Type = "video"
+	return json.Marshal(&value)
+}
+func (*InlineQueryResultVoice) isInlineQueryResult() {}
+
+// ResultID returns ID
+func (i *InlineQueryResultVoice) ResultID() string {
+	return i.ID
+}
+
+// MarshalJSON sets Type to "voice"
+func (i *InlineQueryResultVoice) MarshalJSON() ([]byte, error) {
+	type plain InlineQueryResultVoice
+	value := plain(*i)
+	value.Type = "voice"
+	return json.Marshal(&value)
+}
+
+// chooseInlineQueryResult returns the InlineQueryResult member for the json object, nil if it
+// is none of them
+func chooseInlineQueryResult(fields jsonObject) InlineQueryResult {
+	switch {
+	case fields.is("type", "video") && fields.has("video_url") && fields.has("mime_type") && fields.has("thumbnail_url"):
+		return &InlineQueryResultVideo{}
+	case fields.is("type", "audio") && fields.has("audio_url") && fields.has("title"):
+		return &InlineQueryResultAudio{}
+	case fields.is("type", "document") && fields.has("document_url") && fields.has("mime_type"):
+		return &InlineQueryResultDocument{}
+	case fields.is("type", "gif") && fields.has("gif_url") && fields.has("thumbnail_url"):
+		return &InlineQueryResultGif{}
+	case fields.is("type", "mpeg4_gif") && fields.has("mpeg4_url") && fields.has("thumbnail_url"):
+		return &InlineQueryResultMpeg4Gif{}
+	case fields.is("type", "photo") && fields.has("photo_url") && fields.has("thumbnail_url"):
+		return &InlineQueryResultPhoto{}
+	case fields.is("type", "audio") && fields.has("audio_file_id"):
+		return &InlineQueryResultCachedAudio{}
+	case fields.is("type", "document") && fields.has("document_file_id"):
+		return &InlineQueryResultCachedDocument{}
+	case fields.is("type", "gif") && fields.has("gif_file_id"):
+		return &InlineQueryResultCachedGif{}
+	case fields.is("type", "mpeg4_gif") && fields.has("mpeg4_file_id"):
+		return &InlineQueryResultCachedMpeg4Gif{}
+	case fields.is("type", "photo") && fields.has("photo_file_id"):
+		return &InlineQueryResultCachedPhoto{}
+	case fields.is("type", "video") && fields.has("video_file_id"):
+		return &InlineQueryResultCachedVideo{}
+	case fields.is("type", "voice") && fields.has("voice_file_id"):
+		return &InlineQueryResultCachedVoice{}
+	case fields.is("type", "voice") && fields.has("voice_url"):
+		return &InlineQueryResultVoice{}
+	case fields.is("type", "sticker"):
+		return &InlineQueryResultCachedSticker{}
+	case fields.is("type", "article"):
+		return &InlineQueryResultArticle{}
+	case fields.is("type", "contact"):
+		return &InlineQueryResultContact{}
+	case fields.is("type", "game"):
+		return &InlineQueryResultGame{}
+	case fields.is("type", "location"):
+		return &InlineQueryResultLocation{}
+	case fields.is("type", "venue"):
+		return &InlineQueryResultVenue{}
+	}
+	return nil
+}
+
+func (*InputTextMessageContent) isInputMessageContent()     {}
+func (*InputLocationMessageContent) isInputMessageContent() {}
+func (*InputVenueMessageContent) isInputMessageContent()    {}
+func (*InputContactMessageContent) isInputMessageContent()  {}
+func (*InputInvoiceMessageContent) isInputMessageContent()  {}
+
+// chooseInputMessageContent returns the InputMessageContent member for the json object, nil if
+// it is none of them
+func chooseInputMessageContent(fields jsonObject) InputMessageContent {
+	switch {
+	case fields.has("title") && fields.has("description") && fields.has("payload") && fields.has("provider_token") && fields.has("currency") && fields.has("prices"):
+		return &InputInvoiceMessageContent{}
+	case fields.has("latitude") && fields.has("longitude") && fields.has("title") && fields.has("address"):
+		return &InputVenueMessageContent{}
+	case fields.has("latitude") && fields.has("longitude"):
+		return &InputLocationMessageContent{}
+	case fields.has("phone_number") && fields.has("first_name"):
+		return &InputContactMessageContent{}
+	case fields.has("message_text"):
+		return &InputTextMessageContent{}
+	}
+	return nil
+}
+
+func (*Message) isMaybeInaccessibleMessage()             {}
+func (*InaccessibleMessage) isMaybeInaccessibleMessage() {}
+
+// chooseMaybeInaccessibleMessage returns the MaybeInaccessibleMessage member for the json
+// object, nil if it is none of them
+func chooseMaybeInaccessibleMessage(fields jsonObject) MaybeInaccessibleMessage {
+	switch {
+	case !fields.isZero("date"):
+		return &Message{}
+	case fields.isZero("date"):
+		return &InaccessibleMessage{}
+	}
+	return nil
+}
+
+func (*PassportElementErrorDataField) isPassportElementError() {}
+
+// MarshalJSON sets Source to "data"
+func (p *PassportElementErrorDataField) MarshalJSON() ([]byte, error) {
+	type plain PassportElementErrorDataField
+	value := plain(*p)
+	value.Source = "data"
+	return json.Marshal(&value)
+}
+func (*PassportElementErrorFrontSide) isPassportElementError() {}
+
+// MarshalJSON sets Source to "front_side"
+func (p *PassportElementErrorFrontSide) MarshalJSON() ([]byte, error) {
+	type plain PassportElementErrorFrontSide
+	value := plain(*p)
+	value.Source = "front_side"
+	return json.Marshal(&value)
+}
+func (*PassportElementErrorReverseSide) isPassportElementError() {}
+
+// MarshalJSON sets Source to "reverse_side"
+func (p *PassportElementErrorReverseSide) MarshalJSON() ([]byte, error) {
+	type plain PassportElementErrorReverseSide
+	value := plain(*p)
+	value.Source = "reverse_side"
+	return json.Marshal(&value)
+}
+func (*PassportElementErrorSelfie) isPassportElementError() {}
+
+// MarshalJSON sets Source to "selfie"
+func (p *PassportElementErrorSelfie) MarshalJSON() ([]byte, error) {
+	type plain PassportElementErrorSelfie
+	value := plain(*p)
+	value.Source = "selfie"
+	return json.Marshal(&value)
+}
+func (*PassportElementErrorFile) isPassportElementError() {}
+
+// MarshalJSON sets Source to "file"
+func (p *PassportElementErrorFile) MarshalJSON() ([]byte, error) {
+	type plain PassportElementErrorFile
+	value := plain(*p)
+	value.Source = "file"
+	return json.Marshal(&value)
+}
+func (*PassportElementErrorFiles) isPassportElementError() {}
+
+// MarshalJSON sets Source to "files"
+func (p *PassportElementErrorFiles) MarshalJSON() ([]byte, error) {
+	type plain PassportElementErrorFiles
+	value := plain(*p)
+	value.Source = "files"
+	return json.Marshal(&value)
+}
+func (*PassportElementErrorTranslationFile) isPassportElementError() {}
+
+// MarshalJSON sets Source to "translation_file"
+func (p *PassportElementErrorTranslationFile) MarshalJSON() ([]byte, error) {
+	type plain PassportElementErrorTranslationFile
+	value := plain(*p)
+	value.Source = "translation_file"
+	return json.Marshal(&value)
+}
+func (*PassportElementErrorTranslationFiles) isPassportElementError() {}
+
+// MarshalJSON sets Source to "translation_files"
+func (p *PassportElementErrorTranslationFiles) MarshalJSON() ([]byte, error) {
+	type plain PassportElementErrorTranslationFiles
+	value := plain(*p)
+	value.Source = "translation_files"
+	return json.Marshal(&value)
+}
+func (*PassportElementErrorUnspecified) isPassportElementError() {}
+
+// MarshalJSON sets Source to "unspecified"
+func (p *PassportElementErrorUnspecified) MarshalJSON() ([]byte, error) {
+	type plain PassportElementErrorUnspecified
+	value := plain(*p)
+	value.Source = "unspecified"
+	return json.Marshal(&value)
+}
+
+// choosePassportElementError returns the PassportElementError member for the json object, nil
+// if it is none of them
+func choosePassportElementError(fields jsonObject) PassportElementError {
+	switch {
+	case fields.is("source", "data"):
+		return &PassportElementErrorDataField{}
+	case fields.is("source", "front_side"):
+		return &PassportElementErrorFrontSide{}
+	case fields.is("source", "reverse_side"):
+		return &PassportElementErrorReverseSide{}
+	case fields.is("source", "selfie"):
+		return &PassportElementErrorSelfie{}
+	case fields.is("source", "file"):
+		return &PassportElementErrorFile{}
+	case fields.is("source", "files"):
+		return &PassportElementErrorFiles{}
+	case fields.is("source", "translation_file"):
+		return &PassportElementErrorTranslationFile{}
+	case fields.is("source", "translation_files"):
+		return &PassportElementErrorTranslationFiles{}
+	case fields.is("source", "unspecified"):
+		return &PassportElementErrorUnspecified{}
+	}
+	return nil
+}
+
+// Update kind and the effective chat, user and message
+
+// UpdateKind is the json name of the Update field that is set
+type UpdateKind string
+
+const (
+	UpdateKindMessage              UpdateKind = "message"
+	UpdateKindEditedMessage        UpdateKind = "edited_message"
+	UpdateKindChannelPost          UpdateKind = "channel_post"
+	UpdateKindEditedChannelPost    UpdateKind = "edited_channel_post"
+	UpdateKindMessageReaction      UpdateKind = "message_reaction"
+	UpdateKindMessageReactionCount UpdateKind = "message_reaction_count"
+	UpdateKindInlineQuery          UpdateKind = "inline_query"
+	UpdateKindChosenInlineResult   UpdateKind = "chosen_inline_result"
+	UpdateKindCallbackQuery        UpdateKind = "callback_query"
+	UpdateKindShippingQuery        UpdateKind = "shipping_query"
+	UpdateKindPreCheckoutQuery     UpdateKind = "pre_checkout_query"
+	UpdateKindPoll                 UpdateKind = "poll"
+	UpdateKindPollAnswer           UpdateKind = "poll_answer"
+	UpdateKindMyChatMember         UpdateKind = "my_chat_member"
+	UpdateKindChatMember           UpdateKind = "chat_member"
+	UpdateKindChatJoinRequest      UpdateKind = "chat_join_request"
+	UpdateKindChatBoost            UpdateKind = "chat_boost"
+	UpdateKindRemovedChatBoost     UpdateKind = "removed_chat_boost"
+	// UpdateKindUnknown is an update of a kind added after the API version
+	UpdateKindUnknown UpdateKind = "unknown"
+)
+
+// Kind returns the kind of the update, e.g. UpdateKindMessage
+func (u *Update) Kind() UpdateKind {
+	switch {
+	case u.Message != nil:
+		return UpdateKindMessage
+	case u.EditedMessage != nil:
+		return UpdateKindEditedMessage
+	case u.ChannelPost != nil:
+		return UpdateKindChannelPost
+	case u.EditedChannelPost != nil:
+		return UpdateKindEditedChannelPost
+	case u.MessageReaction != nil:
+		return UpdateKindMessageReaction
+	case u.MessageReactionCount != nil:
+		return UpdateKindMessageReactionCount
+	case u.InlineQuery != nil:
+		return UpdateKindInlineQuery
+	case u.ChosenInlineResult != nil:
+		return UpdateKindChosenInlineResult
+	case u.CallbackQuery != nil:
+		return UpdateKindCallbackQuery
+	case u.ShippingQuery != nil:
+		return UpdateKindShippingQuery
+	case u.PreCheckoutQuery != nil:
+		return UpdateKindPreCheckoutQuery
+	case u.Poll != nil:
+		return UpdateKindPoll
+	case u.PollAnswer != nil:
+		return UpdateKindPollAnswer
+	case u.MyChatMember != nil:
+		return UpdateKindMyChatMember
+	case u.ChatMember != nil:
+		return UpdateKindChatMember
+	case u.ChatJoinRequest != nil:
+		return UpdateKindChatJoinRequest
+	case u.ChatBoost != nil:
+		return UpdateKindChatBoost
+	case u.RemovedChatBoost != nil:
+		return UpdateKindRemovedChatBoost
+	}
+	return UpdateKindUnknown
+}
+
+// EffectiveChat returns the chat the update happened in, for callback queries the chat of the
+// message with the button. Nil if there is no chat, e.g. for inline queries
+func (u *Update) EffectiveChat() *Chat {
+	switch {
+	case u.Message != nil:
+		return u.Message.Chat
+	case u.EditedMessage != nil:
+		return u.EditedMessage.Chat
+	case u.ChannelPost != nil:
+		return u.ChannelPost.Chat
+	case u.EditedChannelPost != nil:
+		return u.EditedChannelPost.Chat
+	case u.MessageReaction != nil:
+		return u.MessageReaction.Chat
+	case u.MessageReactionCount != nil:
+		return u.MessageReactionCount.Chat
+	case u.CallbackQuery != nil && u.CallbackQuery.Message != nil:
+		return maybeMessageChat(u.CallbackQuery.Message)
+	case u.MyChatMember != nil:
+		return u.MyChatMember.Chat
+	case u.ChatMember != nil:
+		return u.ChatMember.Chat
+	case u.ChatJoinRequest != nil:
+		return u.ChatJoinRequest.Chat
+	case u.ChatBoost != nil:
+		return u.ChatBoost.Chat
+	case u.RemovedChatBoost != nil:
+		return u.RemovedChatBoost.Chat
+	}
+	return nil
+}
+
+// EffectiveUser returns the user who caused the update, the booster for the chat boosts. Nil
+// if it is unknown, e.g. for channel posts or unclaimed giveaway boosts
+func (u *Update) EffectiveUser() *User {
+	switch {
+	case u.Message != nil:
+		return u.Message.From
+	case u.EditedMessage != nil:
+		return u.EditedMessage.From
+	case u.ChannelPost != nil:
+		return u.ChannelPost.From
+	case u.EditedChannelPost != nil:
+		return u.EditedChannelPost.From
+	case u.MessageReaction != nil:
+		return u.MessageReaction.User
+	case u.InlineQuery != nil:
+		return u.InlineQuery.From
+	case u.ChosenInlineResult != nil:
+		return u.ChosenInlineResult.From
+	case u.CallbackQuery != nil:
+		return u.CallbackQuery.From
+	case u.ShippingQuery != nil:
+		return u.ShippingQuery.From
+	case u.PreCheckoutQuery != nil:
+		return u.PreCheckoutQuery.From
+	case u.PollAnswer != nil:
+		return u.PollAnswer.User
+	case u.MyChatMember != nil:
+		return u.MyChatMember.From
+	case u.ChatMember != nil:
+		return u.ChatMember.From
+	case u.ChatJoinRequest != nil:
+		return u.ChatJoinRequest.From
+	case u.ChatBoost != nil && u.ChatBoost.Boost != nil && u.ChatBoost.Boost.Source != nil:
+		return u.ChatBoost.Boost.Source.User
+	case u.RemovedChatBoost != nil && u.RemovedChatBoost.Source != nil:
+		return u.RemovedChatBoost.Source.User
+	}
+	return nil
+}
+
+// EffectiveSenderChat returns the chat on behalf of which the message was sent, the reaction
+// was changed or the poll was answered, nil if the user acted as themselves
+func (u *Update) EffectiveSenderChat() *Chat {
+	switch {
+	case u.Message != nil:
+		return u.Message.SenderChat
+	case u.EditedMessage != nil:
+		return u.EditedMessage.SenderChat
+	case u.ChannelPost != nil:
+		return u.ChannelPost.SenderChat
+	case u.EditedChannelPost != nil:
+		return u.EditedChannelPost.SenderChat
+	case u.MessageReaction != nil:
+		return u.MessageReaction.ActorChat
+	case u.PollAnswer != nil:
+		return u.PollAnswer.VoterChat
+	}
+	return nil
+}
+
+// EffectiveMessage returns the new or edited message or post, or the message with the pressed
+// button. Nil for the other updates and the inaccessible messages
+func (u *Update) EffectiveMessage() *Message {
+	switch {
+	case u.Message != nil:
+		return u.Message
+	case u.EditedMessage != nil:
+		return u.EditedMessage
+	case u.ChannelPost != nil:
+		return u.ChannelPost
+	case u.EditedChannelPost != nil:
+		return u.EditedChannelPost
+	case u.CallbackQuery != nil:
+		return accessibleMessage(u.CallbackQuery.Message)
+	}
+	return nil
+}
+
+// Message content type and files
+
+// ContentType is the json name of the Message field with the content
+type ContentType string
+
+const (
+	ContentTypeText                          ContentType = "text"
+	ContentTypeAnimation                     ContentType = "animation"
+	ContentTypeAudio                         ContentType = "audio"
+	ContentTypeDocument                      ContentType = "document"
+	ContentTypePhoto                         ContentType = "photo"
+	ContentTypeSticker                       ContentType = "sticker"
+	ContentTypeStory                         ContentType = "story"
+	ContentTypeVideo                         ContentType = "video"
+	ContentTypeVideoNote                     ContentType = "video_note"
+	ContentTypeVoice                         ContentType = "voice"
+	ContentTypeContact                       ContentType = "contact"
+	ContentTypeDice                          ContentType = "dice"
+	ContentTypeGame                          ContentType = "game"
+	ContentTypePoll                          ContentType = "poll"
+	ContentTypeVenue                         ContentType = "venue"
+	ContentTypeLocation                      ContentType = "location"
+	ContentTypeNewChatMembers                ContentType = "new_chat_members"
+	ContentTypeLeftChatMember                ContentType = "left_chat_member"
+	ContentTypeNewChatTitle                  ContentType = "new_chat_title"
+	ContentTypeNewChatPhoto                  ContentType = "new_chat_photo"
+	ContentTypeDeleteChatPhoto               ContentType = "delete_chat_photo"
+	ContentTypeGroupChatCreated              ContentType = "group_chat_created"
+	ContentTypeSupergroupChatCreated         ContentType = "supergroup_chat_created"
+	ContentTypeChannelChatCreated            ContentType = "channel_chat_created"
+	ContentTypeMessageAutoDeleteTimerChanged ContentType = "message_auto_delete_timer_changed"
+	ContentTypeMigrateToChatID               ContentType = "migrate_to_chat_id"
+	ContentTypeMigrateFromChatID             ContentType = "migrate_from_chat_id"
+	ContentTypePinnedMessage                 ContentType = "pinned_message"
+	ContentTypeInvoice                       ContentType = "invoice"
+	ContentTypeSuccessfulPayment             ContentType = "successful_payment"
+	ContentTypeUsersShared                   ContentType = "users_shared"
+	ContentTypeChatShared                    ContentType = "chat_shared"
+	ContentTypeConnectedWebsite              ContentType = "connected_website"
+	ContentTypeWriteAccessAllowed            ContentType = "write_access_allowed"
+	ContentTypePassportData                  ContentType = "passport_data"
+	ContentTypeProximityAlertTriggered       ContentType = "proximity_alert_triggered"
+	ContentTypeForumTopicCreated             ContentType = "forum_topic_created"
+	ContentTypeForumTopicEdited              ContentType = "forum_topic_edited"
+	ContentTypeForumTopicClosed              ContentType = "forum_topic_closed"
+	ContentTypeForumTopicReopened            ContentType = "forum_topic_reopened"
+	ContentTypeGeneralForumTopicHidden       ContentType = "general_forum_topic_hidden"
+	ContentTypeGeneralForumTopicUnhidden     ContentType = "general_forum_topic_unhidden"
+	ContentTypeGiveawayCreated               ContentType = "giveaway_created"
+	ContentTypeGiveaway                      ContentType = "giveaway"
+	ContentTypeGiveawayWinners               ContentType = "giveaway_winners"
+	ContentTypeGiveawayCompleted             ContentType = "giveaway_completed"
+	ContentTypeVideoChatScheduled            ContentType = "video_chat_scheduled"
+	ContentTypeVideoChatStarted              ContentType = "video_chat_started"
+	ContentTypeVideoChatEnded                ContentType = "video_chat_ended"
+	ContentTypeVideoChatParticipantsInvited  ContentType = "video_chat_participants_invited"
+	ContentTypeWebAppData                    ContentType = "web_app_data"
+	// ContentTypeUnknown is a message with a content added after the API version
+	ContentTypeUnknown ContentType = "unknown"
+)
+
+// ContentType returns the type of the message content or of the service message, e.g.
+// ContentTypePhoto or ContentTypeNewChatMembers
+func (m *Message) ContentType() ContentType {
+	switch {
+	case m.Text != "":
+		return ContentTypeText
+	case m.Animation != nil:
+		return ContentTypeAnimation
+	case m.Audio != nil:
+		return ContentTypeAudio
+	case m.Document != nil:
+		return ContentTypeDocument
+	case len(m.Photo) != 0:
+		return ContentTypePhoto
+	case m.Sticker != nil:
+		return ContentTypeSticker
+	case m.Story != nil:
+		return ContentTypeStory
+	case m.Video != nil:
+		return ContentTypeVideo
+	case m.VideoNote != nil:
+		return ContentTypeVideoNote
+	case m.Voice != nil:
+		return ContentTypeVoice
+	case m.Contact != nil:
+		return ContentTypeContact
+	case m.Dice != nil:
+		return ContentTypeDice
+	case m.Game != nil:
+		return ContentTypeGame
+	case m.Poll != nil:
+		return ContentTypePoll
+	case m.Venue != nil:
+		return ContentTypeVenue
+	case m.Location != nil:
+		return ContentTypeLocation
+	case len(m.NewChatMembers) != 0:
+		return ContentTypeNewChatMembers
+	case m.LeftChatMember != nil:
+		return ContentTypeLeftChatMember
+	case m.NewChatTitle != "":
+		return ContentTypeNewChatTitle
+	case len(m.NewChatPhoto) != 0:
+		return ContentTypeNewChatPhoto
+	case m.DeleteChatPhoto:
+		return ContentTypeDeleteChatPhoto
+	case m.GroupChatCreated:
+		return ContentTypeGroupChatCreated
+	case m.SupergroupChatCreated:
+		return ContentTypeSupergroupChatCreated
+	case m.ChannelChatCreated:
+		return ContentTypeChannelChatCreated
+	case m.MessageAutoDeleteTimerChanged != nil:
+		return ContentTypeMessageAutoDeleteTimerChanged
+	case m.MigrateToChatID != 0:
+		return ContentTypeMigrateToChatID
+	case m.MigrateFromChatID != 0:
+		return ContentTypeMigrateFromChatID
+	case m.PinnedMessage != nil:
+		return ContentTypePinnedMessage
+	case m.Invoice != nil:
+		return ContentTypeInvoice
+	case m.SuccessfulPayment != nil:
+		return ContentTypeSuccessfulPayment
+	case m.UsersShared != nil:
+		return ContentTypeUsersShared
+	case m.ChatShared != nil:
+		return ContentTypeChatShared
+	case m.ConnectedWebsite != "":
+		return ContentTypeConnectedWebsite
+	case m.WriteAccessAllowed != nil:
+		return ContentTypeWriteAccessAllowed
+	case m.PassportData != nil:
+		return ContentTypePassportData
+	case m.ProximityAlertTriggered != nil:
+		return ContentTypeProximityAlertTriggered
+	case m.ForumTopicCreated != nil:
+		return ContentTypeForumTopicCreated
+	case m.ForumTopicEdited != nil:
+		return ContentTypeForumTopicEdited
+	case m.ForumTopicClosed != nil:
+		return ContentTypeForumTopicClosed
+	case m.ForumTopicReopened != nil:
+		return ContentTypeForumTopicReopened
+	case m.GeneralForumTopicHidden != nil:
+		return ContentTypeGeneralForumTopicHidden
+	case m.GeneralForumTopicUnhidden != nil:
+		return ContentTypeGeneralForumTopicUnhidden
+	case m.GiveawayCreated != nil:
+		return ContentTypeGiveawayCreated
+	case m.Giveaway != nil:
+		return ContentTypeGiveaway
+	case m.GiveawayWinners != nil:
+		return ContentTypeGiveawayWinners
+	case m.GiveawayCompleted != nil:
+		return ContentTypeGiveawayCompleted
+	case m.VideoChatScheduled != nil:
+		return ContentTypeVideoChatScheduled
+	case m.VideoChatStarted != nil:
+		return ContentTypeVideoChatStarted
+	case m.VideoChatEnded != nil:
+		return ContentTypeVideoChatEnded
+	case m.VideoChatParticipantsInvited != nil:
+		return ContentTypeVideoChatParticipantsInvited
+	case m.WebAppData != nil:
+		return ContentTypeWebAppData
+	}
+	return ContentTypeUnknown
+}
+
+// IsService reports if the message is a service message, e.g. about a new chat member or a
+// pinned message
+func (m *Message) IsService() bool {
+	switch m.ContentType() {
+	case ContentTypeNewChatMembers,
+		ContentTypeLeftChatMember,
+		ContentTypeNewChatTitle,
+		ContentTypeNewChatPhoto,
+		ContentTypeDeleteChatPhoto,
+		ContentTypeGroupChatCreated,
+		ContentTypeSupergroupChatCreated,
+		ContentTypeChannelChatCreated,
+		ContentTypeMessageAutoDeleteTimerChanged,
+		ContentTypeMigrateToChatID,
+		ContentTypeMigrateFromChatID,
+		ContentTypePinnedMessage,
+		ContentTypeSuccessfulPayment,
+		ContentTypeUsersShared,
+		ContentTypeChatShared,
+		ContentTypeConnectedWebsite,
+		ContentTypeWriteAccessAllowed,
+		ContentTypePassportData,
+		ContentTypeProximityAlertTriggered,
+		ContentTypeForumTopicCreated,
+		ContentTypeForumTopicEdited,
+		ContentTypeForumTopicClosed,
+		ContentTypeForumTopicReopened,
+		ContentTypeGeneralForumTopicHidden,
+		ContentTypeGeneralForumTopicUnhidden,
+		ContentTypeGiveawayCreated,
+		ContentTypeGiveawayCompleted,
+		ContentTypeVideoChatScheduled,
+		ContentTypeVideoChatStarted,
+		ContentTypeVideoChatEnded,
+		ContentTypeVideoChatParticipantsInvited,
+		ContentTypeWebAppData:
+		return true
+	}
+	return false
+}
+
+// File returns the file of the message media, for photos the largest size. Nil if the message
+// has no file
+func (m *Message) File() *File {
+	switch {
+	case m.Animation != nil:
+		return m.Animation.File()
+	case m.Audio != nil:
+		return m.Audio.File()
+	case m.Document != nil:
+		return m.Document.File()
+	case len(m.Photo) != 0:
+		return m.LargestPhoto().File()
+	case m.Sticker != nil:
+		return m.Sticker.File()
+	case m.Video != nil:
+		return m.Video.File()
+	case m.VideoNote != nil:
+		return m.VideoNote.File()
+	case m.Voice != nil:
+		return m.Voice.File()
+	}
+	return nil
+}
+
+// File returns the PhotoSize file for getFile and DownloadFile
+func (p *PhotoSize) File() *File {
+	return &File{FileID: p.FileID, FileUniqueID: p.FileUniqueID, FileSize: p.FileSize}
+}
+
+// File returns the Animation file for getFile and DownloadFile
+func (a *Animation) File() *File {
+	return &File{FileID: a.FileID, FileUniqueID: a.FileUniqueID, FileSize: a.FileSize}
+}
+
+// File returns the Audio file for getFile and DownloadFile
+func (a *Audio) File() *File {
+	return &File{FileID: a.FileID, FileUniqueID: a.FileUniqueID, FileSize: a.FileSize}
+}
+
+// File returns the Document file for getFile and DownloadFile
+func (d *Document) File() *File {
+	return &File{FileID: d.FileID, FileUniqueID: d.FileUniqueID, FileSize: d.FileSize}
+}
+
+// File returns the Video file for getFile and DownloadFile
+func (v *Video) File() *File {
+	return &File{FileID: v.FileID, FileUniqueID: v.FileUniqueID, FileSize: v.FileSize}
+}
+
+// File returns the VideoNote file for getFile and DownloadFile
+func (v *VideoNote) File() *File {
+	return &File{FileID: v.FileID, FileUniqueID: v.FileUniqueID, FileSize: v.FileSize}
+}
+
+// File returns the Voice file for getFile and DownloadFile
+func (v *Voice) File() *File {
+	return &File{FileID: v.FileID, FileUniqueID: v.FileUniqueID, FileSize: v.FileSize}
+}
+
+// File returns the Sticker file for getFile and DownloadFile
+func (s *Sticker) File() *File {
+	return &File{FileID: s.FileID, FileUniqueID: s.FileUniqueID, FileSize: s.FileSize}
+}
+
+// File returns the PassportFile file for getFile and DownloadFile
+func (p *PassportFile) File() *File {
+	return &File{FileID: p.FileID, FileUniqueID: p.FileUniqueID, FileSize: p.FileSize}
+}
+
 // Bot request and response types
+
 // Request for API call 'getUpdates'
 type GetUpdatesRequest struct {
 	// Identifier of the first update to be returned. Must be greater by one than the highest
@@ -4374,6 +5932,16 @@ type GetUpdatesRequest struct {
 	AllowedUpdates []string `json:"allowed_updates,omitempty"`
 }
 
+// TimeoutAsDuration returns Timeout as time.Duration
+func (g *GetUpdatesRequest) TimeoutAsDuration() time.Duration {
+	return secondsToDuration(g.Timeout)
+}
+
+// SetTimeout sets Timeout from time.Duration
+func (g *GetUpdatesRequest) SetTimeout(value time.Duration) {
+	g.Timeout = durationToSeconds(value)
+}
+
 // Response for API call 'getUpdates'
 type GetUpdatesResponse struct {
 	// Raw response from the server
@@ -4786,6 +6354,16 @@ type SendAudioRequest struct {
 	ReplyMarkup interface{} `json:"reply_markup,omitempty"`
 }
 
+// DurationAsDuration returns Duration as time.Duration
+func (s *SendAudioRequest) DurationAsDuration() time.Duration {
+	return secondsToDuration(s.Duration)
+}
+
+// SetDuration sets Duration from time.Duration
+func (s *SendAudioRequest) SetDuration(value time.Duration) {
+	s.Duration = durationToSeconds(value)
+}
+
 // Response for API call 'sendAudio'
 type SendAudioResponse struct {
 	// Raw response from the server
@@ -4921,6 +6499,16 @@ type SendVideoRequest struct {
 	ReplyMarkup interface{} `json:"reply_markup,omitempty"`
 }
 
+// DurationAsDuration returns Duration as time.Duration
+func (s *SendVideoRequest) DurationAsDuration() time.Duration {
+	return secondsToDuration(s.Duration)
+}
+
+// SetDuration sets Duration from time.Duration
+func (s *SendVideoRequest) SetDuration(value time.Duration) {
+	s.Duration = durationToSeconds(value)
+}
+
 // Response for API call 'sendVideo'
 type SendVideoResponse struct {
 	// Raw response from the server
@@ -4992,6 +6580,16 @@ type SendAnimationRequest struct {
 	ReplyMarkup interface{} `json:"reply_markup,omitempty"`
 }
 
+// DurationAsDuration returns Duration as time.Duration
+func (s *SendAnimationRequest) DurationAsDuration() time.Duration {
+	return secondsToDuration(s.Duration)
+}
+
+// SetDuration sets Duration from time.Duration
+func (s *SendAnimationRequest) SetDuration(value time.Duration) {
+	s.Duration = durationToSeconds(value)
+}
+
 // Response for API call 'sendAnimation'
 type SendAnimationResponse struct {
 	// Raw response from the server
@@ -5045,6 +6643,16 @@ type SendVoiceRequest struct {
 	ReplyMarkup interface{} `json:"reply_markup,omitempty"`
 }
 
+// DurationAsDuration returns Duration as time.Duration
+func (s *SendVoiceRequest) DurationAsDuration() time.Duration {
+	return secondsToDuration(s.Duration)
+}
+
+// SetDuration sets Duration from time.Duration
+func (s *SendVoiceRequest) SetDuration(value time.Duration) {
+	s.Duration = durationToSeconds(value)
+}
+
 // Response for API call 'sendVoice'
 type SendVoiceResponse struct {
 	// Raw response from the server
@@ -5097,6 +6705,16 @@ type SendVideoNoteRequest struct {
 	ReplyMarkup interface{} `json:"reply_markup,omitempty"`
 }
 
+// DurationAsDuration returns Duration as time.Duration
+func (s *SendVideoNoteRequest) DurationAsDuration() time.Duration {
+	return secondsToDuration(s.Duration)
+}
+
+// SetDuration sets Duration from time.Duration
+func (s *SendVideoNoteRequest) SetDuration(value time.Duration) {
+	s.Duration = durationToSeconds(value)
+}
+
 // Response for API call 'sendVideoNote'
 type SendVideoNoteResponse struct {
 	// Raw response from the server
@@ -5151,6 +6769,16 @@ type SendLocationRequest struct {
 	ReplyMarkup interface{} `json:"reply_markup,omitempty"`
 }
 
+// LivePeriodAsDuration returns LivePeriod as time.Duration
+func (s *SendLocationRequest) LivePeriodAsDuration() time.Duration {
+	return secondsToDuration(s.LivePeriod)
+}
+
+// SetLivePeriod sets LivePeriod from time.Duration
+func (s *SendLocationRequest) SetLivePeriod(value time.Duration) {
+	s.LivePeriod = durationToSeconds(value)
+}
+
 // Response for API call 'sendLocation'
 type SendLocationResponse struct {
 	// Raw response from the server
@@ -5329,6 +6957,26 @@ type SendPollRequest struct {
 	ReplyMarkup interface{} `json:"reply_markup,omitempty"`
 }
 
+// OpenPeriodAsDuration returns OpenPeriod as time.Duration
+func (s *SendPollRequest) OpenPeriodAsDuration() time.Duration {
+	return secondsToDuration(s.OpenPeriod)
+}
+
+// SetOpenPeriod sets OpenPeriod from time.Duration
+func (s *SendPollRequest) SetOpenPeriod(value time.Duration) {
+	s.OpenPeriod = durationToSeconds(value)
+}
+
+// CloseDateAsTime returns CloseDate as time.Time
+func (s *SendPollRequest) CloseDateAsTime() time.Time {
+	return unixToTime(s.CloseDate)
+}
+
+// SetCloseDate sets CloseDate from time.Time
+func (s *SendPollRequest) SetCloseDate(value time.Time) {
+	s.CloseDate = timeToUnix(value)
+}
+
 // Response for API call 'sendPoll'
 type SendPollResponse struct {
 	// Raw response from the server
@@ -5478,6 +7126,16 @@ type BanChatMemberRequest struct {
 	RevokeMessages bool `json:"revoke_messages,omitempty"`
 }
 
+// UntilDateAsTime returns UntilDate as time.Time
+func (b *BanChatMemberRequest) UntilDateAsTime() time.Time {
+	return unixToTime(b.UntilDate)
+}
+
+// SetUntilDate sets UntilDate from time.Time
+func (b *BanChatMemberRequest) SetUntilDate(value time.Time) {
+	b.UntilDate = timeToUnix(value)
+}
+
 // Response for API call 'banChatMember'
 type BanChatMemberResponse struct {
 	// Raw response from the server
@@ -5528,6 +7186,16 @@ type RestrictChatMemberRequest struct {
 	UntilDate int64 `json:"until_date,omitempty"`
 }
 
+// UntilDateAsTime returns UntilDate as time.Time
+func (r *RestrictChatMemberRequest) UntilDateAsTime() time.Time {
+	return unixToTime(r.UntilDate)
+}
+
+// SetUntilDate sets UntilDate from time.Time
+func (r *RestrictChatMemberRequest) SetUntilDate(value time.Time) {
+	r.UntilDate = timeToUnix(value)
+}
+
 // Response for API call 'restrictChatMember'
 type RestrictChatMemberResponse struct {
 	// Raw response from the server
@@ -5711,6 +7379,16 @@ type CreateChatInviteLinkRequest struct {
 	CreatesJoinRequest bool `json:"creates_join_request,omitempty"`
 }
 
+// ExpireDateAsTime returns ExpireDate as time.Time
+func (c *CreateChatInviteLinkRequest) ExpireDateAsTime() time.Time {
+	return unixToTime(c.ExpireDate)
+}
+
+// SetExpireDate sets ExpireDate from time.Time
+func (c *CreateChatInviteLinkRequest) SetExpireDate(value time.Time) {
+	c.ExpireDate = timeToUnix(value)
+}
+
 // Response for API call 'createChatInviteLink'
 type CreateChatInviteLinkResponse struct {
 	// Raw response from the server
@@ -5741,6 +7419,16 @@ type EditChatInviteLinkRequest struct {
 	CreatesJoinRequest bool `json:"creates_join_request,omitempty"`
 }
 
+// ExpireDateAsTime returns ExpireDate as time.Time
+func (e *EditChatInviteLinkRequest) ExpireDateAsTime() time.Time {
+	return unixToTime(e.ExpireDate)
+}
+
+// SetExpireDate sets ExpireDate from time.Time
+func (e *EditChatInviteLinkRequest) SetExpireDate(value time.Time) {
+	e.ExpireDate = timeToUnix(value)
+}
+
 // Response for API call 'editChatInviteLink'
 type EditChatInviteLinkResponse struct {
 	// Raw response from the server
@@ -6238,6 +7926,16 @@ type AnswerCallbackQueryRequest struct {
 	CacheTime int64 `json:"cache_time,omitempty"`
 }
 
+// CacheTimeAsDuration returns CacheTime as time.Duration
+func (a *AnswerCallbackQueryRequest) CacheTimeAsDuration() time.Duration {
+	return secondsToDuration(a.CacheTime)
+}
+
+// SetCacheTime sets CacheTime from time.Duration
+func (a *AnswerCallbackQueryRequest) SetCacheTime(value time.Duration) {
+	a.CacheTime = durationToSeconds(value)
+}
+
 // Response for API call 'answerCallbackQuery'
 type AnswerCallbackQueryResponse struct {
 	// Raw response from the server
@@ -7007,7 +8705,7 @@ type AnswerInlineQueryRequest struct {
 	InlineQueryID string `json:"inline_query_id,omitempty"`
 
 	// A JSON-serialized array of results for the inline query
-	Results []*InlineQueryResult `json:"results,omitempty"`
+	Results []InlineQueryResult `json:"results,omitempty"`
 
 	// The maximum amount of time in seconds that the result of the inline query may be cached on
 	// the server. Defaults to 300.
@@ -7026,6 +8724,33 @@ type AnswerInlineQueryRequest struct {
 	Button *InlineQueryResultsButton `json:"button,omitempty"`
 }
 
+// CacheTimeAsDuration returns CacheTime as time.Duration
+func (a *AnswerInlineQueryRequest) CacheTimeAsDuration() time.Duration {
+	return secondsToDuration(a.CacheTime)
+}
+
+// SetCacheTime sets CacheTime from time.Duration
+func (a *AnswerInlineQueryRequest) SetCacheTime(value time.Duration) {
+	a.CacheTime = durationToSeconds(value)
+}
+
+// UnmarshalJSON decodes Results into the members of the interface
+func (a *AnswerInlineQueryRequest) UnmarshalJSON(data []byte) error {
+	type plain AnswerInlineQueryRequest
+	value := struct {
+		*plain
+		Results json.RawMessage `json:"results"`
+	}{plain: (*plain)(a)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if a.Results, err = unmarshalMembers(value.Results, "InlineQueryResult", chooseInlineQueryResult); err != nil {
+		return err
+	}
+	return nil
+}
+
 // Response for API call 'answerInlineQuery'
 type AnswerInlineQueryResponse struct {
 	// Raw response from the server
@@ -7038,7 +8763,24 @@ type AnswerWebAppQueryRequest struct {
 	WebAppQueryID string `json:"web_app_query_id,omitempty"`
 
 	// A JSON-serialized object describing the message to be sent
-	Result *InlineQueryResult `json:"result,omitempty"`
+	Result InlineQueryResult `json:"result,omitempty"`
+}
+
+// UnmarshalJSON decodes Result into the members of the interface
+func (a *AnswerWebAppQueryRequest) UnmarshalJSON(data []byte) error {
+	type plain AnswerWebAppQueryRequest
+	value := struct {
+		*plain
+		Result json.RawMessage `json:"result"`
+	}{plain: (*plain)(a)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if a.Result, err = unmarshalMember(value.Result, "InlineQueryResult", chooseInlineQueryResult); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Response for API call 'answerWebAppQuery'
@@ -7291,7 +9033,24 @@ type SetPassportDataErrorsRequest struct {
 	UserID int64 `json:"user_id,omitempty"`
 
 	// A JSON-serialized array describing the errors
-	Errors []*PassportElementError `json:"errors,omitempty"`
+	Errors []PassportElementError `json:"errors,omitempty"`
+}
+
+// UnmarshalJSON decodes Errors into the members of the interface
+func (s *SetPassportDataErrorsRequest) UnmarshalJSON(data []byte) error {
+	type plain SetPassportDataErrorsRequest
+	value := struct {
+		*plain
+		Errors json.RawMessage `json:"errors"`
+	}{plain: (*plain)(s)}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+	if s.Errors, err = unmarshalMembers(value.Errors, "PassportElementError", choosePassportElementError); err != nil {
+		return err
+	}
+	return nil
 }
 
 // Response for API call 'setPassportDataErrors'
@@ -8730,3 +10489,131 @@ func (a *TelegramApi) GetGameHighScores(request *GetGameHighScoresRequest) (*Get
 	}
 	return &GetGameHighScoresResponse{Result: apiResponse.Result}, nil
 }
+
+// TelegramApiInterface has all Bot API methods of TelegramApi
+type TelegramApiInterface interface {
+	// WithContext returns the api that makes all calls with the context
+	WithContext(ctx context.Context) TelegramApiInterface
+	// DownloadFile downloads the file by its id
+	DownloadFile(ctx context.Context, fileID string) (*FileContent, error)
+	GetUpdates(request *GetUpdatesRequest) (*GetUpdatesResponse, error)
+	SetWebhook(request *SetWebhookRequest) (*SetWebhookResponse, error)
+	DeleteWebhook(request *DeleteWebhookRequest) (*DeleteWebhookResponse, error)
+	GetWebhookInfo(request *GetWebhookInfoRequest) (*GetWebhookInfoResponse, error)
+	GetMe(request *GetMeRequest) (*GetMeResponse, error)
+	LogOut(request *LogOutRequest) (*LogOutResponse, error)
+	Close(request *CloseRequest) (*CloseResponse, error)
+	SendMessage(request *SendMessageRequest) (*SendMessageResponse, error)
+	ForwardMessage(request *ForwardMessageRequest) (*ForwardMessageResponse, error)
+	ForwardMessages(request *ForwardMessagesRequest) (*ForwardMessagesResponse, error)
+	CopyMessage(request *CopyMessageRequest) (*CopyMessageResponse, error)
+	CopyMessages(request *CopyMessagesRequest) (*CopyMessagesResponse, error)
+	SendPhoto(request *SendPhotoRequest) (*SendPhotoResponse, error)
+	SendAudio(request *SendAudioRequest) (*SendAudioResponse, error)
+	SendDocument(request *SendDocumentRequest) (*SendDocumentResponse, error)
+	SendVideo(request *SendVideoRequest) (*SendVideoResponse, error)
+	SendAnimation(request *SendAnimationRequest) (*SendAnimationResponse, error)
+	SendVoice(request *SendVoiceRequest) (*SendVoiceResponse, error)
+	SendVideoNote(request *SendVideoNoteRequest) (*SendVideoNoteResponse, error)
+	SendLocation(request *SendLocationRequest) (*SendLocationResponse, error)
+	SendVenue(request *SendVenueRequest) (*SendVenueResponse, error)
+	SendContact(request *SendContactRequest) (*SendContactResponse, error)
+	SendPoll(request *SendPollRequest) (*SendPollResponse, error)
+	SendDice(request *SendDiceRequest) (*SendDiceResponse, error)
+	SendChatAction(request *SendChatActionRequest) (*SendChatActionResponse, error)
+	SetMessageReaction(request *SetMessageReactionRequest) (*SetMessageReactionResponse, error)
+	GetUserProfilePhotos(request *GetUserProfilePhotosRequest) (*GetUserProfilePhotosResponse, error)
+	GetFile(request *GetFileRequest) (*GetFileResponse, error)
+	BanChatMember(request *BanChatMemberRequest) (*BanChatMemberResponse, error)
+	UnbanChatMember(request *UnbanChatMemberRequest) (*UnbanChatMemberResponse, error)
+	RestrictChatMember(request *RestrictChatMemberRequest) (*RestrictChatMemberResponse, error)
+	PromoteChatMember(request *PromoteChatMemberRequest) (*PromoteChatMemberResponse, error)
+	SetChatAdministratorCustomTitle(request *SetChatAdministratorCustomTitleRequest) (*SetChatAdministratorCustomTitleResponse, error)
+	BanChatSenderChat(request *BanChatSenderChatRequest) (*BanChatSenderChatResponse, error)
+	UnbanChatSenderChat(request *UnbanChatSenderChatRequest) (*UnbanChatSenderChatResponse, error)
+	SetChatPermissions(request *SetChatPermissionsRequest) (*SetChatPermissionsResponse, error)
+	ExportChatInviteLink(request *ExportChatInviteLinkRequest) (*ExportChatInviteLinkResponse, error)
+	CreateChatInviteLink(request *CreateChatInviteLinkRequest) (*CreateChatInviteLinkResponse, error)
+	EditChatInviteLink(request *EditChatInviteLinkRequest) (*EditChatInviteLinkResponse, error)
+	RevokeChatInviteLink(request *RevokeChatInviteLinkRequest) (*RevokeChatInviteLinkResponse, error)
+	ApproveChatJoinRequest(request *ApproveChatJoinRequestRequest) (*ApproveChatJoinRequestResponse, error)
+	DeclineChatJoinRequest(request *DeclineChatJoinRequestRequest) (*DeclineChatJoinRequestResponse, error)
+	SetChatPhoto(request *SetChatPhotoRequest) (*SetChatPhotoResponse, error)
+	DeleteChatPhoto(request *DeleteChatPhotoRequest) (*DeleteChatPhotoResponse, error)
+	SetChatTitle(request *SetChatTitleRequest) (*SetChatTitleResponse, error)
+	SetChatDescription(request *SetChatDescriptionRequest) (*SetChatDescriptionResponse, error)
+	PinChatMessage(request *PinChatMessageRequest) (*PinChatMessageResponse, error)
+	UnpinChatMessage(request *UnpinChatMessageRequest) (*UnpinChatMessageResponse, error)
+	UnpinAllChatMessages(request *UnpinAllChatMessagesRequest) (*UnpinAllChatMessagesResponse, error)
+	LeaveChat(request *LeaveChatRequest) (*LeaveChatResponse, error)
+	GetChat(request *GetChatRequest) (*GetChatResponse, error)
+	GetChatAdministrators(request *GetChatAdministratorsRequest) (*GetChatAdministratorsResponse, error)
+	GetChatMemberCount(request *GetChatMemberCountRequest) (*GetChatMemberCountResponse, error)
+	GetChatMember(request *GetChatMemberRequest) (*GetChatMemberResponse, error)
+	SetChatStickerSet(request *SetChatStickerSetRequest) (*SetChatStickerSetResponse, error)
+	DeleteChatStickerSet(request *DeleteChatStickerSetRequest) (*DeleteChatStickerSetResponse, error)
+	GetForumTopicIconStickers(request *GetForumTopicIconStickersRequest) (*GetForumTopicIconStickersResponse, error)
+	CreateForumTopic(request *CreateForumTopicRequest) (*CreateForumTopicResponse, error)
+	EditForumTopic(request *EditForumTopicRequest) (*EditForumTopicResponse, error)
+	CloseForumTopic(request *CloseForumTopicRequest) (*CloseForumTopicResponse, error)
+	ReopenForumTopic(request *ReopenForumTopicRequest) (*ReopenForumTopicResponse, error)
+	DeleteForumTopic(request *DeleteForumTopicRequest) (*DeleteForumTopicResponse, error)
+	UnpinAllForumTopicMessages(request *UnpinAllForumTopicMessagesRequest) (*UnpinAllForumTopicMessagesResponse, error)
+	EditGeneralForumTopic(request *EditGeneralForumTopicRequest) (*EditGeneralForumTopicResponse, error)
+	CloseGeneralForumTopic(request *CloseGeneralForumTopicRequest) (*CloseGeneralForumTopicResponse, error)
+	ReopenGeneralForumTopic(request *ReopenGeneralForumTopicRequest) (*ReopenGeneralForumTopicResponse, error)
+	HideGeneralForumTopic(request *HideGeneralForumTopicRequest) (*HideGeneralForumTopicResponse, error)
+	UnhideGeneralForumTopic(request *UnhideGeneralForumTopicRequest) (*UnhideGeneralForumTopicResponse, error)
+	UnpinAllGeneralForumTopicMessages(request *UnpinAllGeneralForumTopicMessagesRequest) (*UnpinAllGeneralForumTopicMessagesResponse, error)
+	AnswerCallbackQuery(request *AnswerCallbackQueryRequest) (*AnswerCallbackQueryResponse, error)
+	GetUserChatBoosts(request *GetUserChatBoostsRequest) (*GetUserChatBoostsResponse, error)
+	SetMyCommands(request *SetMyCommandsRequest) (*SetMyCommandsResponse, error)
+	DeleteMyCommands(request *DeleteMyCommandsRequest) (*DeleteMyCommandsResponse, error)
+	GetMyCommands(request *GetMyCommandsRequest) (*GetMyCommandsResponse, error)
+	SetMyName(request *SetMyNameRequest) (*SetMyNameResponse, error)
+	GetMyName(request *GetMyNameRequest) (*GetMyNameResponse, error)
+	SetMyDescription(request *SetMyDescriptionRequest) (*SetMyDescriptionResponse, error)
+	GetMyDescription(request *GetMyDescriptionRequest) (*GetMyDescriptionResponse, error)
+	SetMyShortDescription(request *SetMyShortDescriptionRequest) (*SetMyShortDescriptionResponse, error)
+	GetMyShortDescription(request *GetMyShortDescriptionRequest) (*GetMyShortDescriptionResponse, error)
+	SetChatMenuButton(request *SetChatMenuButtonRequest) (*SetChatMenuButtonResponse, error)
+	GetChatMenuButton(request *GetChatMenuButtonRequest) (*GetChatMenuButtonResponse, error)
+	SetMyDefaultAdministratorRights(request *SetMyDefaultAdministratorRightsRequest) (*SetMyDefaultAdministratorRightsResponse, error)
+	GetMyDefaultAdministratorRights(request *GetMyDefaultAdministratorRightsRequest) (*GetMyDefaultAdministratorRightsResponse, error)
+	EditMessageText(request *EditMessageTextRequest) (*EditMessageTextResponse, error)
+	EditMessageCaption(request *EditMessageCaptionRequest) (*EditMessageCaptionResponse, error)
+	EditMessageMedia(request *EditMessageMediaRequest) (*EditMessageMediaResponse, error)
+	EditMessageLiveLocation(request *EditMessageLiveLocationRequest) (*EditMessageLiveLocationResponse, error)
+	StopMessageLiveLocation(request *StopMessageLiveLocationRequest) (*StopMessageLiveLocationResponse, error)
+	EditMessageReplyMarkup(request *EditMessageReplyMarkupRequest) (*EditMessageReplyMarkupResponse, error)
+	StopPoll(request *StopPollRequest) (*StopPollResponse, error)
+	DeleteMessage(request *DeleteMessageRequest) (*DeleteMessageResponse, error)
+	DeleteMessages(request *DeleteMessagesRequest) (*DeleteMessagesResponse, error)
+	SendSticker(request *SendStickerRequest) (*SendStickerResponse, error)
+	GetStickerSet(request *GetStickerSetRequest) (*GetStickerSetResponse, error)
+	GetCustomEmojiStickers(request *GetCustomEmojiStickersRequest) (*GetCustomEmojiStickersResponse, error)
+	UploadStickerFile(request *UploadStickerFileRequest) (*UploadStickerFileResponse, error)
+	CreateNewStickerSet(request *CreateNewStickerSetRequest) (*CreateNewStickerSetResponse, error)
+	AddStickerToSet(request *AddStickerToSetRequest) (*AddStickerToSetResponse, error)
+	SetStickerPositionInSet(request *SetStickerPositionInSetRequest) (*SetStickerPositionInSetResponse, error)
+	DeleteStickerFromSet(request *DeleteStickerFromSetRequest) (*DeleteStickerFromSetResponse, error)
+	SetStickerEmojiList(request *SetStickerEmojiListRequest) (*SetStickerEmojiListResponse, error)
+	SetStickerKeywords(request *SetStickerKeywordsRequest) (*SetStickerKeywordsResponse, error)
+	SetStickerMaskPosition(request *SetStickerMaskPositionRequest) (*SetStickerMaskPositionResponse, error)
+	SetStickerSetTitle(request *SetStickerSetTitleRequest) (*SetStickerSetTitleResponse, error)
+	SetStickerSetThumbnail(request *SetStickerSetThumbnailRequest) (*SetStickerSetThumbnailResponse, error)
+	SetCustomEmojiStickerSetThumbnail(request *SetCustomEmojiStickerSetThumbnailRequest) (*SetCustomEmojiStickerSetThumbnailResponse, error)
+	DeleteStickerSet(request *DeleteStickerSetRequest) (*DeleteStickerSetResponse, error)
+	AnswerInlineQuery(request *AnswerInlineQueryRequest) (*AnswerInlineQueryResponse, error)
+	AnswerWebAppQuery(request *AnswerWebAppQueryRequest) (*AnswerWebAppQueryResponse, error)
+	SendInvoice(request *SendInvoiceRequest) (*SendInvoiceResponse, error)
+	CreateInvoiceLink(request *CreateInvoiceLinkRequest) (*CreateInvoiceLinkResponse, error)
+	AnswerShippingQuery(request *AnswerShippingQueryRequest) (*AnswerShippingQueryResponse, error)
+	AnswerPreCheckoutQuery(request *AnswerPreCheckoutQueryRequest) (*AnswerPreCheckoutQueryResponse, error)
+	SetPassportDataErrors(request *SetPassportDataErrorsRequest) (*SetPassportDataErrorsResponse, error)
+	SendGame(request *SendGameRequest) (*SendGameResponse, error)
+	SetGameScore(request *SetGameScoreRequest) (*SetGameScoreResponse, error)
+	GetGameHighScores(request *GetGameHighScoresRequest) (*GetGameHighScoresResponse, error)
+}
+
+var _ TelegramApiInterface = (*TelegramApi)(nil)
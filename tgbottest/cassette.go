@@ -0,0 +1,234 @@
+package tgbottest
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lanseg/tgbot"
+)
+
+// CassetteEntry is a single recorded call, one json object per line of the cassette.
+type CassetteEntry struct {
+	Method     string          `json:"method"`
+	Request    json.RawMessage `json:"request"`
+	Response   json.RawMessage `json:"response,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Time       time.Time       `json:"time"`
+	DurationMs int64           `json:"duration_ms"`
+}
+
+// ReadCassette reads all entries of the JSONL cassette.
+func ReadCassette(r io.Reader) ([]*CassetteEntry, error) {
+	entries := []*CassetteEntry{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		entry := &CassetteEntry{}
+		if err := json.Unmarshal(scanner.Bytes(), entry); err != nil {
+			return nil, fmt.Errorf("Cannot parse cassette line %d: %s", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// Recorder appends the calls to a cassette. Failing to record a call does not change its result,
+// the call may have already changed the server state, so the recording errors are reported by Err.
+type Recorder struct {
+	// Logger logs the recording errors if set
+	Logger *slog.Logger
+
+	cassette io.Writer
+	mu       sync.Mutex
+	err      error
+}
+
+func NewRecorder(cassette io.Writer) *Recorder {
+	return &Recorder{cassette: cassette}
+}
+
+// Err returns the first recording error, nil if all the calls are in the cassette.
+func (r *Recorder) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *Recorder) record(ctx context.Context, entry *CassetteEntry, request interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	err := r.write(entry, request)
+	if err == nil {
+		return
+	}
+	if r.err == nil {
+		r.err = err
+	}
+	if r.Logger != nil {
+		r.Logger.WarnContext(ctx, "Cannot record call", slog.String("method", entry.Method),
+			slog.String("error", err.Error()))
+	}
+}
+
+func (r *Recorder) write(entry *CassetteEntry, request interface{}) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("Cannot record %s request: %s", entry.Method, err)
+	}
+	entry.Request = body
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("Cannot record %s: %s", entry.Method, err)
+	}
+	if _, err := r.cassette.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("Cannot write %s to the cassette: %s", entry.Method, err)
+	}
+	return nil
+}
+
+// Interceptor records every call and returns its original result.
+func (r *Recorder) Interceptor() tgbot.Interceptor {
+	return func(next tgbot.QueryFunc) tgbot.QueryFunc {
+		return func(ctx context.Context, apiMethod string, request interface{}) ([]byte, error) {
+			start := time.Now()
+			response, err := next(ctx, apiMethod, request)
+
+			entry := &CassetteEntry{
+				Method:     apiMethod,
+				Time:       start,
+				DurationMs: time.Since(start).Milliseconds(),
+			}
+			if json.Valid(response) {
+				entry.Response = response
+			}
+			if err != nil {
+				entry.Error = err.Error()
+			}
+			r.record(ctx, entry, request)
+			return response, err
+		}
+	}
+}
+
+// RecordingInterceptor appends every call to the cassette and logs the recording errors, use
+// NewRecorder to check them in a test.
+func RecordingInterceptor(cassette io.Writer) tgbot.Interceptor {
+	recorder := NewRecorder(cassette)
+	recorder.Logger = slog.Default()
+	return recorder.Interceptor()
+}
+
+// Record wraps the bot so all its calls are appended to the cassette. For bots created with
+// tgbot.NewCustomBot prefer tgbot.WithInterceptors(RecordingInterceptor(cassette)).
+func Record(bot tgbot.TelegramBot, cassette io.Writer) tgbot.TelegramBot {
+	return tgbot.Intercept(bot, RecordingInterceptor(cassette))
+}
+
+// ReplayMode defines how the calls are matched to the cassette entries.
+type ReplayMode int
+
+const (
+	// ReplayStrict expects the calls in exactly the recorded order
+	ReplayStrict ReplayMode = iota
+	// ReplayAnyOrder answers a call with the first unused entry with the same method and request
+	ReplayAnyOrder
+)
+
+// Replayer is a TelegramBot that answers the calls with the responses from a cassette.
+type Replayer struct {
+	mode    ReplayMode
+	mu      sync.Mutex
+	entries []*CassetteEntry
+	used    []bool
+	next    int
+}
+
+func NewReplayer(cassette io.Reader, mode ReplayMode) (*Replayer, error) {
+	entries, err := ReadCassette(cassette)
+	if err != nil {
+		return nil, err
+	}
+	return &Replayer{
+		mode:    mode,
+		entries: entries,
+		used:    make([]bool, len(entries)),
+	}, nil
+}
+
+func sameJSON(a []byte, b []byte) bool {
+	var aValue, bValue interface{}
+	if json.Unmarshal(a, &aValue) != nil || json.Unmarshal(b, &bValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+func (r *Replayer) matches(i int, apiMethod string, request []byte) bool {
+	entry := r.entries[i]
+	return !r.used[i] && strings.EqualFold(entry.Method, apiMethod) && sameJSON(entry.Request, request)
+}
+
+func (r *Replayer) find(apiMethod string, request []byte) (*CassetteEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.mode == ReplayStrict {
+		if r.next >= len(r.entries) {
+			return nil, fmt.Errorf("Unexpected call %s %s: cassette is over", apiMethod, request)
+		}
+		if !r.matches(r.next, apiMethod, request) {
+			expected := r.entries[r.next]
+			return nil, fmt.Errorf("Unexpected call %s %s, expected %s %s",
+				apiMethod, request, expected.Method, expected.Request)
+		}
+		r.used[r.next] = true
+		r.next++
+		return r.entries[r.next-1], nil
+	}
+	for i := range r.entries {
+		if r.matches(i, apiMethod, request) {
+			r.used[i] = true
+			return r.entries[i], nil
+		}
+	}
+	return nil, fmt.Errorf("No cassette entry for call %s %s", apiMethod, request)
+}
+
+func (r *Replayer) Query(apiMethod string, body interface{}) ([]byte, error) {
+	request, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := r.find(apiMethod, request)
+	if err != nil {
+		return nil, err
+	}
+	if entry.Error != "" {
+		return entry.Response, errors.New(entry.Error)
+	}
+	return entry.Response, nil
+}
+
+// Unused returns entries that were not replayed, empty if the session was fully repeated.
+func (r *Replayer) Unused() []*CassetteEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	result := []*CassetteEntry{}
+	for i, entry := range r.entries {
+		if !r.used[i] {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
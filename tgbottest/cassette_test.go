@@ -0,0 +1,148 @@
+package tgbottest_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/lanseg/tgbot"
+	"github.com/lanseg/tgbot/tgbottest"
+)
+
+// recordSession records a short session with the fake server into a cassette.
+func recordSession(t *testing.T) []byte {
+	t.Helper()
+	server := tgbottest.NewServer()
+	defer server.Close()
+	server.AddUser(testUser)
+	cassette := &bytes.Buffer{}
+	bot, err := tgbot.NewCustomBot(server.URL, server.Token,
+		tgbot.WithInterceptors(tgbottest.RecordingInterceptor(cassette)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := tgbot.NewTelegramApi(bot)
+	if _, err := api.GetMe(&tgbot.GetMeRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "100", Text: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "404", Text: "lost"}); err == nil {
+		t.Fatal("Message to the unknown chat is sent")
+	}
+	return cassette.Bytes()
+}
+
+func TestRecord(t *testing.T) {
+	entries, err := tgbottest.ReadCassette(bytes.NewReader(recordSession(t)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 3 {
+		t.Fatalf("Recorded %d entries", len(entries))
+	}
+	if entries[0].Method != "GetMe" || entries[1].Method != "SendMessage" || entries[0].Time.IsZero() {
+		t.Errorf("Recorded entries %+v %+v", entries[0], entries[1])
+	}
+	if !strings.Contains(string(entries[1].Request), `"text":"hello"`) ||
+		!strings.Contains(string(entries[1].Response), `"message_id":1`) {
+		t.Errorf("Recorded request %s and response %s", entries[1].Request, entries[1].Response)
+	}
+	if !strings.Contains(string(entries[2].Response), "chat not found") {
+		t.Errorf("Recorded error response %s", entries[2].Response)
+	}
+}
+
+type brokenWriter struct{}
+
+func (brokenWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk is full")
+}
+
+func TestRecorderErrors(t *testing.T) {
+	server := tgbottest.NewServer()
+	defer server.Close()
+	server.AddUser(testUser)
+	recorder := tgbottest.NewRecorder(brokenWriter{})
+	bot, err := tgbot.NewCustomBot(server.URL, server.Token, tgbot.WithInterceptors(recorder.Interceptor()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := tgbot.NewTelegramApi(bot)
+
+	// The message is sent even though it cannot be recorded.
+	sent, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "100", Text: "hello"})
+	if err != nil || sent.Result.Text != "hello" {
+		t.Errorf("Sent %v, %v", sent, err)
+	}
+	if _, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "404", Text: "lost"}); err == nil ||
+		!strings.Contains(err.Error(), "chat not found") {
+		t.Errorf("Got error %v, want the server error", err)
+	}
+	if err := recorder.Err(); err == nil || !strings.Contains(err.Error(), "disk is full") {
+		t.Errorf("Recorder error is %v", err)
+	}
+	if messages := server.Messages(100); len(messages) != 1 {
+		t.Errorf("Server has %d messages", len(messages))
+	}
+}
+
+func TestReplayStrict(t *testing.T) {
+	cassette := recordSession(t)
+	replayer, err := tgbottest.NewReplayer(bytes.NewReader(cassette), tgbottest.ReplayStrict)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := tgbot.NewTelegramApi(replayer)
+
+	if _, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "100", Text: "hello"}); err == nil {
+		t.Errorf("Call out of order is replayed")
+	}
+	if _, err := api.GetMe(&tgbot.GetMeRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	sent, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "100", Text: "hello"})
+	if err != nil || sent.Result.Text != "hello" {
+		t.Fatalf("Replayed %v, %v", sent, err)
+	}
+	if unused := replayer.Unused(); len(unused) != 1 {
+		t.Errorf("Unused entries %v", unused)
+	}
+	if _, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "404", Text: "lost"}); err == nil ||
+		!strings.Contains(err.Error(), "chat not found") {
+		t.Errorf("Replayed error %v", err)
+	}
+	if _, err := api.GetMe(&tgbot.GetMeRequest{}); err == nil {
+		t.Errorf("Call after the end of the cassette is replayed")
+	}
+}
+
+func TestReplayAnyOrder(t *testing.T) {
+	replayer, err := tgbottest.NewReplayer(bytes.NewReader(recordSession(t)), tgbottest.ReplayAnyOrder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	api := tgbot.NewTelegramApi(replayer)
+
+	if _, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "100", Text: "hello"}); err != nil {
+		t.Errorf("Call out of order is not replayed: %s", err)
+	}
+	if _, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "100", Text: "hello"}); err == nil {
+		t.Errorf("Entry is replayed twice")
+	}
+	if _, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "100", Text: "other"}); err == nil {
+		t.Errorf("Call with another request is replayed")
+	}
+	if unused := replayer.Unused(); len(unused) != 2 || unused[0].Method != "GetMe" {
+		t.Errorf("Unused entries %v", unused)
+	}
+}
+
+func TestReadCassetteErrors(t *testing.T) {
+	if _, err := tgbottest.ReadCassette(strings.NewReader("{\"method\":\"GetMe\"}\n\nnot json\n")); err == nil ||
+		!strings.Contains(err.Error(), "line 3") {
+		t.Errorf("ReadCassette error %v", err)
+	}
+}
@@ -0,0 +1,167 @@
+package tgbottest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/lanseg/tgbot"
+)
+
+// MockCall is a single recorded call of the MockApi.
+type MockCall struct {
+	Method  string
+	Request interface{}
+}
+
+// MockApi is a tgbot.TelegramApiInterface for unit tests: it records calls and returns stubbed
+// responses, empty responses without error if the method is not stubbed.
+//
+//	api := tgbottest.NewMockApi()
+//	api.OnSendMessage(func(r *tgbot.SendMessageRequest) (*tgbot.SendMessageResponse, error) {
+//		return &tgbot.SendMessageResponse{Result: &tgbot.Message{MessageID: 1}}, nil
+//	})
+//	... run the handler with api ...
+//	tgbottest.AssertCalled(t, api.SendMessageCalls(), func(r *tgbot.SendMessageRequest) bool {
+//		return r.ChatID == "42" && strings.Contains(r.Text, "Welcome")
+//	})
+type MockApi struct {
+	mu    sync.Mutex
+	calls []*MockCall
+	stubs map[string]func(request interface{}) (interface{}, error)
+}
+
+func NewMockApi() *MockApi {
+	return &MockApi{
+		stubs: map[string]func(request interface{}) (interface{}, error){},
+	}
+}
+
+// Stub sets the function that answers the method calls, prefer the typed On<Method> stubs.
+func (m *MockApi) Stub(method string, stub func(request interface{}) (interface{}, error)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stubs[method] = stub
+}
+
+// Fail makes all calls of the method return the error.
+func (m *MockApi) Fail(method string, err error) {
+	m.Stub(method, func(interface{}) (interface{}, error) {
+		return nil, err
+	})
+}
+
+// Calls returns all recorded calls in order.
+func (m *MockApi) Calls() []*MockCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]*MockCall{}, m.calls...)
+}
+
+// Reset forgets the recorded calls, stubs are kept.
+func (m *MockApi) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = nil
+}
+
+// WithContext returns the mock itself, the context is not recorded.
+func (m *MockApi) WithContext(context.Context) tgbot.TelegramApiInterface {
+	return m
+}
+
+// DownloadFile records the call with the file id as the request and returns the stubbed file.
+func (m *MockApi) DownloadFile(_ context.Context, fileID string) (*tgbot.FileContent, error) {
+	return mockCall[tgbot.FileContent](m, "DownloadFile", fileID)
+}
+
+// OnDownloadFile stubs the DownloadFile calls.
+func (m *MockApi) OnDownloadFile(stub func(fileID string) (*tgbot.FileContent, error)) {
+	m.Stub("DownloadFile", func(request interface{}) (interface{}, error) {
+		return stub(request.(string))
+	})
+}
+
+// DownloadFileCalls returns the file ids of all DownloadFile calls.
+func (m *MockApi) DownloadFileCalls() []string {
+	result := []string{}
+	for _, call := range m.Calls() {
+		if call.Method == "DownloadFile" {
+			result = append(result, call.Request.(string))
+		}
+	}
+	return result
+}
+
+func (m *MockApi) call(method string, request interface{}) (interface{}, error) {
+	m.mu.Lock()
+	m.calls = append(m.calls, &MockCall{Method: method, Request: request})
+	stub := m.stubs[method]
+	m.mu.Unlock()
+
+	if stub == nil {
+		return nil, nil
+	}
+	return stub(request)
+}
+
+func mockCall[T any](m *MockApi, method string, request interface{}) (*T, error) {
+	response, err := m.call(method, request)
+	if typed, ok := response.(*T); ok {
+		return typed, err
+	}
+	if err != nil {
+		return nil, err
+	}
+	return new(T), nil
+}
+
+func mockCalls[T any](m *MockApi, method string) []*T {
+	result := []*T{}
+	for _, call := range m.Calls() {
+		if call.Method == method {
+			result = append(result, call.Request.(*T))
+		}
+	}
+	return result
+}
+
+func describe[T any](calls []T) string {
+	result := ""
+	for i, call := range calls {
+		result += fmt.Sprintf("\n  %d: %+v", i, call)
+	}
+	return result
+}
+
+// AssertCalled checks that at least one of the calls matches and returns the first match.
+func AssertCalled[T any](t testing.TB, calls []T, match func(request T) bool) T {
+	t.Helper()
+	for _, call := range calls {
+		if match(call) {
+			return call
+		}
+	}
+	t.Errorf("No matching call among %d calls:%s", len(calls), describe(calls))
+	var empty T
+	return empty
+}
+
+// AssertNotCalled checks that none of the calls match.
+func AssertNotCalled[T any](t testing.TB, calls []T, match func(request T) bool) {
+	t.Helper()
+	for i, call := range calls {
+		if match(call) {
+			t.Errorf("Unexpected matching call %d: %+v", i, call)
+		}
+	}
+}
+
+// AssertCallCount checks the number of calls.
+func AssertCallCount[T any](t testing.TB, calls []T, count int) {
+	t.Helper()
+	if len(calls) != count {
+		t.Errorf("Expected %d calls, got %d:%s", count, len(calls), describe(calls))
+	}
+}
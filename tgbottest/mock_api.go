@@ -0,0 +1,2012 @@
+// Telegram bot API mock methods
+package tgbottest
+
+import "github.com/lanseg/tgbot"
+
+// GetUpdates records the call and returns the stubbed response
+func (m *MockApi) GetUpdates(request *tgbot.GetUpdatesRequest) (*tgbot.GetUpdatesResponse, error) {
+	return mockCall[tgbot.GetUpdatesResponse](m, "GetUpdates", request)
+}
+
+// OnGetUpdates stubs the GetUpdates calls
+func (m *MockApi) OnGetUpdates(stub func(request *tgbot.GetUpdatesRequest) (*tgbot.GetUpdatesResponse, error)) {
+	m.Stub("GetUpdates", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetUpdatesRequest))
+	})
+}
+
+// GetUpdatesCalls returns requests of all GetUpdates calls
+func (m *MockApi) GetUpdatesCalls() []*tgbot.GetUpdatesRequest {
+	return mockCalls[tgbot.GetUpdatesRequest](m, "GetUpdates")
+}
+
+// SetWebhook records the call and returns the stubbed response
+func (m *MockApi) SetWebhook(request *tgbot.SetWebhookRequest) (*tgbot.SetWebhookResponse, error) {
+	return mockCall[tgbot.SetWebhookResponse](m, "SetWebhook", request)
+}
+
+// OnSetWebhook stubs the SetWebhook calls
+func (m *MockApi) OnSetWebhook(stub func(request *tgbot.SetWebhookRequest) (*tgbot.SetWebhookResponse, error)) {
+	m.Stub("SetWebhook", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetWebhookRequest))
+	})
+}
+
+// SetWebhookCalls returns requests of all SetWebhook calls
+func (m *MockApi) SetWebhookCalls() []*tgbot.SetWebhookRequest {
+	return mockCalls[tgbot.SetWebhookRequest](m, "SetWebhook")
+}
+
+// DeleteWebhook records the call and returns the stubbed response
+func (m *MockApi) DeleteWebhook(request *tgbot.DeleteWebhookRequest) (*tgbot.DeleteWebhookResponse, error) {
+	return mockCall[tgbot.DeleteWebhookResponse](m, "DeleteWebhook", request)
+}
+
+// OnDeleteWebhook stubs the DeleteWebhook calls
+func (m *MockApi) OnDeleteWebhook(stub func(request *tgbot.DeleteWebhookRequest) (*tgbot.DeleteWebhookResponse, error)) {
+	m.Stub("DeleteWebhook", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.DeleteWebhookRequest))
+	})
+}
+
+// DeleteWebhookCalls returns requests of all DeleteWebhook calls
+func (m *MockApi) DeleteWebhookCalls() []*tgbot.DeleteWebhookRequest {
+	return mockCalls[tgbot.DeleteWebhookRequest](m, "DeleteWebhook")
+}
+
+// GetWebhookInfo records the call and returns the stubbed response
+func (m *MockApi) GetWebhookInfo(request *tgbot.GetWebhookInfoRequest) (*tgbot.GetWebhookInfoResponse, error) {
+	return mockCall[tgbot.GetWebhookInfoResponse](m, "GetWebhookInfo", request)
+}
+
+// OnGetWebhookInfo stubs the GetWebhookInfo calls
+func (m *MockApi) OnGetWebhookInfo(stub func(request *tgbot.GetWebhookInfoRequest) (*tgbot.GetWebhookInfoResponse, error)) {
+	m.Stub("GetWebhookInfo", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetWebhookInfoRequest))
+	})
+}
+
+// GetWebhookInfoCalls returns requests of all GetWebhookInfo calls
+func (m *MockApi) GetWebhookInfoCalls() []*tgbot.GetWebhookInfoRequest {
+	return mockCalls[tgbot.GetWebhookInfoRequest](m, "GetWebhookInfo")
+}
+
+// GetMe records the call and returns the stubbed response
+func (m *MockApi) GetMe(request *tgbot.GetMeRequest) (*tgbot.GetMeResponse, error) {
+	return mockCall[tgbot.GetMeResponse](m, "GetMe", request)
+}
+
+// OnGetMe stubs the GetMe calls
+func (m *MockApi) OnGetMe(stub func(request *tgbot.GetMeRequest) (*tgbot.GetMeResponse, error)) {
+	m.Stub("GetMe", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetMeRequest))
+	})
+}
+
+// GetMeCalls returns requests of all GetMe calls
+func (m *MockApi) GetMeCalls() []*tgbot.GetMeRequest {
+	return mockCalls[tgbot.GetMeRequest](m, "GetMe")
+}
+
+// LogOut records the call and returns the stubbed response
+func (m *MockApi) LogOut(request *tgbot.LogOutRequest) (*tgbot.LogOutResponse, error) {
+	return mockCall[tgbot.LogOutResponse](m, "LogOut", request)
+}
+
+// OnLogOut stubs the LogOut calls
+func (m *MockApi) OnLogOut(stub func(request *tgbot.LogOutRequest) (*tgbot.LogOutResponse, error)) {
+	m.Stub("LogOut", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.LogOutRequest))
+	})
+}
+
+// LogOutCalls returns requests of all LogOut calls
+func (m *MockApi) LogOutCalls() []*tgbot.LogOutRequest {
+	return mockCalls[tgbot.LogOutRequest](m, "LogOut")
+}
+
+// Close records the call and returns the stubbed response
+func (m *MockApi) Close(request *tgbot.CloseRequest) (*tgbot.CloseResponse, error) {
+	return mockCall[tgbot.CloseResponse](m, "Close", request)
+}
+
+// OnClose stubs the Close calls
+func (m *MockApi) OnClose(stub func(request *tgbot.CloseRequest) (*tgbot.CloseResponse, error)) {
+	m.Stub("Close", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.CloseRequest))
+	})
+}
+
+// CloseCalls returns requests of all Close calls
+func (m *MockApi) CloseCalls() []*tgbot.CloseRequest {
+	return mockCalls[tgbot.CloseRequest](m, "Close")
+}
+
+// SendMessage records the call and returns the stubbed response
+func (m *MockApi) SendMessage(request *tgbot.SendMessageRequest) (*tgbot.SendMessageResponse, error) {
+	return mockCall[tgbot.SendMessageResponse](m, "SendMessage", request)
+}
+
+// OnSendMessage stubs the SendMessage calls
+func (m *MockApi) OnSendMessage(stub func(request *tgbot.SendMessageRequest) (*tgbot.SendMessageResponse, error)) {
+	m.Stub("SendMessage", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendMessageRequest))
+	})
+}
+
+// SendMessageCalls returns requests of all SendMessage calls
+func (m *MockApi) SendMessageCalls() []*tgbot.SendMessageRequest {
+	return mockCalls[tgbot.SendMessageRequest](m, "SendMessage")
+}
+
+// ForwardMessage records the call and returns the stubbed response
+func (m *MockApi) ForwardMessage(request *tgbot.ForwardMessageRequest) (*tgbot.ForwardMessageResponse, error) {
+	return mockCall[tgbot.ForwardMessageResponse](m, "ForwardMessage", request)
+}
+
+// OnForwardMessage stubs the ForwardMessage calls
+func (m *MockApi) OnForwardMessage(stub func(request *tgbot.ForwardMessageRequest) (*tgbot.ForwardMessageResponse, error)) {
+	m.Stub("ForwardMessage", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.ForwardMessageRequest))
+	})
+}
+
+// ForwardMessageCalls returns requests of all ForwardMessage calls
+func (m *MockApi) ForwardMessageCalls() []*tgbot.ForwardMessageRequest {
+	return mockCalls[tgbot.ForwardMessageRequest](m, "ForwardMessage")
+}
+
+// ForwardMessages records the call and returns the stubbed response
+func (m *MockApi) ForwardMessages(request *tgbot.ForwardMessagesRequest) (*tgbot.ForwardMessagesResponse, error) {
+	return mockCall[tgbot.ForwardMessagesResponse](m, "ForwardMessages", request)
+}
+
+// OnForwardMessages stubs the ForwardMessages calls
+func (m *MockApi) OnForwardMessages(stub func(request *tgbot.ForwardMessagesRequest) (*tgbot.ForwardMessagesResponse, error)) {
+	m.Stub("ForwardMessages", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.ForwardMessagesRequest))
+	})
+}
+
+// ForwardMessagesCalls returns requests of all ForwardMessages calls
+func (m *MockApi) ForwardMessagesCalls() []*tgbot.ForwardMessagesRequest {
+	return mockCalls[tgbot.ForwardMessagesRequest](m, "ForwardMessages")
+}
+
+// CopyMessage records the call and returns the stubbed response
+func (m *MockApi) CopyMessage(request *tgbot.CopyMessageRequest) (*tgbot.CopyMessageResponse, error) {
+	return mockCall[tgbot.CopyMessageResponse](m, "CopyMessage", request)
+}
+
+// OnCopyMessage stubs the CopyMessage calls
+func (m *MockApi) OnCopyMessage(stub func(request *tgbot.CopyMessageRequest) (*tgbot.CopyMessageResponse, error)) {
+	m.Stub("CopyMessage", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.CopyMessageRequest))
+	})
+}
+
+// CopyMessageCalls returns requests of all CopyMessage calls
+func (m *MockApi) CopyMessageCalls() []*tgbot.CopyMessageRequest {
+	return mockCalls[tgbot.CopyMessageRequest](m, "CopyMessage")
+}
+
+// CopyMessages records the call and returns the stubbed response
+func (m *MockApi) CopyMessages(request *tgbot.CopyMessagesRequest) (*tgbot.CopyMessagesResponse, error) {
+	return mockCall[tgbot.CopyMessagesResponse](m, "CopyMessages", request)
+}
+
+// OnCopyMessages stubs the CopyMessages calls
+func (m *MockApi) OnCopyMessages(stub func(request *tgbot.CopyMessagesRequest) (*tgbot.CopyMessagesResponse, error)) {
+	m.Stub("CopyMessages", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.CopyMessagesRequest))
+	})
+}
+
+// CopyMessagesCalls returns requests of all CopyMessages calls
+func (m *MockApi) CopyMessagesCalls() []*tgbot.CopyMessagesRequest {
+	return mockCalls[tgbot.CopyMessagesRequest](m, "CopyMessages")
+}
+
+// SendPhoto records the call and returns the stubbed response
+func (m *MockApi) SendPhoto(request *tgbot.SendPhotoRequest) (*tgbot.SendPhotoResponse, error) {
+	return mockCall[tgbot.SendPhotoResponse](m, "SendPhoto", request)
+}
+
+// OnSendPhoto stubs the SendPhoto calls
+func (m *MockApi) OnSendPhoto(stub func(request *tgbot.SendPhotoRequest) (*tgbot.SendPhotoResponse, error)) {
+	m.Stub("SendPhoto", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendPhotoRequest))
+	})
+}
+
+// SendPhotoCalls returns requests of all SendPhoto calls
+func (m *MockApi) SendPhotoCalls() []*tgbot.SendPhotoRequest {
+	return mockCalls[tgbot.SendPhotoRequest](m, "SendPhoto")
+}
+
+// SendAudio records the call and returns the stubbed response
+func (m *MockApi) SendAudio(request *tgbot.SendAudioRequest) (*tgbot.SendAudioResponse, error) {
+	return mockCall[tgbot.SendAudioResponse](m, "SendAudio", request)
+}
+
+// OnSendAudio stubs the SendAudio calls
+func (m *MockApi) OnSendAudio(stub func(request *tgbot.SendAudioRequest) (*tgbot.SendAudioResponse, error)) {
+	m.Stub("SendAudio", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendAudioRequest))
+	})
+}
+
+// SendAudioCalls returns requests of all SendAudio calls
+func (m *MockApi) SendAudioCalls() []*tgbot.SendAudioRequest {
+	return mockCalls[tgbot.SendAudioRequest](m, "SendAudio")
+}
+
+// SendDocument records the call and returns the stubbed response
+func (m *MockApi) SendDocument(request *tgbot.SendDocumentRequest) (*tgbot.SendDocumentResponse, error) {
+	return mockCall[tgbot.SendDocumentResponse](m, "SendDocument", request)
+}
+
+// OnSendDocument stubs the SendDocument calls
+func (m *MockApi) OnSendDocument(stub func(request *tgbot.SendDocumentRequest) (*tgbot.SendDocumentResponse, error)) {
+	m.Stub("SendDocument", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendDocumentRequest))
+	})
+}
+
+// SendDocumentCalls returns requests of all SendDocument calls
+func (m *MockApi) SendDocumentCalls() []*tgbot.SendDocumentRequest {
+	return mockCalls[tgbot.SendDocumentRequest](m, "SendDocument")
+}
+
+// SendVideo records the call and returns the stubbed response
+func (m *MockApi) SendVideo(request *tgbot.SendVideoRequest) (*tgbot.SendVideoResponse, error) {
+	return mockCall[tgbot.SendVideoResponse](m, "SendVideo", request)
+}
+
+// OnSendVideo stubs the SendVideo calls
+func (m *MockApi) OnSendVideo(stub func(request *tgbot.SendVideoRequest) (*tgbot.SendVideoResponse, error)) {
+	m.Stub("SendVideo", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendVideoRequest))
+	})
+}
+
+// SendVideoCalls returns requests of all SendVideo calls
+func (m *MockApi) SendVideoCalls() []*tgbot.SendVideoRequest {
+	return mockCalls[tgbot.SendVideoRequest](m, "SendVideo")
+}
+
+// SendAnimation records the call and returns the stubbed response
+func (m *MockApi) SendAnimation(request *tgbot.SendAnimationRequest) (*tgbot.SendAnimationResponse, error) {
+	return mockCall[tgbot.SendAnimationResponse](m, "SendAnimation", request)
+}
+
+// OnSendAnimation stubs the SendAnimation calls
+func (m *MockApi) OnSendAnimation(stub func(request *tgbot.SendAnimationRequest) (*tgbot.SendAnimationResponse, error)) {
+	m.Stub("SendAnimation", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendAnimationRequest))
+	})
+}
+
+// SendAnimationCalls returns requests of all SendAnimation calls
+func (m *MockApi) SendAnimationCalls() []*tgbot.SendAnimationRequest {
+	return mockCalls[tgbot.SendAnimationRequest](m, "SendAnimation")
+}
+
+// SendVoice records the call and returns the stubbed response
+func (m *MockApi) SendVoice(request *tgbot.SendVoiceRequest) (*tgbot.SendVoiceResponse, error) {
+	return mockCall[tgbot.SendVoiceResponse](m, "SendVoice", request)
+}
+
+// OnSendVoice stubs the SendVoice calls
+func (m *MockApi) OnSendVoice(stub func(request *tgbot.SendVoiceRequest) (*tgbot.SendVoiceResponse, error)) {
+	m.Stub("SendVoice", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendVoiceRequest))
+	})
+}
+
+// SendVoiceCalls returns requests of all SendVoice calls
+func (m *MockApi) SendVoiceCalls() []*tgbot.SendVoiceRequest {
+	return mockCalls[tgbot.SendVoiceRequest](m, "SendVoice")
+}
+
+// SendVideoNote records the call and returns the stubbed response
+func (m *MockApi) SendVideoNote(request *tgbot.SendVideoNoteRequest) (*tgbot.SendVideoNoteResponse, error) {
+	return mockCall[tgbot.SendVideoNoteResponse](m, "SendVideoNote", request)
+}
+
+// OnSendVideoNote stubs the SendVideoNote calls
+func (m *MockApi) OnSendVideoNote(stub func(request *tgbot.SendVideoNoteRequest) (*tgbot.SendVideoNoteResponse, error)) {
+	m.Stub("SendVideoNote", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendVideoNoteRequest))
+	})
+}
+
+// SendVideoNoteCalls returns requests of all SendVideoNote calls
+func (m *MockApi) SendVideoNoteCalls() []*tgbot.SendVideoNoteRequest {
+	return mockCalls[tgbot.SendVideoNoteRequest](m, "SendVideoNote")
+}
+
+// SendLocation records the call and returns the stubbed response
+func (m *MockApi) SendLocation(request *tgbot.SendLocationRequest) (*tgbot.SendLocationResponse, error) {
+	return mockCall[tgbot.SendLocationResponse](m, "SendLocation", request)
+}
+
+// OnSendLocation stubs the SendLocation calls
+func (m *MockApi) OnSendLocation(stub func(request *tgbot.SendLocationRequest) (*tgbot.SendLocationResponse, error)) {
+	m.Stub("SendLocation", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendLocationRequest))
+	})
+}
+
+// SendLocationCalls returns requests of all SendLocation calls
+func (m *MockApi) SendLocationCalls() []*tgbot.SendLocationRequest {
+	return mockCalls[tgbot.SendLocationRequest](m, "SendLocation")
+}
+
+// SendVenue records the call and returns the stubbed response
+func (m *MockApi) SendVenue(request *tgbot.SendVenueRequest) (*tgbot.SendVenueResponse, error) {
+	return mockCall[tgbot.SendVenueResponse](m, "SendVenue", request)
+}
+
+// OnSendVenue stubs the SendVenue calls
+func (m *MockApi) OnSendVenue(stub func(request *tgbot.SendVenueRequest) (*tgbot.SendVenueResponse, error)) {
+	m.Stub("SendVenue", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendVenueRequest))
+	})
+}
+
+// SendVenueCalls returns requests of all SendVenue calls
+func (m *MockApi) SendVenueCalls() []*tgbot.SendVenueRequest {
+	return mockCalls[tgbot.SendVenueRequest](m, "SendVenue")
+}
+
+// SendContact records the call and returns the stubbed response
+func (m *MockApi) SendContact(request *tgbot.SendContactRequest) (*tgbot.SendContactResponse, error) {
+	return mockCall[tgbot.SendContactResponse](m, "SendContact", request)
+}
+
+// OnSendContact stubs the SendContact calls
+func (m *MockApi) OnSendContact(stub func(request *tgbot.SendContactRequest) (*tgbot.SendContactResponse, error)) {
+	m.Stub("SendContact", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendContactRequest))
+	})
+}
+
+// SendContactCalls returns requests of all SendContact calls
+func (m *MockApi) SendContactCalls() []*tgbot.SendContactRequest {
+	return mockCalls[tgbot.SendContactRequest](m, "SendContact")
+}
+
+// SendPoll records the call and returns the stubbed response
+func (m *MockApi) SendPoll(request *tgbot.SendPollRequest) (*tgbot.SendPollResponse, error) {
+	return mockCall[tgbot.SendPollResponse](m, "SendPoll", request)
+}
+
+// OnSendPoll stubs the SendPoll calls
+func (m *MockApi) OnSendPoll(stub func(request *tgbot.SendPollRequest) (*tgbot.SendPollResponse, error)) {
+	m.Stub("SendPoll", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendPollRequest))
+	})
+}
+
+// SendPollCalls returns requests of all SendPoll calls
+func (m *MockApi) SendPollCalls() []*tgbot.SendPollRequest {
+	return mockCalls[tgbot.SendPollRequest](m, "SendPoll")
+}
+
+// SendDice records the call and returns the stubbed response
+func (m *MockApi) SendDice(request *tgbot.SendDiceRequest) (*tgbot.SendDiceResponse, error) {
+	return mockCall[tgbot.SendDiceResponse](m, "SendDice", request)
+}
+
+// OnSendDice stubs the SendDice calls
+func (m *MockApi) OnSendDice(stub func(request *tgbot.SendDiceRequest) (*tgbot.SendDiceResponse, error)) {
+	m.Stub("SendDice", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendDiceRequest))
+	})
+}
+
+// SendDiceCalls returns requests of all SendDice calls
+func (m *MockApi) SendDiceCalls() []*tgbot.SendDiceRequest {
+	return mockCalls[tgbot.SendDiceRequest](m, "SendDice")
+}
+
+// SendChatAction records the call and returns the stubbed response
+func (m *MockApi) SendChatAction(request *tgbot.SendChatActionRequest) (*tgbot.SendChatActionResponse, error) {
+	return mockCall[tgbot.SendChatActionResponse](m, "SendChatAction", request)
+}
+
+// OnSendChatAction stubs the SendChatAction calls
+func (m *MockApi) OnSendChatAction(stub func(request *tgbot.SendChatActionRequest) (*tgbot.SendChatActionResponse, error)) {
+	m.Stub("SendChatAction", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendChatActionRequest))
+	})
+}
+
+// SendChatActionCalls returns requests of all SendChatAction calls
+func (m *MockApi) SendChatActionCalls() []*tgbot.SendChatActionRequest {
+	return mockCalls[tgbot.SendChatActionRequest](m, "SendChatAction")
+}
+
+// SetMessageReaction records the call and returns the stubbed response
+func (m *MockApi) SetMessageReaction(request *tgbot.SetMessageReactionRequest) (*tgbot.SetMessageReactionResponse, error) {
+	return mockCall[tgbot.SetMessageReactionResponse](m, "SetMessageReaction", request)
+}
+
+// OnSetMessageReaction stubs the SetMessageReaction calls
+func (m *MockApi) OnSetMessageReaction(stub func(request *tgbot.SetMessageReactionRequest) (*tgbot.SetMessageReactionResponse, error)) {
+	m.Stub("SetMessageReaction", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetMessageReactionRequest))
+	})
+}
+
+// SetMessageReactionCalls returns requests of all SetMessageReaction calls
+func (m *MockApi) SetMessageReactionCalls() []*tgbot.SetMessageReactionRequest {
+	return mockCalls[tgbot.SetMessageReactionRequest](m, "SetMessageReaction")
+}
+
+// GetUserProfilePhotos records the call and returns the stubbed response
+func (m *MockApi) GetUserProfilePhotos(request *tgbot.GetUserProfilePhotosRequest) (*tgbot.GetUserProfilePhotosResponse, error) {
+	return mockCall[tgbot.GetUserProfilePhotosResponse](m, "GetUserProfilePhotos", request)
+}
+
+// OnGetUserProfilePhotos stubs the GetUserProfilePhotos calls
+func (m *MockApi) OnGetUserProfilePhotos(stub func(request *tgbot.GetUserProfilePhotosRequest) (*tgbot.GetUserProfilePhotosResponse, error)) {
+	m.Stub("GetUserProfilePhotos", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetUserProfilePhotosRequest))
+	})
+}
+
+// GetUserProfilePhotosCalls returns requests of all GetUserProfilePhotos calls
+func (m *MockApi) GetUserProfilePhotosCalls() []*tgbot.GetUserProfilePhotosRequest {
+	return mockCalls[tgbot.GetUserProfilePhotosRequest](m, "GetUserProfilePhotos")
+}
+
+// GetFile records the call and returns the stubbed response
+func (m *MockApi) GetFile(request *tgbot.GetFileRequest) (*tgbot.GetFileResponse, error) {
+	return mockCall[tgbot.GetFileResponse](m, "GetFile", request)
+}
+
+// OnGetFile stubs the GetFile calls
+func (m *MockApi) OnGetFile(stub func(request *tgbot.GetFileRequest) (*tgbot.GetFileResponse, error)) {
+	m.Stub("GetFile", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetFileRequest))
+	})
+}
+
+// GetFileCalls returns requests of all GetFile calls
+func (m *MockApi) GetFileCalls() []*tgbot.GetFileRequest {
+	return mockCalls[tgbot.GetFileRequest](m, "GetFile")
+}
+
+// BanChatMember records the call and returns the stubbed response
+func (m *MockApi) BanChatMember(request *tgbot.BanChatMemberRequest) (*tgbot.BanChatMemberResponse, error) {
+	return mockCall[tgbot.BanChatMemberResponse](m, "BanChatMember", request)
+}
+
+// OnBanChatMember stubs the BanChatMember calls
+func (m *MockApi) OnBanChatMember(stub func(request *tgbot.BanChatMemberRequest) (*tgbot.BanChatMemberResponse, error)) {
+	m.Stub("BanChatMember", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.BanChatMemberRequest))
+	})
+}
+
+// BanChatMemberCalls returns requests of all BanChatMember calls
+func (m *MockApi) BanChatMemberCalls() []*tgbot.BanChatMemberRequest {
+	return mockCalls[tgbot.BanChatMemberRequest](m, "BanChatMember")
+}
+
+// UnbanChatMember records the call and returns the stubbed response
+func (m *MockApi) UnbanChatMember(request *tgbot.UnbanChatMemberRequest) (*tgbot.UnbanChatMemberResponse, error) {
+	return mockCall[tgbot.UnbanChatMemberResponse](m, "UnbanChatMember", request)
+}
+
+// OnUnbanChatMember stubs the UnbanChatMember calls
+func (m *MockApi) OnUnbanChatMember(stub func(request *tgbot.UnbanChatMemberRequest) (*tgbot.UnbanChatMemberResponse, error)) {
+	m.Stub("UnbanChatMember", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.UnbanChatMemberRequest))
+	})
+}
+
+// UnbanChatMemberCalls returns requests of all UnbanChatMember calls
+func (m *MockApi) UnbanChatMemberCalls() []*tgbot.UnbanChatMemberRequest {
+	return mockCalls[tgbot.UnbanChatMemberRequest](m, "UnbanChatMember")
+}
+
+// RestrictChatMember records the call and returns the stubbed response
+func (m *MockApi) RestrictChatMember(request *tgbot.RestrictChatMemberRequest) (*tgbot.RestrictChatMemberResponse, error) {
+	return mockCall[tgbot.RestrictChatMemberResponse](m, "RestrictChatMember", request)
+}
+
+// OnRestrictChatMember stubs the RestrictChatMember calls
+func (m *MockApi) OnRestrictChatMember(stub func(request *tgbot.RestrictChatMemberRequest) (*tgbot.RestrictChatMemberResponse, error)) {
+	m.Stub("RestrictChatMember", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.RestrictChatMemberRequest))
+	})
+}
+
+// RestrictChatMemberCalls returns requests of all RestrictChatMember calls
+func (m *MockApi) RestrictChatMemberCalls() []*tgbot.RestrictChatMemberRequest {
+	return mockCalls[tgbot.RestrictChatMemberRequest](m, "RestrictChatMember")
+}
+
+// PromoteChatMember records the call and returns the stubbed response
+func (m *MockApi) PromoteChatMember(request *tgbot.PromoteChatMemberRequest) (*tgbot.PromoteChatMemberResponse, error) {
+	return mockCall[tgbot.PromoteChatMemberResponse](m, "PromoteChatMember", request)
+}
+
+// OnPromoteChatMember stubs the PromoteChatMember calls
+func (m *MockApi) OnPromoteChatMember(stub func(request *tgbot.PromoteChatMemberRequest) (*tgbot.PromoteChatMemberResponse, error)) {
+	m.Stub("PromoteChatMember", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.PromoteChatMemberRequest))
+	})
+}
+
+// PromoteChatMemberCalls returns requests of all PromoteChatMember calls
+func (m *MockApi) PromoteChatMemberCalls() []*tgbot.PromoteChatMemberRequest {
+	return mockCalls[tgbot.PromoteChatMemberRequest](m, "PromoteChatMember")
+}
+
+// SetChatAdministratorCustomTitle records the call and returns the stubbed response
+func (m *MockApi) SetChatAdministratorCustomTitle(request *tgbot.SetChatAdministratorCustomTitleRequest) (*tgbot.SetChatAdministratorCustomTitleResponse, error) {
+	return mockCall[tgbot.SetChatAdministratorCustomTitleResponse](m, "SetChatAdministratorCustomTitle", request)
+}
+
+// OnSetChatAdministratorCustomTitle stubs the SetChatAdministratorCustomTitle calls
+func (m *MockApi) OnSetChatAdministratorCustomTitle(stub func(request *tgbot.SetChatAdministratorCustomTitleRequest) (*tgbot.SetChatAdministratorCustomTitleResponse, error)) {
+	m.Stub("SetChatAdministratorCustomTitle", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetChatAdministratorCustomTitleRequest))
+	})
+}
+
+// SetChatAdministratorCustomTitleCalls returns requests of all SetChatAdministratorCustomTitle calls
+func (m *MockApi) SetChatAdministratorCustomTitleCalls() []*tgbot.SetChatAdministratorCustomTitleRequest {
+	return mockCalls[tgbot.SetChatAdministratorCustomTitleRequest](m, "SetChatAdministratorCustomTitle")
+}
+
+// BanChatSenderChat records the call and returns the stubbed response
+func (m *MockApi) BanChatSenderChat(request *tgbot.BanChatSenderChatRequest) (*tgbot.BanChatSenderChatResponse, error) {
+	return mockCall[tgbot.BanChatSenderChatResponse](m, "BanChatSenderChat", request)
+}
+
+// OnBanChatSenderChat stubs the BanChatSenderChat calls
+func (m *MockApi) OnBanChatSenderChat(stub func(request *tgbot.BanChatSenderChatRequest) (*tgbot.BanChatSenderChatResponse, error)) {
+	m.Stub("BanChatSenderChat", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.BanChatSenderChatRequest))
+	})
+}
+
+// BanChatSenderChatCalls returns requests of all BanChatSenderChat calls
+func (m *MockApi) BanChatSenderChatCalls() []*tgbot.BanChatSenderChatRequest {
+	return mockCalls[tgbot.BanChatSenderChatRequest](m, "BanChatSenderChat")
+}
+
+// UnbanChatSenderChat records the call and returns the stubbed response
+func (m *MockApi) UnbanChatSenderChat(request *tgbot.UnbanChatSenderChatRequest) (*tgbot.UnbanChatSenderChatResponse, error) {
+	return mockCall[tgbot.UnbanChatSenderChatResponse](m, "UnbanChatSenderChat", request)
+}
+
+// OnUnbanChatSenderChat stubs the UnbanChatSenderChat calls
+func (m *MockApi) OnUnbanChatSenderChat(stub func(request *tgbot.UnbanChatSenderChatRequest) (*tgbot.UnbanChatSenderChatResponse, error)) {
+	m.Stub("UnbanChatSenderChat", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.UnbanChatSenderChatRequest))
+	})
+}
+
+// UnbanChatSenderChatCalls returns requests of all UnbanChatSenderChat calls
+func (m *MockApi) UnbanChatSenderChatCalls() []*tgbot.UnbanChatSenderChatRequest {
+	return mockCalls[tgbot.UnbanChatSenderChatRequest](m, "UnbanChatSenderChat")
+}
+
+// SetChatPermissions records the call and returns the stubbed response
+func (m *MockApi) SetChatPermissions(request *tgbot.SetChatPermissionsRequest) (*tgbot.SetChatPermissionsResponse, error) {
+	return mockCall[tgbot.SetChatPermissionsResponse](m, "SetChatPermissions", request)
+}
+
+// OnSetChatPermissions stubs the SetChatPermissions calls
+func (m *MockApi) OnSetChatPermissions(stub func(request *tgbot.SetChatPermissionsRequest) (*tgbot.SetChatPermissionsResponse, error)) {
+	m.Stub("SetChatPermissions", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetChatPermissionsRequest))
+	})
+}
+
+// SetChatPermissionsCalls returns requests of all SetChatPermissions calls
+func (m *MockApi) SetChatPermissionsCalls() []*tgbot.SetChatPermissionsRequest {
+	return mockCalls[tgbot.SetChatPermissionsRequest](m, "SetChatPermissions")
+}
+
+// ExportChatInviteLink records the call and returns the stubbed response
+func (m *MockApi) ExportChatInviteLink(request *tgbot.ExportChatInviteLinkRequest) (*tgbot.ExportChatInviteLinkResponse, error) {
+	return mockCall[tgbot.ExportChatInviteLinkResponse](m, "ExportChatInviteLink", request)
+}
+
+// OnExportChatInviteLink stubs the ExportChatInviteLink calls
+func (m *MockApi) OnExportChatInviteLink(stub func(request *tgbot.ExportChatInviteLinkRequest) (*tgbot.ExportChatInviteLinkResponse, error)) {
+	m.Stub("ExportChatInviteLink", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.ExportChatInviteLinkRequest))
+	})
+}
+
+// ExportChatInviteLinkCalls returns requests of all ExportChatInviteLink calls
+func (m *MockApi) ExportChatInviteLinkCalls() []*tgbot.ExportChatInviteLinkRequest {
+	return mockCalls[tgbot.ExportChatInviteLinkRequest](m, "ExportChatInviteLink")
+}
+
+// CreateChatInviteLink records the call and returns the stubbed response
+func (m *MockApi) CreateChatInviteLink(request *tgbot.CreateChatInviteLinkRequest) (*tgbot.CreateChatInviteLinkResponse, error) {
+	return mockCall[tgbot.CreateChatInviteLinkResponse](m, "CreateChatInviteLink", request)
+}
+
+// OnCreateChatInviteLink stubs the CreateChatInviteLink calls
+func (m *MockApi) OnCreateChatInviteLink(stub func(request *tgbot.CreateChatInviteLinkRequest) (*tgbot.CreateChatInviteLinkResponse, error)) {
+	m.Stub("CreateChatInviteLink", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.CreateChatInviteLinkRequest))
+	})
+}
+
+// CreateChatInviteLinkCalls returns requests of all CreateChatInviteLink calls
+func (m *MockApi) CreateChatInviteLinkCalls() []*tgbot.CreateChatInviteLinkRequest {
+	return mockCalls[tgbot.CreateChatInviteLinkRequest](m, "CreateChatInviteLink")
+}
+
+// EditChatInviteLink records the call and returns the stubbed response
+func (m *MockApi) EditChatInviteLink(request *tgbot.EditChatInviteLinkRequest) (*tgbot.EditChatInviteLinkResponse, error) {
+	return mockCall[tgbot.EditChatInviteLinkResponse](m, "EditChatInviteLink", request)
+}
+
+// OnEditChatInviteLink stubs the EditChatInviteLink calls
+func (m *MockApi) OnEditChatInviteLink(stub func(request *tgbot.EditChatInviteLinkRequest) (*tgbot.EditChatInviteLinkResponse, error)) {
+	m.Stub("EditChatInviteLink", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.EditChatInviteLinkRequest))
+	})
+}
+
+// EditChatInviteLinkCalls returns requests of all EditChatInviteLink calls
+func (m *MockApi) EditChatInviteLinkCalls() []*tgbot.EditChatInviteLinkRequest {
+	return mockCalls[tgbot.EditChatInviteLinkRequest](m, "EditChatInviteLink")
+}
+
+// RevokeChatInviteLink records the call and returns the stubbed response
+func (m *MockApi) RevokeChatInviteLink(request *tgbot.RevokeChatInviteLinkRequest) (*tgbot.RevokeChatInviteLinkResponse, error) {
+	return mockCall[tgbot.RevokeChatInviteLinkResponse](m, "RevokeChatInviteLink", request)
+}
+
+// OnRevokeChatInviteLink stubs the RevokeChatInviteLink calls
+func (m *MockApi) OnRevokeChatInviteLink(stub func(request *tgbot.RevokeChatInviteLinkRequest) (*tgbot.RevokeChatInviteLinkResponse, error)) {
+	m.Stub("RevokeChatInviteLink", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.RevokeChatInviteLinkRequest))
+	})
+}
+
+// RevokeChatInviteLinkCalls returns requests of all RevokeChatInviteLink calls
+func (m *MockApi) RevokeChatInviteLinkCalls() []*tgbot.RevokeChatInviteLinkRequest {
+	return mockCalls[tgbot.RevokeChatInviteLinkRequest](m, "RevokeChatInviteLink")
+}
+
+// ApproveChatJoinRequest records the call and returns the stubbed response
+func (m *MockApi) ApproveChatJoinRequest(request *tgbot.ApproveChatJoinRequestRequest) (*tgbot.ApproveChatJoinRequestResponse, error) {
+	return mockCall[tgbot.ApproveChatJoinRequestResponse](m, "ApproveChatJoinRequest", request)
+}
+
+// OnApproveChatJoinRequest stubs the ApproveChatJoinRequest calls
+func (m *MockApi) OnApproveChatJoinRequest(stub func(request *tgbot.ApproveChatJoinRequestRequest) (*tgbot.ApproveChatJoinRequestResponse, error)) {
+	m.Stub("ApproveChatJoinRequest", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.ApproveChatJoinRequestRequest))
+	})
+}
+
+// ApproveChatJoinRequestCalls returns requests of all ApproveChatJoinRequest calls
+func (m *MockApi) ApproveChatJoinRequestCalls() []*tgbot.ApproveChatJoinRequestRequest {
+	return mockCalls[tgbot.ApproveChatJoinRequestRequest](m, "ApproveChatJoinRequest")
+}
+
+// DeclineChatJoinRequest records the call and returns the stubbed response
+func (m *MockApi) DeclineChatJoinRequest(request *tgbot.DeclineChatJoinRequestRequest) (*tgbot.DeclineChatJoinRequestResponse, error) {
+	return mockCall[tgbot.DeclineChatJoinRequestResponse](m, "DeclineChatJoinRequest", request)
+}
+
+// OnDeclineChatJoinRequest stubs the DeclineChatJoinRequest calls
+func (m *MockApi) OnDeclineChatJoinRequest(stub func(request *tgbot.DeclineChatJoinRequestRequest) (*tgbot.DeclineChatJoinRequestResponse, error)) {
+	m.Stub("DeclineChatJoinRequest", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.DeclineChatJoinRequestRequest))
+	})
+}
+
+// DeclineChatJoinRequestCalls returns requests of all DeclineChatJoinRequest calls
+func (m *MockApi) DeclineChatJoinRequestCalls() []*tgbot.DeclineChatJoinRequestRequest {
+	return mockCalls[tgbot.DeclineChatJoinRequestRequest](m, "DeclineChatJoinRequest")
+}
+
+// SetChatPhoto records the call and returns the stubbed response
+func (m *MockApi) SetChatPhoto(request *tgbot.SetChatPhotoRequest) (*tgbot.SetChatPhotoResponse, error) {
+	return mockCall[tgbot.SetChatPhotoResponse](m, "SetChatPhoto", request)
+}
+
+// OnSetChatPhoto stubs the SetChatPhoto calls
+func (m *MockApi) OnSetChatPhoto(stub func(request *tgbot.SetChatPhotoRequest) (*tgbot.SetChatPhotoResponse, error)) {
+	m.Stub("SetChatPhoto", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetChatPhotoRequest))
+	})
+}
+
+// SetChatPhotoCalls returns requests of all SetChatPhoto calls
+func (m *MockApi) SetChatPhotoCalls() []*tgbot.SetChatPhotoRequest {
+	return mockCalls[tgbot.SetChatPhotoRequest](m, "SetChatPhoto")
+}
+
+// DeleteChatPhoto records the call and returns the stubbed response
+func (m *MockApi) DeleteChatPhoto(request *tgbot.DeleteChatPhotoRequest) (*tgbot.DeleteChatPhotoResponse, error) {
+	return mockCall[tgbot.DeleteChatPhotoResponse](m, "DeleteChatPhoto", request)
+}
+
+// OnDeleteChatPhoto stubs the DeleteChatPhoto calls
+func (m *MockApi) OnDeleteChatPhoto(stub func(request *tgbot.DeleteChatPhotoRequest) (*tgbot.DeleteChatPhotoResponse, error)) {
+	m.Stub("DeleteChatPhoto", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.DeleteChatPhotoRequest))
+	})
+}
+
+// DeleteChatPhotoCalls returns requests of all DeleteChatPhoto calls
+func (m *MockApi) DeleteChatPhotoCalls() []*tgbot.DeleteChatPhotoRequest {
+	return mockCalls[tgbot.DeleteChatPhotoRequest](m, "DeleteChatPhoto")
+}
+
+// SetChatTitle records the call and returns the stubbed response
+func (m *MockApi) SetChatTitle(request *tgbot.SetChatTitleRequest) (*tgbot.SetChatTitleResponse, error) {
+	return mockCall[tgbot.SetChatTitleResponse](m, "SetChatTitle", request)
+}
+
+// OnSetChatTitle stubs the SetChatTitle calls
+func (m *MockApi) OnSetChatTitle(stub func(request *tgbot.SetChatTitleRequest) (*tgbot.SetChatTitleResponse, error)) {
+	m.Stub("SetChatTitle", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetChatTitleRequest))
+	})
+}
+
+// SetChatTitleCalls returns requests of all SetChatTitle calls
+func (m *MockApi) SetChatTitleCalls() []*tgbot.SetChatTitleRequest {
+	return mockCalls[tgbot.SetChatTitleRequest](m, "SetChatTitle")
+}
+
+// SetChatDescription records the call and returns the stubbed response
+func (m *MockApi) SetChatDescription(request *tgbot.SetChatDescriptionRequest) (*tgbot.SetChatDescriptionResponse, error) {
+	return mockCall[tgbot.SetChatDescriptionResponse](m, "SetChatDescription", request)
+}
+
+// OnSetChatDescription stubs the SetChatDescription calls
+func (m *MockApi) OnSetChatDescription(stub func(request *tgbot.SetChatDescriptionRequest) (*tgbot.SetChatDescriptionResponse, error)) {
+	m.Stub("SetChatDescription", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetChatDescriptionRequest))
+	})
+}
+
+// SetChatDescriptionCalls returns requests of all SetChatDescription calls
+func (m *MockApi) SetChatDescriptionCalls() []*tgbot.SetChatDescriptionRequest {
+	return mockCalls[tgbot.SetChatDescriptionRequest](m, "SetChatDescription")
+}
+
+// PinChatMessage records the call and returns the stubbed response
+func (m *MockApi) PinChatMessage(request *tgbot.PinChatMessageRequest) (*tgbot.PinChatMessageResponse, error) {
+	return mockCall[tgbot.PinChatMessageResponse](m, "PinChatMessage", request)
+}
+
+// OnPinChatMessage stubs the PinChatMessage calls
+func (m *MockApi) OnPinChatMessage(stub func(request *tgbot.PinChatMessageRequest) (*tgbot.PinChatMessageResponse, error)) {
+	m.Stub("PinChatMessage", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.PinChatMessageRequest))
+	})
+}
+
+// PinChatMessageCalls returns requests of all PinChatMessage calls
+func (m *MockApi) PinChatMessageCalls() []*tgbot.PinChatMessageRequest {
+	return mockCalls[tgbot.PinChatMessageRequest](m, "PinChatMessage")
+}
+
+// UnpinChatMessage records the call and returns the stubbed response
+func (m *MockApi) UnpinChatMessage(request *tgbot.UnpinChatMessageRequest) (*tgbot.UnpinChatMessageResponse, error) {
+	return mockCall[tgbot.UnpinChatMessageResponse](m, "UnpinChatMessage", request)
+}
+
+// OnUnpinChatMessage stubs the UnpinChatMessage calls
+func (m *MockApi) OnUnpinChatMessage(stub func(request *tgbot.UnpinChatMessageRequest) (*tgbot.UnpinChatMessageResponse, error)) {
+	m.Stub("UnpinChatMessage", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.UnpinChatMessageRequest))
+	})
+}
+
+// UnpinChatMessageCalls returns requests of all UnpinChatMessage calls
+func (m *MockApi) UnpinChatMessageCalls() []*tgbot.UnpinChatMessageRequest {
+	return mockCalls[tgbot.UnpinChatMessageRequest](m, "UnpinChatMessage")
+}
+
+// UnpinAllChatMessages records the call and returns the stubbed response
+func (m *MockApi) UnpinAllChatMessages(request *tgbot.UnpinAllChatMessagesRequest) (*tgbot.UnpinAllChatMessagesResponse, error) {
+	return mockCall[tgbot.UnpinAllChatMessagesResponse](m, "UnpinAllChatMessages", request)
+}
+
+// OnUnpinAllChatMessages stubs the UnpinAllChatMessages calls
+func (m *MockApi) OnUnpinAllChatMessages(stub func(request *tgbot.UnpinAllChatMessagesRequest) (*tgbot.UnpinAllChatMessagesResponse, error)) {
+	m.Stub("UnpinAllChatMessages", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.UnpinAllChatMessagesRequest))
+	})
+}
+
+// UnpinAllChatMessagesCalls returns requests of all UnpinAllChatMessages calls
+func (m *MockApi) UnpinAllChatMessagesCalls() []*tgbot.UnpinAllChatMessagesRequest {
+	return mockCalls[tgbot.UnpinAllChatMessagesRequest](m, "UnpinAllChatMessages")
+}
+
+// LeaveChat records the call and returns the stubbed response
+func (m *MockApi) LeaveChat(request *tgbot.LeaveChatRequest) (*tgbot.LeaveChatResponse, error) {
+	return mockCall[tgbot.LeaveChatResponse](m, "LeaveChat", request)
+}
+
+// OnLeaveChat stubs the LeaveChat calls
+func (m *MockApi) OnLeaveChat(stub func(request *tgbot.LeaveChatRequest) (*tgbot.LeaveChatResponse, error)) {
+	m.Stub("LeaveChat", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.LeaveChatRequest))
+	})
+}
+
+// LeaveChatCalls returns requests of all LeaveChat calls
+func (m *MockApi) LeaveChatCalls() []*tgbot.LeaveChatRequest {
+	return mockCalls[tgbot.LeaveChatRequest](m, "LeaveChat")
+}
+
+// GetChat records the call and returns the stubbed response
+func (m *MockApi) GetChat(request *tgbot.GetChatRequest) (*tgbot.GetChatResponse, error) {
+	return mockCall[tgbot.GetChatResponse](m, "GetChat", request)
+}
+
+// OnGetChat stubs the GetChat calls
+func (m *MockApi) OnGetChat(stub func(request *tgbot.GetChatRequest) (*tgbot.GetChatResponse, error)) {
+	m.Stub("GetChat", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetChatRequest))
+	})
+}
+
+// GetChatCalls returns requests of all GetChat calls
+func (m *MockApi) GetChatCalls() []*tgbot.GetChatRequest {
+	return mockCalls[tgbot.GetChatRequest](m, "GetChat")
+}
+
+// GetChatAdministrators records the call and returns the stubbed response
+func (m *MockApi) GetChatAdministrators(request *tgbot.GetChatAdministratorsRequest) (*tgbot.GetChatAdministratorsResponse, error) {
+	return mockCall[tgbot.GetChatAdministratorsResponse](m, "GetChatAdministrators", request)
+}
+
+// OnGetChatAdministrators stubs the GetChatAdministrators calls
+func (m *MockApi) OnGetChatAdministrators(stub func(request *tgbot.GetChatAdministratorsRequest) (*tgbot.GetChatAdministratorsResponse, error)) {
+	m.Stub("GetChatAdministrators", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetChatAdministratorsRequest))
+	})
+}
+
+// GetChatAdministratorsCalls returns requests of all GetChatAdministrators calls
+func (m *MockApi) GetChatAdministratorsCalls() []*tgbot.GetChatAdministratorsRequest {
+	return mockCalls[tgbot.GetChatAdministratorsRequest](m, "GetChatAdministrators")
+}
+
+// GetChatMemberCount records the call and returns the stubbed response
+func (m *MockApi) GetChatMemberCount(request *tgbot.GetChatMemberCountRequest) (*tgbot.GetChatMemberCountResponse, error) {
+	return mockCall[tgbot.GetChatMemberCountResponse](m, "GetChatMemberCount", request)
+}
+
+// OnGetChatMemberCount stubs the GetChatMemberCount calls
+func (m *MockApi) OnGetChatMemberCount(stub func(request *tgbot.GetChatMemberCountRequest) (*tgbot.GetChatMemberCountResponse, error)) {
+	m.Stub("GetChatMemberCount", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetChatMemberCountRequest))
+	})
+}
+
+// GetChatMemberCountCalls returns requests of all GetChatMemberCount calls
+func (m *MockApi) GetChatMemberCountCalls() []*tgbot.GetChatMemberCountRequest {
+	return mockCalls[tgbot.GetChatMemberCountRequest](m, "GetChatMemberCount")
+}
+
+// GetChatMember records the call and returns the stubbed response
+func (m *MockApi) GetChatMember(request *tgbot.GetChatMemberRequest) (*tgbot.GetChatMemberResponse, error) {
+	return mockCall[tgbot.GetChatMemberResponse](m, "GetChatMember", request)
+}
+
+// OnGetChatMember stubs the GetChatMember calls
+func (m *MockApi) OnGetChatMember(stub func(request *tgbot.GetChatMemberRequest) (*tgbot.GetChatMemberResponse, error)) {
+	m.Stub("GetChatMember", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetChatMemberRequest))
+	})
+}
+
+// GetChatMemberCalls returns requests of all GetChatMember calls
+func (m *MockApi) GetChatMemberCalls() []*tgbot.GetChatMemberRequest {
+	return mockCalls[tgbot.GetChatMemberRequest](m, "GetChatMember")
+}
+
+// SetChatStickerSet records the call and returns the stubbed response
+func (m *MockApi) SetChatStickerSet(request *tgbot.SetChatStickerSetRequest) (*tgbot.SetChatStickerSetResponse, error) {
+	return mockCall[tgbot.SetChatStickerSetResponse](m, "SetChatStickerSet", request)
+}
+
+// OnSetChatStickerSet stubs the SetChatStickerSet calls
+func (m *MockApi) OnSetChatStickerSet(stub func(request *tgbot.SetChatStickerSetRequest) (*tgbot.SetChatStickerSetResponse, error)) {
+	m.Stub("SetChatStickerSet", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetChatStickerSetRequest))
+	})
+}
+
+// SetChatStickerSetCalls returns requests of all SetChatStickerSet calls
+func (m *MockApi) SetChatStickerSetCalls() []*tgbot.SetChatStickerSetRequest {
+	return mockCalls[tgbot.SetChatStickerSetRequest](m, "SetChatStickerSet")
+}
+
+// DeleteChatStickerSet records the call and returns the stubbed response
+func (m *MockApi) DeleteChatStickerSet(request *tgbot.DeleteChatStickerSetRequest) (*tgbot.DeleteChatStickerSetResponse, error) {
+	return mockCall[tgbot.DeleteChatStickerSetResponse](m, "DeleteChatStickerSet", request)
+}
+
+// OnDeleteChatStickerSet stubs the DeleteChatStickerSet calls
+func (m *MockApi) OnDeleteChatStickerSet(stub func(request *tgbot.DeleteChatStickerSetRequest) (*tgbot.DeleteChatStickerSetResponse, error)) {
+	m.Stub("DeleteChatStickerSet", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.DeleteChatStickerSetRequest))
+	})
+}
+
+// DeleteChatStickerSetCalls returns requests of all DeleteChatStickerSet calls
+func (m *MockApi) DeleteChatStickerSetCalls() []*tgbot.DeleteChatStickerSetRequest {
+	return mockCalls[tgbot.DeleteChatStickerSetRequest](m, "DeleteChatStickerSet")
+}
+
+// GetForumTopicIconStickers records the call and returns the stubbed response
+func (m *MockApi) GetForumTopicIconStickers(request *tgbot.GetForumTopicIconStickersRequest) (*tgbot.GetForumTopicIconStickersResponse, error) {
+	return mockCall[tgbot.GetForumTopicIconStickersResponse](m, "GetForumTopicIconStickers", request)
+}
+
+// OnGetForumTopicIconStickers stubs the GetForumTopicIconStickers calls
+func (m *MockApi) OnGetForumTopicIconStickers(stub func(request *tgbot.GetForumTopicIconStickersRequest) (*tgbot.GetForumTopicIconStickersResponse, error)) {
+	m.Stub("GetForumTopicIconStickers", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetForumTopicIconStickersRequest))
+	})
+}
+
+// GetForumTopicIconStickersCalls returns requests of all GetForumTopicIconStickers calls
+func (m *MockApi) GetForumTopicIconStickersCalls() []*tgbot.GetForumTopicIconStickersRequest {
+	return mockCalls[tgbot.GetForumTopicIconStickersRequest](m, "GetForumTopicIconStickers")
+}
+
+// CreateForumTopic records the call and returns the stubbed response
+func (m *MockApi) CreateForumTopic(request *tgbot.CreateForumTopicRequest) (*tgbot.CreateForumTopicResponse, error) {
+	return mockCall[tgbot.CreateForumTopicResponse](m, "CreateForumTopic", request)
+}
+
+// OnCreateForumTopic stubs the CreateForumTopic calls
+func (m *MockApi) OnCreateForumTopic(stub func(request *tgbot.CreateForumTopicRequest) (*tgbot.CreateForumTopicResponse, error)) {
+	m.Stub("CreateForumTopic", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.CreateForumTopicRequest))
+	})
+}
+
+// CreateForumTopicCalls returns requests of all CreateForumTopic calls
+func (m *MockApi) CreateForumTopicCalls() []*tgbot.CreateForumTopicRequest {
+	return mockCalls[tgbot.CreateForumTopicRequest](m, "CreateForumTopic")
+}
+
+// EditForumTopic records the call and returns the stubbed response
+func (m *MockApi) EditForumTopic(request *tgbot.EditForumTopicRequest) (*tgbot.EditForumTopicResponse, error) {
+	return mockCall[tgbot.EditForumTopicResponse](m, "EditForumTopic", request)
+}
+
+// OnEditForumTopic stubs the EditForumTopic calls
+func (m *MockApi) OnEditForumTopic(stub func(request *tgbot.EditForumTopicRequest) (*tgbot.EditForumTopicResponse, error)) {
+	m.Stub("EditForumTopic", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.EditForumTopicRequest))
+	})
+}
+
+// EditForumTopicCalls returns requests of all EditForumTopic calls
+func (m *MockApi) EditForumTopicCalls() []*tgbot.EditForumTopicRequest {
+	return mockCalls[tgbot.EditForumTopicRequest](m, "EditForumTopic")
+}
+
+// CloseForumTopic records the call and returns the stubbed response
+func (m *MockApi) CloseForumTopic(request *tgbot.CloseForumTopicRequest) (*tgbot.CloseForumTopicResponse, error) {
+	return mockCall[tgbot.CloseForumTopicResponse](m, "CloseForumTopic", request)
+}
+
+// OnCloseForumTopic stubs the CloseForumTopic calls
+func (m *MockApi) OnCloseForumTopic(stub func(request *tgbot.CloseForumTopicRequest) (*tgbot.CloseForumTopicResponse, error)) {
+	m.Stub("CloseForumTopic", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.CloseForumTopicRequest))
+	})
+}
+
+// CloseForumTopicCalls returns requests of all CloseForumTopic calls
+func (m *MockApi) CloseForumTopicCalls() []*tgbot.CloseForumTopicRequest {
+	return mockCalls[tgbot.CloseForumTopicRequest](m, "CloseForumTopic")
+}
+
+// ReopenForumTopic records the call and returns the stubbed response
+func (m *MockApi) ReopenForumTopic(request *tgbot.ReopenForumTopicRequest) (*tgbot.ReopenForumTopicResponse, error) {
+	return mockCall[tgbot.ReopenForumTopicResponse](m, "ReopenForumTopic", request)
+}
+
+// OnReopenForumTopic stubs the ReopenForumTopic calls
+func (m *MockApi) OnReopenForumTopic(stub func(request *tgbot.ReopenForumTopicRequest) (*tgbot.ReopenForumTopicResponse, error)) {
+	m.Stub("ReopenForumTopic", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.ReopenForumTopicRequest))
+	})
+}
+
+// ReopenForumTopicCalls returns requests of all ReopenForumTopic calls
+func (m *MockApi) ReopenForumTopicCalls() []*tgbot.ReopenForumTopicRequest {
+	return mockCalls[tgbot.ReopenForumTopicRequest](m, "ReopenForumTopic")
+}
+
+// DeleteForumTopic records the call and returns the stubbed response
+func (m *MockApi) DeleteForumTopic(request *tgbot.DeleteForumTopicRequest) (*tgbot.DeleteForumTopicResponse, error) {
+	return mockCall[tgbot.DeleteForumTopicResponse](m, "DeleteForumTopic", request)
+}
+
+// OnDeleteForumTopic stubs the DeleteForumTopic calls
+func (m *MockApi) OnDeleteForumTopic(stub func(request *tgbot.DeleteForumTopicRequest) (*tgbot.DeleteForumTopicResponse, error)) {
+	m.Stub("DeleteForumTopic", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.DeleteForumTopicRequest))
+	})
+}
+
+// DeleteForumTopicCalls returns requests of all DeleteForumTopic calls
+func (m *MockApi) DeleteForumTopicCalls() []*tgbot.DeleteForumTopicRequest {
+	return mockCalls[tgbot.DeleteForumTopicRequest](m, "DeleteForumTopic")
+}
+
+// UnpinAllForumTopicMessages records the call and returns the stubbed response
+func (m *MockApi) UnpinAllForumTopicMessages(request *tgbot.UnpinAllForumTopicMessagesRequest) (*tgbot.UnpinAllForumTopicMessagesResponse, error) {
+	return mockCall[tgbot.UnpinAllForumTopicMessagesResponse](m, "UnpinAllForumTopicMessages", request)
+}
+
+// OnUnpinAllForumTopicMessages stubs the UnpinAllForumTopicMessages calls
+func (m *MockApi) OnUnpinAllForumTopicMessages(stub func(request *tgbot.UnpinAllForumTopicMessagesRequest) (*tgbot.UnpinAllForumTopicMessagesResponse, error)) {
+	m.Stub("UnpinAllForumTopicMessages", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.UnpinAllForumTopicMessagesRequest))
+	})
+}
+
+// UnpinAllForumTopicMessagesCalls returns requests of all UnpinAllForumTopicMessages calls
+func (m *MockApi) UnpinAllForumTopicMessagesCalls() []*tgbot.UnpinAllForumTopicMessagesRequest {
+	return mockCalls[tgbot.UnpinAllForumTopicMessagesRequest](m, "UnpinAllForumTopicMessages")
+}
+
+// EditGeneralForumTopic records the call and returns the stubbed response
+func (m *MockApi) EditGeneralForumTopic(request *tgbot.EditGeneralForumTopicRequest) (*tgbot.EditGeneralForumTopicResponse, error) {
+	return mockCall[tgbot.EditGeneralForumTopicResponse](m, "EditGeneralForumTopic", request)
+}
+
+// OnEditGeneralForumTopic stubs the EditGeneralForumTopic calls
+func (m *MockApi) OnEditGeneralForumTopic(stub func(request *tgbot.EditGeneralForumTopicRequest) (*tgbot.EditGeneralForumTopicResponse, error)) {
+	m.Stub("EditGeneralForumTopic", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.EditGeneralForumTopicRequest))
+	})
+}
+
+// EditGeneralForumTopicCalls returns requests of all EditGeneralForumTopic calls
+func (m *MockApi) EditGeneralForumTopicCalls() []*tgbot.EditGeneralForumTopicRequest {
+	return mockCalls[tgbot.EditGeneralForumTopicRequest](m, "EditGeneralForumTopic")
+}
+
+// CloseGeneralForumTopic records the call and returns the stubbed response
+func (m *MockApi) CloseGeneralForumTopic(request *tgbot.CloseGeneralForumTopicRequest) (*tgbot.CloseGeneralForumTopicResponse, error) {
+	return mockCall[tgbot.CloseGeneralForumTopicResponse](m, "CloseGeneralForumTopic", request)
+}
+
+// OnCloseGeneralForumTopic stubs the CloseGeneralForumTopic calls
+func (m *MockApi) OnCloseGeneralForumTopic(stub func(request *tgbot.CloseGeneralForumTopicRequest) (*tgbot.CloseGeneralForumTopicResponse, error)) {
+	m.Stub("CloseGeneralForumTopic", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.CloseGeneralForumTopicRequest))
+	})
+}
+
+// CloseGeneralForumTopicCalls returns requests of all CloseGeneralForumTopic calls
+func (m *MockApi) CloseGeneralForumTopicCalls() []*tgbot.CloseGeneralForumTopicRequest {
+	return mockCalls[tgbot.CloseGeneralForumTopicRequest](m, "CloseGeneralForumTopic")
+}
+
+// ReopenGeneralForumTopic records the call and returns the stubbed response
+func (m *MockApi) ReopenGeneralForumTopic(request *tgbot.ReopenGeneralForumTopicRequest) (*tgbot.ReopenGeneralForumTopicResponse, error) {
+	return mockCall[tgbot.ReopenGeneralForumTopicResponse](m, "ReopenGeneralForumTopic", request)
+}
+
+// OnReopenGeneralForumTopic stubs the ReopenGeneralForumTopic calls
+func (m *MockApi) OnReopenGeneralForumTopic(stub func(request *tgbot.ReopenGeneralForumTopicRequest) (*tgbot.ReopenGeneralForumTopicResponse, error)) {
+	m.Stub("ReopenGeneralForumTopic", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.ReopenGeneralForumTopicRequest))
+	})
+}
+
+// ReopenGeneralForumTopicCalls returns requests of all ReopenGeneralForumTopic calls
+func (m *MockApi) ReopenGeneralForumTopicCalls() []*tgbot.ReopenGeneralForumTopicRequest {
+	return mockCalls[tgbot.ReopenGeneralForumTopicRequest](m, "ReopenGeneralForumTopic")
+}
+
+// HideGeneralForumTopic records the call and returns the stubbed response
+func (m *MockApi) HideGeneralForumTopic(request *tgbot.HideGeneralForumTopicRequest) (*tgbot.HideGeneralForumTopicResponse, error) {
+	return mockCall[tgbot.HideGeneralForumTopicResponse](m, "HideGeneralForumTopic", request)
+}
+
+// OnHideGeneralForumTopic stubs the HideGeneralForumTopic calls
+func (m *MockApi) OnHideGeneralForumTopic(stub func(request *tgbot.HideGeneralForumTopicRequest) (*tgbot.HideGeneralForumTopicResponse, error)) {
+	m.Stub("HideGeneralForumTopic", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.HideGeneralForumTopicRequest))
+	})
+}
+
+// HideGeneralForumTopicCalls returns requests of all HideGeneralForumTopic calls
+func (m *MockApi) HideGeneralForumTopicCalls() []*tgbot.HideGeneralForumTopicRequest {
+	return mockCalls[tgbot.HideGeneralForumTopicRequest](m, "HideGeneralForumTopic")
+}
+
+// UnhideGeneralForumTopic records the call and returns the stubbed response
+func (m *MockApi) UnhideGeneralForumTopic(request *tgbot.UnhideGeneralForumTopicRequest) (*tgbot.UnhideGeneralForumTopicResponse, error) {
+	return mockCall[tgbot.UnhideGeneralForumTopicResponse](m, "UnhideGeneralForumTopic", request)
+}
+
+// OnUnhideGeneralForumTopic stubs the UnhideGeneralForumTopic calls
+func (m *MockApi) OnUnhideGeneralForumTopic(stub func(request *tgbot.UnhideGeneralForumTopicRequest) (*tgbot.UnhideGeneralForumTopicResponse, error)) {
+	m.Stub("UnhideGeneralForumTopic", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.UnhideGeneralForumTopicRequest))
+	})
+}
+
+// UnhideGeneralForumTopicCalls returns requests of all UnhideGeneralForumTopic calls
+func (m *MockApi) UnhideGeneralForumTopicCalls() []*tgbot.UnhideGeneralForumTopicRequest {
+	return mockCalls[tgbot.UnhideGeneralForumTopicRequest](m, "UnhideGeneralForumTopic")
+}
+
+// UnpinAllGeneralForumTopicMessages records the call and returns the stubbed response
+func (m *MockApi) UnpinAllGeneralForumTopicMessages(request *tgbot.UnpinAllGeneralForumTopicMessagesRequest) (*tgbot.UnpinAllGeneralForumTopicMessagesResponse, error) {
+	return mockCall[tgbot.UnpinAllGeneralForumTopicMessagesResponse](m, "UnpinAllGeneralForumTopicMessages", request)
+}
+
+// OnUnpinAllGeneralForumTopicMessages stubs the UnpinAllGeneralForumTopicMessages calls
+func (m *MockApi) OnUnpinAllGeneralForumTopicMessages(stub func(request *tgbot.UnpinAllGeneralForumTopicMessagesRequest) (*tgbot.UnpinAllGeneralForumTopicMessagesResponse, error)) {
+	m.Stub("UnpinAllGeneralForumTopicMessages", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.UnpinAllGeneralForumTopicMessagesRequest))
+	})
+}
+
+// UnpinAllGeneralForumTopicMessagesCalls returns requests of all UnpinAllGeneralForumTopicMessages calls
+func (m *MockApi) UnpinAllGeneralForumTopicMessagesCalls() []*tgbot.UnpinAllGeneralForumTopicMessagesRequest {
+	return mockCalls[tgbot.UnpinAllGeneralForumTopicMessagesRequest](m, "UnpinAllGeneralForumTopicMessages")
+}
+
+// AnswerCallbackQuery records the call and returns the stubbed response
+func (m *MockApi) AnswerCallbackQuery(request *tgbot.AnswerCallbackQueryRequest) (*tgbot.AnswerCallbackQueryResponse, error) {
+	return mockCall[tgbot.AnswerCallbackQueryResponse](m, "AnswerCallbackQuery", request)
+}
+
+// OnAnswerCallbackQuery stubs the AnswerCallbackQuery calls
+func (m *MockApi) OnAnswerCallbackQuery(stub func(request *tgbot.AnswerCallbackQueryRequest) (*tgbot.AnswerCallbackQueryResponse, error)) {
+	m.Stub("AnswerCallbackQuery", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.AnswerCallbackQueryRequest))
+	})
+}
+
+// AnswerCallbackQueryCalls returns requests of all AnswerCallbackQuery calls
+func (m *MockApi) AnswerCallbackQueryCalls() []*tgbot.AnswerCallbackQueryRequest {
+	return mockCalls[tgbot.AnswerCallbackQueryRequest](m, "AnswerCallbackQuery")
+}
+
+// GetUserChatBoosts records the call and returns the stubbed response
+func (m *MockApi) GetUserChatBoosts(request *tgbot.GetUserChatBoostsRequest) (*tgbot.GetUserChatBoostsResponse, error) {
+	return mockCall[tgbot.GetUserChatBoostsResponse](m, "GetUserChatBoosts", request)
+}
+
+// OnGetUserChatBoosts stubs the GetUserChatBoosts calls
+func (m *MockApi) OnGetUserChatBoosts(stub func(request *tgbot.GetUserChatBoostsRequest) (*tgbot.GetUserChatBoostsResponse, error)) {
+	m.Stub("GetUserChatBoosts", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetUserChatBoostsRequest))
+	})
+}
+
+// GetUserChatBoostsCalls returns requests of all GetUserChatBoosts calls
+func (m *MockApi) GetUserChatBoostsCalls() []*tgbot.GetUserChatBoostsRequest {
+	return mockCalls[tgbot.GetUserChatBoostsRequest](m, "GetUserChatBoosts")
+}
+
+// SetMyCommands records the call and returns the stubbed response
+func (m *MockApi) SetMyCommands(request *tgbot.SetMyCommandsRequest) (*tgbot.SetMyCommandsResponse, error) {
+	return mockCall[tgbot.SetMyCommandsResponse](m, "SetMyCommands", request)
+}
+
+// OnSetMyCommands stubs the SetMyCommands calls
+func (m *MockApi) OnSetMyCommands(stub func(request *tgbot.SetMyCommandsRequest) (*tgbot.SetMyCommandsResponse, error)) {
+	m.Stub("SetMyCommands", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetMyCommandsRequest))
+	})
+}
+
+// SetMyCommandsCalls returns requests of all SetMyCommands calls
+func (m *MockApi) SetMyCommandsCalls() []*tgbot.SetMyCommandsRequest {
+	return mockCalls[tgbot.SetMyCommandsRequest](m, "SetMyCommands")
+}
+
+// DeleteMyCommands records the call and returns the stubbed response
+func (m *MockApi) DeleteMyCommands(request *tgbot.DeleteMyCommandsRequest) (*tgbot.DeleteMyCommandsResponse, error) {
+	return mockCall[tgbot.DeleteMyCommandsResponse](m, "DeleteMyCommands", request)
+}
+
+// OnDeleteMyCommands stubs the DeleteMyCommands calls
+func (m *MockApi) OnDeleteMyCommands(stub func(request *tgbot.DeleteMyCommandsRequest) (*tgbot.DeleteMyCommandsResponse, error)) {
+	m.Stub("DeleteMyCommands", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.DeleteMyCommandsRequest))
+	})
+}
+
+// DeleteMyCommandsCalls returns requests of all DeleteMyCommands calls
+func (m *MockApi) DeleteMyCommandsCalls() []*tgbot.DeleteMyCommandsRequest {
+	return mockCalls[tgbot.DeleteMyCommandsRequest](m, "DeleteMyCommands")
+}
+
+// GetMyCommands records the call and returns the stubbed response
+func (m *MockApi) GetMyCommands(request *tgbot.GetMyCommandsRequest) (*tgbot.GetMyCommandsResponse, error) {
+	return mockCall[tgbot.GetMyCommandsResponse](m, "GetMyCommands", request)
+}
+
+// OnGetMyCommands stubs the GetMyCommands calls
+func (m *MockApi) OnGetMyCommands(stub func(request *tgbot.GetMyCommandsRequest) (*tgbot.GetMyCommandsResponse, error)) {
+	m.Stub("GetMyCommands", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetMyCommandsRequest))
+	})
+}
+
+// GetMyCommandsCalls returns requests of all GetMyCommands calls
+func (m *MockApi) GetMyCommandsCalls() []*tgbot.GetMyCommandsRequest {
+	return mockCalls[tgbot.GetMyCommandsRequest](m, "GetMyCommands")
+}
+
+// SetMyName records the call and returns the stubbed response
+func (m *MockApi) SetMyName(request *tgbot.SetMyNameRequest) (*tgbot.SetMyNameResponse, error) {
+	return mockCall[tgbot.SetMyNameResponse](m, "SetMyName", request)
+}
+
+// OnSetMyName stubs the SetMyName calls
+func (m *MockApi) OnSetMyName(stub func(request *tgbot.SetMyNameRequest) (*tgbot.SetMyNameResponse, error)) {
+	m.Stub("SetMyName", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetMyNameRequest))
+	})
+}
+
+// SetMyNameCalls returns requests of all SetMyName calls
+func (m *MockApi) SetMyNameCalls() []*tgbot.SetMyNameRequest {
+	return mockCalls[tgbot.SetMyNameRequest](m, "SetMyName")
+}
+
+// GetMyName records the call and returns the stubbed response
+func (m *MockApi) GetMyName(request *tgbot.GetMyNameRequest) (*tgbot.GetMyNameResponse, error) {
+	return mockCall[tgbot.GetMyNameResponse](m, "GetMyName", request)
+}
+
+// OnGetMyName stubs the GetMyName calls
+func (m *MockApi) OnGetMyName(stub func(request *tgbot.GetMyNameRequest) (*tgbot.GetMyNameResponse, error)) {
+	m.Stub("GetMyName", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetMyNameRequest))
+	})
+}
+
+// GetMyNameCalls returns requests of all GetMyName calls
+func (m *MockApi) GetMyNameCalls() []*tgbot.GetMyNameRequest {
+	return mockCalls[tgbot.GetMyNameRequest](m, "GetMyName")
+}
+
+// SetMyDescription records the call and returns the stubbed response
+func (m *MockApi) SetMyDescription(request *tgbot.SetMyDescriptionRequest) (*tgbot.SetMyDescriptionResponse, error) {
+	return mockCall[tgbot.SetMyDescriptionResponse](m, "SetMyDescription", request)
+}
+
+// OnSetMyDescription stubs the SetMyDescription calls
+func (m *MockApi) OnSetMyDescription(stub func(request *tgbot.SetMyDescriptionRequest) (*tgbot.SetMyDescriptionResponse, error)) {
+	m.Stub("SetMyDescription", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetMyDescriptionRequest))
+	})
+}
+
+// SetMyDescriptionCalls returns requests of all SetMyDescription calls
+func (m *MockApi) SetMyDescriptionCalls() []*tgbot.SetMyDescriptionRequest {
+	return mockCalls[tgbot.SetMyDescriptionRequest](m, "SetMyDescription")
+}
+
+// GetMyDescription records the call and returns the stubbed response
+func (m *MockApi) GetMyDescription(request *tgbot.GetMyDescriptionRequest) (*tgbot.GetMyDescriptionResponse, error) {
+	return mockCall[tgbot.GetMyDescriptionResponse](m, "GetMyDescription", request)
+}
+
+// OnGetMyDescription stubs the GetMyDescription calls
+func (m *MockApi) OnGetMyDescription(stub func(request *tgbot.GetMyDescriptionRequest) (*tgbot.GetMyDescriptionResponse, error)) {
+	m.Stub("GetMyDescription", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetMyDescriptionRequest))
+	})
+}
+
+// GetMyDescriptionCalls returns requests of all GetMyDescription calls
+func (m *MockApi) GetMyDescriptionCalls() []*tgbot.GetMyDescriptionRequest {
+	return mockCalls[tgbot.GetMyDescriptionRequest](m, "GetMyDescription")
+}
+
+// SetMyShortDescription records the call and returns the stubbed response
+func (m *MockApi) SetMyShortDescription(request *tgbot.SetMyShortDescriptionRequest) (*tgbot.SetMyShortDescriptionResponse, error) {
+	return mockCall[tgbot.SetMyShortDescriptionResponse](m, "SetMyShortDescription", request)
+}
+
+// OnSetMyShortDescription stubs the SetMyShortDescription calls
+func (m *MockApi) OnSetMyShortDescription(stub func(request *tgbot.SetMyShortDescriptionRequest) (*tgbot.SetMyShortDescriptionResponse, error)) {
+	m.Stub("SetMyShortDescription", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetMyShortDescriptionRequest))
+	})
+}
+
+// SetMyShortDescriptionCalls returns requests of all SetMyShortDescription calls
+func (m *MockApi) SetMyShortDescriptionCalls() []*tgbot.SetMyShortDescriptionRequest {
+	return mockCalls[tgbot.SetMyShortDescriptionRequest](m, "SetMyShortDescription")
+}
+
+// GetMyShortDescription records the call and returns the stubbed response
+func (m *MockApi) GetMyShortDescription(request *tgbot.GetMyShortDescriptionRequest) (*tgbot.GetMyShortDescriptionResponse, error) {
+	return mockCall[tgbot.GetMyShortDescriptionResponse](m, "GetMyShortDescription", request)
+}
+
+// OnGetMyShortDescription stubs the GetMyShortDescription calls
+func (m *MockApi) OnGetMyShortDescription(stub func(request *tgbot.GetMyShortDescriptionRequest) (*tgbot.GetMyShortDescriptionResponse, error)) {
+	m.Stub("GetMyShortDescription", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetMyShortDescriptionRequest))
+	})
+}
+
+// GetMyShortDescriptionCalls returns requests of all GetMyShortDescription calls
+func (m *MockApi) GetMyShortDescriptionCalls() []*tgbot.GetMyShortDescriptionRequest {
+	return mockCalls[tgbot.GetMyShortDescriptionRequest](m, "GetMyShortDescription")
+}
+
+// SetChatMenuButton records the call and returns the stubbed response
+func (m *MockApi) SetChatMenuButton(request *tgbot.SetChatMenuButtonRequest) (*tgbot.SetChatMenuButtonResponse, error) {
+	return mockCall[tgbot.SetChatMenuButtonResponse](m, "SetChatMenuButton", request)
+}
+
+// OnSetChatMenuButton stubs the SetChatMenuButton calls
+func (m *MockApi) OnSetChatMenuButton(stub func(request *tgbot.SetChatMenuButtonRequest) (*tgbot.SetChatMenuButtonResponse, error)) {
+	m.Stub("SetChatMenuButton", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetChatMenuButtonRequest))
+	})
+}
+
+// SetChatMenuButtonCalls returns requests of all SetChatMenuButton calls
+func (m *MockApi) SetChatMenuButtonCalls() []*tgbot.SetChatMenuButtonRequest {
+	return mockCalls[tgbot.SetChatMenuButtonRequest](m, "SetChatMenuButton")
+}
+
+// GetChatMenuButton records the call and returns the stubbed response
+func (m *MockApi) GetChatMenuButton(request *tgbot.GetChatMenuButtonRequest) (*tgbot.GetChatMenuButtonResponse, error) {
+	return mockCall[tgbot.GetChatMenuButtonResponse](m, "GetChatMenuButton", request)
+}
+
+// OnGetChatMenuButton stubs the GetChatMenuButton calls
+func (m *MockApi) OnGetChatMenuButton(stub func(request *tgbot.GetChatMenuButtonRequest) (*tgbot.GetChatMenuButtonResponse, error)) {
+	m.Stub("GetChatMenuButton", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetChatMenuButtonRequest))
+	})
+}
+
+// GetChatMenuButtonCalls returns requests of all GetChatMenuButton calls
+func (m *MockApi) GetChatMenuButtonCalls() []*tgbot.GetChatMenuButtonRequest {
+	return mockCalls[tgbot.GetChatMenuButtonRequest](m, "GetChatMenuButton")
+}
+
+// SetMyDefaultAdministratorRights records the call and returns the stubbed response
+func (m *MockApi) SetMyDefaultAdministratorRights(request *tgbot.SetMyDefaultAdministratorRightsRequest) (*tgbot.SetMyDefaultAdministratorRightsResponse, error) {
+	return mockCall[tgbot.SetMyDefaultAdministratorRightsResponse](m, "SetMyDefaultAdministratorRights", request)
+}
+
+// OnSetMyDefaultAdministratorRights stubs the SetMyDefaultAdministratorRights calls
+func (m *MockApi) OnSetMyDefaultAdministratorRights(stub func(request *tgbot.SetMyDefaultAdministratorRightsRequest) (*tgbot.SetMyDefaultAdministratorRightsResponse, error)) {
+	m.Stub("SetMyDefaultAdministratorRights", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetMyDefaultAdministratorRightsRequest))
+	})
+}
+
+// SetMyDefaultAdministratorRightsCalls returns requests of all SetMyDefaultAdministratorRights calls
+func (m *MockApi) SetMyDefaultAdministratorRightsCalls() []*tgbot.SetMyDefaultAdministratorRightsRequest {
+	return mockCalls[tgbot.SetMyDefaultAdministratorRightsRequest](m, "SetMyDefaultAdministratorRights")
+}
+
+// GetMyDefaultAdministratorRights records the call and returns the stubbed response
+func (m *MockApi) GetMyDefaultAdministratorRights(request *tgbot.GetMyDefaultAdministratorRightsRequest) (*tgbot.GetMyDefaultAdministratorRightsResponse, error) {
+	return mockCall[tgbot.GetMyDefaultAdministratorRightsResponse](m, "GetMyDefaultAdministratorRights", request)
+}
+
+// OnGetMyDefaultAdministratorRights stubs the GetMyDefaultAdministratorRights calls
+func (m *MockApi) OnGetMyDefaultAdministratorRights(stub func(request *tgbot.GetMyDefaultAdministratorRightsRequest) (*tgbot.GetMyDefaultAdministratorRightsResponse, error)) {
+	m.Stub("GetMyDefaultAdministratorRights", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetMyDefaultAdministratorRightsRequest))
+	})
+}
+
+// GetMyDefaultAdministratorRightsCalls returns requests of all GetMyDefaultAdministratorRights calls
+func (m *MockApi) GetMyDefaultAdministratorRightsCalls() []*tgbot.GetMyDefaultAdministratorRightsRequest {
+	return mockCalls[tgbot.GetMyDefaultAdministratorRightsRequest](m, "GetMyDefaultAdministratorRights")
+}
+
+// EditMessageText records the call and returns the stubbed response
+func (m *MockApi) EditMessageText(request *tgbot.EditMessageTextRequest) (*tgbot.EditMessageTextResponse, error) {
+	return mockCall[tgbot.EditMessageTextResponse](m, "EditMessageText", request)
+}
+
+// OnEditMessageText stubs the EditMessageText calls
+func (m *MockApi) OnEditMessageText(stub func(request *tgbot.EditMessageTextRequest) (*tgbot.EditMessageTextResponse, error)) {
+	m.Stub("EditMessageText", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.EditMessageTextRequest))
+	})
+}
+
+// EditMessageTextCalls returns requests of all EditMessageText calls
+func (m *MockApi) EditMessageTextCalls() []*tgbot.EditMessageTextRequest {
+	return mockCalls[tgbot.EditMessageTextRequest](m, "EditMessageText")
+}
+
+// EditMessageCaption records the call and returns the stubbed response
+func (m *MockApi) EditMessageCaption(request *tgbot.EditMessageCaptionRequest) (*tgbot.EditMessageCaptionResponse, error) {
+	return mockCall[tgbot.EditMessageCaptionResponse](m, "EditMessageCaption", request)
+}
+
+// OnEditMessageCaption stubs the EditMessageCaption calls
+func (m *MockApi) OnEditMessageCaption(stub func(request *tgbot.EditMessageCaptionRequest) (*tgbot.EditMessageCaptionResponse, error)) {
+	m.Stub("EditMessageCaption", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.EditMessageCaptionRequest))
+	})
+}
+
+// EditMessageCaptionCalls returns requests of all EditMessageCaption calls
+func (m *MockApi) EditMessageCaptionCalls() []*tgbot.EditMessageCaptionRequest {
+	return mockCalls[tgbot.EditMessageCaptionRequest](m, "EditMessageCaption")
+}
+
+// EditMessageMedia records the call and returns the stubbed response
+func (m *MockApi) EditMessageMedia(request *tgbot.EditMessageMediaRequest) (*tgbot.EditMessageMediaResponse, error) {
+	return mockCall[tgbot.EditMessageMediaResponse](m, "EditMessageMedia", request)
+}
+
+// OnEditMessageMedia stubs the EditMessageMedia calls
+func (m *MockApi) OnEditMessageMedia(stub func(request *tgbot.EditMessageMediaRequest) (*tgbot.EditMessageMediaResponse, error)) {
+	m.Stub("EditMessageMedia", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.EditMessageMediaRequest))
+	})
+}
+
+// EditMessageMediaCalls returns requests of all EditMessageMedia calls
+func (m *MockApi) EditMessageMediaCalls() []*tgbot.EditMessageMediaRequest {
+	return mockCalls[tgbot.EditMessageMediaRequest](m, "EditMessageMedia")
+}
+
+// EditMessageLiveLocation records the call and returns the stubbed response
+func (m *MockApi) EditMessageLiveLocation(request *tgbot.EditMessageLiveLocationRequest) (*tgbot.EditMessageLiveLocationResponse, error) {
+	return mockCall[tgbot.EditMessageLiveLocationResponse](m, "EditMessageLiveLocation", request)
+}
+
+// OnEditMessageLiveLocation stubs the EditMessageLiveLocation calls
+func (m *MockApi) OnEditMessageLiveLocation(stub func(request *tgbot.EditMessageLiveLocationRequest) (*tgbot.EditMessageLiveLocationResponse, error)) {
+	m.Stub("EditMessageLiveLocation", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.EditMessageLiveLocationRequest))
+	})
+}
+
+// EditMessageLiveLocationCalls returns requests of all EditMessageLiveLocation calls
+func (m *MockApi) EditMessageLiveLocationCalls() []*tgbot.EditMessageLiveLocationRequest {
+	return mockCalls[tgbot.EditMessageLiveLocationRequest](m, "EditMessageLiveLocation")
+}
+
+// StopMessageLiveLocation records the call and returns the stubbed response
+func (m *MockApi) StopMessageLiveLocation(request *tgbot.StopMessageLiveLocationRequest) (*tgbot.StopMessageLiveLocationResponse, error) {
+	return mockCall[tgbot.StopMessageLiveLocationResponse](m, "StopMessageLiveLocation", request)
+}
+
+// OnStopMessageLiveLocation stubs the StopMessageLiveLocation calls
+func (m *MockApi) OnStopMessageLiveLocation(stub func(request *tgbot.StopMessageLiveLocationRequest) (*tgbot.StopMessageLiveLocationResponse, error)) {
+	m.Stub("StopMessageLiveLocation", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.StopMessageLiveLocationRequest))
+	})
+}
+
+// StopMessageLiveLocationCalls returns requests of all StopMessageLiveLocation calls
+func (m *MockApi) StopMessageLiveLocationCalls() []*tgbot.StopMessageLiveLocationRequest {
+	return mockCalls[tgbot.StopMessageLiveLocationRequest](m, "StopMessageLiveLocation")
+}
+
+// EditMessageReplyMarkup records the call and returns the stubbed response
+func (m *MockApi) EditMessageReplyMarkup(request *tgbot.EditMessageReplyMarkupRequest) (*tgbot.EditMessageReplyMarkupResponse, error) {
+	return mockCall[tgbot.EditMessageReplyMarkupResponse](m, "EditMessageReplyMarkup", request)
+}
+
+// OnEditMessageReplyMarkup stubs the EditMessageReplyMarkup calls
+func (m *MockApi) OnEditMessageReplyMarkup(stub func(request *tgbot.EditMessageReplyMarkupRequest) (*tgbot.EditMessageReplyMarkupResponse, error)) {
+	m.Stub("EditMessageReplyMarkup", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.EditMessageReplyMarkupRequest))
+	})
+}
+
+// EditMessageReplyMarkupCalls returns requests of all EditMessageReplyMarkup calls
+func (m *MockApi) EditMessageReplyMarkupCalls() []*tgbot.EditMessageReplyMarkupRequest {
+	return mockCalls[tgbot.EditMessageReplyMarkupRequest](m, "EditMessageReplyMarkup")
+}
+
+// StopPoll records the call and returns the stubbed response
+func (m *MockApi) StopPoll(request *tgbot.StopPollRequest) (*tgbot.StopPollResponse, error) {
+	return mockCall[tgbot.StopPollResponse](m, "StopPoll", request)
+}
+
+// OnStopPoll stubs the StopPoll calls
+func (m *MockApi) OnStopPoll(stub func(request *tgbot.StopPollRequest) (*tgbot.StopPollResponse, error)) {
+	m.Stub("StopPoll", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.StopPollRequest))
+	})
+}
+
+// StopPollCalls returns requests of all StopPoll calls
+func (m *MockApi) StopPollCalls() []*tgbot.StopPollRequest {
+	return mockCalls[tgbot.StopPollRequest](m, "StopPoll")
+}
+
+// DeleteMessage records the call and returns the stubbed response
+func (m *MockApi) DeleteMessage(request *tgbot.DeleteMessageRequest) (*tgbot.DeleteMessageResponse, error) {
+	return mockCall[tgbot.DeleteMessageResponse](m, "DeleteMessage", request)
+}
+
+// OnDeleteMessage stubs the DeleteMessage calls
+func (m *MockApi) OnDeleteMessage(stub func(request *tgbot.DeleteMessageRequest) (*tgbot.DeleteMessageResponse, error)) {
+	m.Stub("DeleteMessage", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.DeleteMessageRequest))
+	})
+}
+
+// DeleteMessageCalls returns requests of all DeleteMessage calls
+func (m *MockApi) DeleteMessageCalls() []*tgbot.DeleteMessageRequest {
+	return mockCalls[tgbot.DeleteMessageRequest](m, "DeleteMessage")
+}
+
+// DeleteMessages records the call and returns the stubbed response
+func (m *MockApi) DeleteMessages(request *tgbot.DeleteMessagesRequest) (*tgbot.DeleteMessagesResponse, error) {
+	return mockCall[tgbot.DeleteMessagesResponse](m, "DeleteMessages", request)
+}
+
+// OnDeleteMessages stubs the DeleteMessages calls
+func (m *MockApi) OnDeleteMessages(stub func(request *tgbot.DeleteMessagesRequest) (*tgbot.DeleteMessagesResponse, error)) {
+	m.Stub("DeleteMessages", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.DeleteMessagesRequest))
+	})
+}
+
+// DeleteMessagesCalls returns requests of all DeleteMessages calls
+func (m *MockApi) DeleteMessagesCalls() []*tgbot.DeleteMessagesRequest {
+	return mockCalls[tgbot.DeleteMessagesRequest](m, "DeleteMessages")
+}
+
+// SendSticker records the call and returns the stubbed response
+func (m *MockApi) SendSticker(request *tgbot.SendStickerRequest) (*tgbot.SendStickerResponse, error) {
+	return mockCall[tgbot.SendStickerResponse](m, "SendSticker", request)
+}
+
+// OnSendSticker stubs the SendSticker calls
+func (m *MockApi) OnSendSticker(stub func(request *tgbot.SendStickerRequest) (*tgbot.SendStickerResponse, error)) {
+	m.Stub("SendSticker", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendStickerRequest))
+	})
+}
+
+// SendStickerCalls returns requests of all SendSticker calls
+func (m *MockApi) SendStickerCalls() []*tgbot.SendStickerRequest {
+	return mockCalls[tgbot.SendStickerRequest](m, "SendSticker")
+}
+
+// GetStickerSet records the call and returns the stubbed response
+func (m *MockApi) GetStickerSet(request *tgbot.GetStickerSetRequest) (*tgbot.GetStickerSetResponse, error) {
+	return mockCall[tgbot.GetStickerSetResponse](m, "GetStickerSet", request)
+}
+
+// OnGetStickerSet stubs the GetStickerSet calls
+func (m *MockApi) OnGetStickerSet(stub func(request *tgbot.GetStickerSetRequest) (*tgbot.GetStickerSetResponse, error)) {
+	m.Stub("GetStickerSet", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetStickerSetRequest))
+	})
+}
+
+// GetStickerSetCalls returns requests of all GetStickerSet calls
+func (m *MockApi) GetStickerSetCalls() []*tgbot.GetStickerSetRequest {
+	return mockCalls[tgbot.GetStickerSetRequest](m, "GetStickerSet")
+}
+
+// GetCustomEmojiStickers records the call and returns the stubbed response
+func (m *MockApi) GetCustomEmojiStickers(request *tgbot.GetCustomEmojiStickersRequest) (*tgbot.GetCustomEmojiStickersResponse, error) {
+	return mockCall[tgbot.GetCustomEmojiStickersResponse](m, "GetCustomEmojiStickers", request)
+}
+
+// OnGetCustomEmojiStickers stubs the GetCustomEmojiStickers calls
+func (m *MockApi) OnGetCustomEmojiStickers(stub func(request *tgbot.GetCustomEmojiStickersRequest) (*tgbot.GetCustomEmojiStickersResponse, error)) {
+	m.Stub("GetCustomEmojiStickers", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetCustomEmojiStickersRequest))
+	})
+}
+
+// GetCustomEmojiStickersCalls returns requests of all GetCustomEmojiStickers calls
+func (m *MockApi) GetCustomEmojiStickersCalls() []*tgbot.GetCustomEmojiStickersRequest {
+	return mockCalls[tgbot.GetCustomEmojiStickersRequest](m, "GetCustomEmojiStickers")
+}
+
+// UploadStickerFile records the call and returns the stubbed response
+func (m *MockApi) UploadStickerFile(request *tgbot.UploadStickerFileRequest) (*tgbot.UploadStickerFileResponse, error) {
+	return mockCall[tgbot.UploadStickerFileResponse](m, "UploadStickerFile", request)
+}
+
+// OnUploadStickerFile stubs the UploadStickerFile calls
+func (m *MockApi) OnUploadStickerFile(stub func(request *tgbot.UploadStickerFileRequest) (*tgbot.UploadStickerFileResponse, error)) {
+	m.Stub("UploadStickerFile", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.UploadStickerFileRequest))
+	})
+}
+
+// UploadStickerFileCalls returns requests of all UploadStickerFile calls
+func (m *MockApi) UploadStickerFileCalls() []*tgbot.UploadStickerFileRequest {
+	return mockCalls[tgbot.UploadStickerFileRequest](m, "UploadStickerFile")
+}
+
+// CreateNewStickerSet records the call and returns the stubbed response
+func (m *MockApi) CreateNewStickerSet(request *tgbot.CreateNewStickerSetRequest) (*tgbot.CreateNewStickerSetResponse, error) {
+	return mockCall[tgbot.CreateNewStickerSetResponse](m, "CreateNewStickerSet", request)
+}
+
+// OnCreateNewStickerSet stubs the CreateNewStickerSet calls
+func (m *MockApi) OnCreateNewStickerSet(stub func(request *tgbot.CreateNewStickerSetRequest) (*tgbot.CreateNewStickerSetResponse, error)) {
+	m.Stub("CreateNewStickerSet", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.CreateNewStickerSetRequest))
+	})
+}
+
+// CreateNewStickerSetCalls returns requests of all CreateNewStickerSet calls
+func (m *MockApi) CreateNewStickerSetCalls() []*tgbot.CreateNewStickerSetRequest {
+	return mockCalls[tgbot.CreateNewStickerSetRequest](m, "CreateNewStickerSet")
+}
+
+// AddStickerToSet records the call and returns the stubbed response
+func (m *MockApi) AddStickerToSet(request *tgbot.AddStickerToSetRequest) (*tgbot.AddStickerToSetResponse, error) {
+	return mockCall[tgbot.AddStickerToSetResponse](m, "AddStickerToSet", request)
+}
+
+// OnAddStickerToSet stubs the AddStickerToSet calls
+func (m *MockApi) OnAddStickerToSet(stub func(request *tgbot.AddStickerToSetRequest) (*tgbot.AddStickerToSetResponse, error)) {
+	m.Stub("AddStickerToSet", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.AddStickerToSetRequest))
+	})
+}
+
+// AddStickerToSetCalls returns requests of all AddStickerToSet calls
+func (m *MockApi) AddStickerToSetCalls() []*tgbot.AddStickerToSetRequest {
+	return mockCalls[tgbot.AddStickerToSetRequest](m, "AddStickerToSet")
+}
+
+// SetStickerPositionInSet records the call and returns the stubbed response
+func (m *MockApi) SetStickerPositionInSet(request *tgbot.SetStickerPositionInSetRequest) (*tgbot.SetStickerPositionInSetResponse, error) {
+	return mockCall[tgbot.SetStickerPositionInSetResponse](m, "SetStickerPositionInSet", request)
+}
+
+// OnSetStickerPositionInSet stubs the SetStickerPositionInSet calls
+func (m *MockApi) OnSetStickerPositionInSet(stub func(request *tgbot.SetStickerPositionInSetRequest) (*tgbot.SetStickerPositionInSetResponse, error)) {
+	m.Stub("SetStickerPositionInSet", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetStickerPositionInSetRequest))
+	})
+}
+
+// SetStickerPositionInSetCalls returns requests of all SetStickerPositionInSet calls
+func (m *MockApi) SetStickerPositionInSetCalls() []*tgbot.SetStickerPositionInSetRequest {
+	return mockCalls[tgbot.SetStickerPositionInSetRequest](m, "SetStickerPositionInSet")
+}
+
+// DeleteStickerFromSet records the call and returns the stubbed response
+func (m *MockApi) DeleteStickerFromSet(request *tgbot.DeleteStickerFromSetRequest) (*tgbot.DeleteStickerFromSetResponse, error) {
+	return mockCall[tgbot.DeleteStickerFromSetResponse](m, "DeleteStickerFromSet", request)
+}
+
+// OnDeleteStickerFromSet stubs the DeleteStickerFromSet calls
+func (m *MockApi) OnDeleteStickerFromSet(stub func(request *tgbot.DeleteStickerFromSetRequest) (*tgbot.DeleteStickerFromSetResponse, error)) {
+	m.Stub("DeleteStickerFromSet", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.DeleteStickerFromSetRequest))
+	})
+}
+
+// DeleteStickerFromSetCalls returns requests of all DeleteStickerFromSet calls
+func (m *MockApi) DeleteStickerFromSetCalls() []*tgbot.DeleteStickerFromSetRequest {
+	return mockCalls[tgbot.DeleteStickerFromSetRequest](m, "DeleteStickerFromSet")
+}
+
+// SetStickerEmojiList records the call and returns the stubbed response
+func (m *MockApi) SetStickerEmojiList(request *tgbot.SetStickerEmojiListRequest) (*tgbot.SetStickerEmojiListResponse, error) {
+	return mockCall[tgbot.SetStickerEmojiListResponse](m, "SetStickerEmojiList", request)
+}
+
+// OnSetStickerEmojiList stubs the SetStickerEmojiList calls
+func (m *MockApi) OnSetStickerEmojiList(stub func(request *tgbot.SetStickerEmojiListRequest) (*tgbot.SetStickerEmojiListResponse, error)) {
+	m.Stub("SetStickerEmojiList", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetStickerEmojiListRequest))
+	})
+}
+
+// SetStickerEmojiListCalls returns requests of all SetStickerEmojiList calls
+func (m *MockApi) SetStickerEmojiListCalls() []*tgbot.SetStickerEmojiListRequest {
+	return mockCalls[tgbot.SetStickerEmojiListRequest](m, "SetStickerEmojiList")
+}
+
+// SetStickerKeywords records the call and returns the stubbed response
+func (m *MockApi) SetStickerKeywords(request *tgbot.SetStickerKeywordsRequest) (*tgbot.SetStickerKeywordsResponse, error) {
+	return mockCall[tgbot.SetStickerKeywordsResponse](m, "SetStickerKeywords", request)
+}
+
+// OnSetStickerKeywords stubs the SetStickerKeywords calls
+func (m *MockApi) OnSetStickerKeywords(stub func(request *tgbot.SetStickerKeywordsRequest) (*tgbot.SetStickerKeywordsResponse, error)) {
+	m.Stub("SetStickerKeywords", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetStickerKeywordsRequest))
+	})
+}
+
+// SetStickerKeywordsCalls returns requests of all SetStickerKeywords calls
+func (m *MockApi) SetStickerKeywordsCalls() []*tgbot.SetStickerKeywordsRequest {
+	return mockCalls[tgbot.SetStickerKeywordsRequest](m, "SetStickerKeywords")
+}
+
+// SetStickerMaskPosition records the call and returns the stubbed response
+func (m *MockApi) SetStickerMaskPosition(request *tgbot.SetStickerMaskPositionRequest) (*tgbot.SetStickerMaskPositionResponse, error) {
+	return mockCall[tgbot.SetStickerMaskPositionResponse](m, "SetStickerMaskPosition", request)
+}
+
+// OnSetStickerMaskPosition stubs the SetStickerMaskPosition calls
+func (m *MockApi) OnSetStickerMaskPosition(stub func(request *tgbot.SetStickerMaskPositionRequest) (*tgbot.SetStickerMaskPositionResponse, error)) {
+	m.Stub("SetStickerMaskPosition", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetStickerMaskPositionRequest))
+	})
+}
+
+// SetStickerMaskPositionCalls returns requests of all SetStickerMaskPosition calls
+func (m *MockApi) SetStickerMaskPositionCalls() []*tgbot.SetStickerMaskPositionRequest {
+	return mockCalls[tgbot.SetStickerMaskPositionRequest](m, "SetStickerMaskPosition")
+}
+
+// SetStickerSetTitle records the call and returns the stubbed response
+func (m *MockApi) SetStickerSetTitle(request *tgbot.SetStickerSetTitleRequest) (*tgbot.SetStickerSetTitleResponse, error) {
+	return mockCall[tgbot.SetStickerSetTitleResponse](m, "SetStickerSetTitle", request)
+}
+
+// OnSetStickerSetTitle stubs the SetStickerSetTitle calls
+func (m *MockApi) OnSetStickerSetTitle(stub func(request *tgbot.SetStickerSetTitleRequest) (*tgbot.SetStickerSetTitleResponse, error)) {
+	m.Stub("SetStickerSetTitle", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetStickerSetTitleRequest))
+	})
+}
+
+// SetStickerSetTitleCalls returns requests of all SetStickerSetTitle calls
+func (m *MockApi) SetStickerSetTitleCalls() []*tgbot.SetStickerSetTitleRequest {
+	return mockCalls[tgbot.SetStickerSetTitleRequest](m, "SetStickerSetTitle")
+}
+
+// SetStickerSetThumbnail records the call and returns the stubbed response
+func (m *MockApi) SetStickerSetThumbnail(request *tgbot.SetStickerSetThumbnailRequest) (*tgbot.SetStickerSetThumbnailResponse, error) {
+	return mockCall[tgbot.SetStickerSetThumbnailResponse](m, "SetStickerSetThumbnail", request)
+}
+
+// OnSetStickerSetThumbnail stubs the SetStickerSetThumbnail calls
+func (m *MockApi) OnSetStickerSetThumbnail(stub func(request *tgbot.SetStickerSetThumbnailRequest) (*tgbot.SetStickerSetThumbnailResponse, error)) {
+	m.Stub("SetStickerSetThumbnail", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetStickerSetThumbnailRequest))
+	})
+}
+
+// SetStickerSetThumbnailCalls returns requests of all SetStickerSetThumbnail calls
+func (m *MockApi) SetStickerSetThumbnailCalls() []*tgbot.SetStickerSetThumbnailRequest {
+	return mockCalls[tgbot.SetStickerSetThumbnailRequest](m, "SetStickerSetThumbnail")
+}
+
+// SetCustomEmojiStickerSetThumbnail records the call and returns the stubbed response
+func (m *MockApi) SetCustomEmojiStickerSetThumbnail(request *tgbot.SetCustomEmojiStickerSetThumbnailRequest) (*tgbot.SetCustomEmojiStickerSetThumbnailResponse, error) {
+	return mockCall[tgbot.SetCustomEmojiStickerSetThumbnailResponse](m, "SetCustomEmojiStickerSetThumbnail", request)
+}
+
+// OnSetCustomEmojiStickerSetThumbnail stubs the SetCustomEmojiStickerSetThumbnail calls
+func (m *MockApi) OnSetCustomEmojiStickerSetThumbnail(stub func(request *tgbot.SetCustomEmojiStickerSetThumbnailRequest) (*tgbot.SetCustomEmojiStickerSetThumbnailResponse, error)) {
+	m.Stub("SetCustomEmojiStickerSetThumbnail", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetCustomEmojiStickerSetThumbnailRequest))
+	})
+}
+
+// SetCustomEmojiStickerSetThumbnailCalls returns requests of all SetCustomEmojiStickerSetThumbnail calls
+func (m *MockApi) SetCustomEmojiStickerSetThumbnailCalls() []*tgbot.SetCustomEmojiStickerSetThumbnailRequest {
+	return mockCalls[tgbot.SetCustomEmojiStickerSetThumbnailRequest](m, "SetCustomEmojiStickerSetThumbnail")
+}
+
+// DeleteStickerSet records the call and returns the stubbed response
+func (m *MockApi) DeleteStickerSet(request *tgbot.DeleteStickerSetRequest) (*tgbot.DeleteStickerSetResponse, error) {
+	return mockCall[tgbot.DeleteStickerSetResponse](m, "DeleteStickerSet", request)
+}
+
+// OnDeleteStickerSet stubs the DeleteStickerSet calls
+func (m *MockApi) OnDeleteStickerSet(stub func(request *tgbot.DeleteStickerSetRequest) (*tgbot.DeleteStickerSetResponse, error)) {
+	m.Stub("DeleteStickerSet", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.DeleteStickerSetRequest))
+	})
+}
+
+// DeleteStickerSetCalls returns requests of all DeleteStickerSet calls
+func (m *MockApi) DeleteStickerSetCalls() []*tgbot.DeleteStickerSetRequest {
+	return mockCalls[tgbot.DeleteStickerSetRequest](m, "DeleteStickerSet")
+}
+
+// AnswerInlineQuery records the call and returns the stubbed response
+func (m *MockApi) AnswerInlineQuery(request *tgbot.AnswerInlineQueryRequest) (*tgbot.AnswerInlineQueryResponse, error) {
+	return mockCall[tgbot.AnswerInlineQueryResponse](m, "AnswerInlineQuery", request)
+}
+
+// OnAnswerInlineQuery stubs the AnswerInlineQuery calls
+func (m *MockApi) OnAnswerInlineQuery(stub func(request *tgbot.AnswerInlineQueryRequest) (*tgbot.AnswerInlineQueryResponse, error)) {
+	m.Stub("AnswerInlineQuery", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.AnswerInlineQueryRequest))
+	})
+}
+
+// AnswerInlineQueryCalls returns requests of all AnswerInlineQuery calls
+func (m *MockApi) AnswerInlineQueryCalls() []*tgbot.AnswerInlineQueryRequest {
+	return mockCalls[tgbot.AnswerInlineQueryRequest](m, "AnswerInlineQuery")
+}
+
+// AnswerWebAppQuery records the call and returns the stubbed response
+func (m *MockApi) AnswerWebAppQuery(request *tgbot.AnswerWebAppQueryRequest) (*tgbot.AnswerWebAppQueryResponse, error) {
+	return mockCall[tgbot.AnswerWebAppQueryResponse](m, "AnswerWebAppQuery", request)
+}
+
+// OnAnswerWebAppQuery stubs the AnswerWebAppQuery calls
+func (m *MockApi) OnAnswerWebAppQuery(stub func(request *tgbot.AnswerWebAppQueryRequest) (*tgbot.AnswerWebAppQueryResponse, error)) {
+	m.Stub("AnswerWebAppQuery", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.AnswerWebAppQueryRequest))
+	})
+}
+
+// AnswerWebAppQueryCalls returns requests of all AnswerWebAppQuery calls
+func (m *MockApi) AnswerWebAppQueryCalls() []*tgbot.AnswerWebAppQueryRequest {
+	return mockCalls[tgbot.AnswerWebAppQueryRequest](m, "AnswerWebAppQuery")
+}
+
+// SendInvoice records the call and returns the stubbed response
+func (m *MockApi) SendInvoice(request *tgbot.SendInvoiceRequest) (*tgbot.SendInvoiceResponse, error) {
+	return mockCall[tgbot.SendInvoiceResponse](m, "SendInvoice", request)
+}
+
+// OnSendInvoice stubs the SendInvoice calls
+func (m *MockApi) OnSendInvoice(stub func(request *tgbot.SendInvoiceRequest) (*tgbot.SendInvoiceResponse, error)) {
+	m.Stub("SendInvoice", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendInvoiceRequest))
+	})
+}
+
+// SendInvoiceCalls returns requests of all SendInvoice calls
+func (m *MockApi) SendInvoiceCalls() []*tgbot.SendInvoiceRequest {
+	return mockCalls[tgbot.SendInvoiceRequest](m, "SendInvoice")
+}
+
+// CreateInvoiceLink records the call and returns the stubbed response
+func (m *MockApi) CreateInvoiceLink(request *tgbot.CreateInvoiceLinkRequest) (*tgbot.CreateInvoiceLinkResponse, error) {
+	return mockCall[tgbot.CreateInvoiceLinkResponse](m, "CreateInvoiceLink", request)
+}
+
+// OnCreateInvoiceLink stubs the CreateInvoiceLink calls
+func (m *MockApi) OnCreateInvoiceLink(stub func(request *tgbot.CreateInvoiceLinkRequest) (*tgbot.CreateInvoiceLinkResponse, error)) {
+	m.Stub("CreateInvoiceLink", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.CreateInvoiceLinkRequest))
+	})
+}
+
+// CreateInvoiceLinkCalls returns requests of all CreateInvoiceLink calls
+func (m *MockApi) CreateInvoiceLinkCalls() []*tgbot.CreateInvoiceLinkRequest {
+	return mockCalls[tgbot.CreateInvoiceLinkRequest](m, "CreateInvoiceLink")
+}
+
+// AnswerShippingQuery records the call and returns the stubbed response
+func (m *MockApi) AnswerShippingQuery(request *tgbot.AnswerShippingQueryRequest) (*tgbot.AnswerShippingQueryResponse, error) {
+	return mockCall[tgbot.AnswerShippingQueryResponse](m, "AnswerShippingQuery", request)
+}
+
+// OnAnswerShippingQuery stubs the AnswerShippingQuery calls
+func (m *MockApi) OnAnswerShippingQuery(stub func(request *tgbot.AnswerShippingQueryRequest) (*tgbot.AnswerShippingQueryResponse, error)) {
+	m.Stub("AnswerShippingQuery", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.AnswerShippingQueryRequest))
+	})
+}
+
+// AnswerShippingQueryCalls returns requests of all AnswerShippingQuery calls
+func (m *MockApi) AnswerShippingQueryCalls() []*tgbot.AnswerShippingQueryRequest {
+	return mockCalls[tgbot.AnswerShippingQueryRequest](m, "AnswerShippingQuery")
+}
+
+// AnswerPreCheckoutQuery records the call and returns the stubbed response
+func (m *MockApi) AnswerPreCheckoutQuery(request *tgbot.AnswerPreCheckoutQueryRequest) (*tgbot.AnswerPreCheckoutQueryResponse, error) {
+	return mockCall[tgbot.AnswerPreCheckoutQueryResponse](m, "AnswerPreCheckoutQuery", request)
+}
+
+// OnAnswerPreCheckoutQuery stubs the AnswerPreCheckoutQuery calls
+func (m *MockApi) OnAnswerPreCheckoutQuery(stub func(request *tgbot.AnswerPreCheckoutQueryRequest) (*tgbot.AnswerPreCheckoutQueryResponse, error)) {
+	m.Stub("AnswerPreCheckoutQuery", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.AnswerPreCheckoutQueryRequest))
+	})
+}
+
+// AnswerPreCheckoutQueryCalls returns requests of all AnswerPreCheckoutQuery calls
+func (m *MockApi) AnswerPreCheckoutQueryCalls() []*tgbot.AnswerPreCheckoutQueryRequest {
+	return mockCalls[tgbot.AnswerPreCheckoutQueryRequest](m, "AnswerPreCheckoutQuery")
+}
+
+// SetPassportDataErrors records the call and returns the stubbed response
+func (m *MockApi) SetPassportDataErrors(request *tgbot.SetPassportDataErrorsRequest) (*tgbot.SetPassportDataErrorsResponse, error) {
+	return mockCall[tgbot.SetPassportDataErrorsResponse](m, "SetPassportDataErrors", request)
+}
+
+// OnSetPassportDataErrors stubs the SetPassportDataErrors calls
+func (m *MockApi) OnSetPassportDataErrors(stub func(request *tgbot.SetPassportDataErrorsRequest) (*tgbot.SetPassportDataErrorsResponse, error)) {
+	m.Stub("SetPassportDataErrors", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetPassportDataErrorsRequest))
+	})
+}
+
+// SetPassportDataErrorsCalls returns requests of all SetPassportDataErrors calls
+func (m *MockApi) SetPassportDataErrorsCalls() []*tgbot.SetPassportDataErrorsRequest {
+	return mockCalls[tgbot.SetPassportDataErrorsRequest](m, "SetPassportDataErrors")
+}
+
+// SendGame records the call and returns the stubbed response
+func (m *MockApi) SendGame(request *tgbot.SendGameRequest) (*tgbot.SendGameResponse, error) {
+	return mockCall[tgbot.SendGameResponse](m, "SendGame", request)
+}
+
+// OnSendGame stubs the SendGame calls
+func (m *MockApi) OnSendGame(stub func(request *tgbot.SendGameRequest) (*tgbot.SendGameResponse, error)) {
+	m.Stub("SendGame", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SendGameRequest))
+	})
+}
+
+// SendGameCalls returns requests of all SendGame calls
+func (m *MockApi) SendGameCalls() []*tgbot.SendGameRequest {
+	return mockCalls[tgbot.SendGameRequest](m, "SendGame")
+}
+
+// SetGameScore records the call and returns the stubbed response
+func (m *MockApi) SetGameScore(request *tgbot.SetGameScoreRequest) (*tgbot.SetGameScoreResponse, error) {
+	return mockCall[tgbot.SetGameScoreResponse](m, "SetGameScore", request)
+}
+
+// OnSetGameScore stubs the SetGameScore calls
+func (m *MockApi) OnSetGameScore(stub func(request *tgbot.SetGameScoreRequest) (*tgbot.SetGameScoreResponse, error)) {
+	m.Stub("SetGameScore", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.SetGameScoreRequest))
+	})
+}
+
+// SetGameScoreCalls returns requests of all SetGameScore calls
+func (m *MockApi) SetGameScoreCalls() []*tgbot.SetGameScoreRequest {
+	return mockCalls[tgbot.SetGameScoreRequest](m, "SetGameScore")
+}
+
+// GetGameHighScores records the call and returns the stubbed response
+func (m *MockApi) GetGameHighScores(request *tgbot.GetGameHighScoresRequest) (*tgbot.GetGameHighScoresResponse, error) {
+	return mockCall[tgbot.GetGameHighScoresResponse](m, "GetGameHighScores", request)
+}
+
+// OnGetGameHighScores stubs the GetGameHighScores calls
+func (m *MockApi) OnGetGameHighScores(stub func(request *tgbot.GetGameHighScoresRequest) (*tgbot.GetGameHighScoresResponse, error)) {
+	m.Stub("GetGameHighScores", func(request interface{}) (interface{}, error) {
+		return stub(request.(*tgbot.GetGameHighScoresRequest))
+	})
+}
+
+// GetGameHighScoresCalls returns requests of all GetGameHighScores calls
+func (m *MockApi) GetGameHighScoresCalls() []*tgbot.GetGameHighScoresRequest {
+	return mockCalls[tgbot.GetGameHighScoresRequest](m, "GetGameHighScores")
+}
+
+var _ tgbot.TelegramApiInterface = (*MockApi)(nil)
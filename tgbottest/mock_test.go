@@ -0,0 +1,116 @@
+package tgbottest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/lanseg/tgbot"
+	"github.com/lanseg/tgbot/tgbottest"
+)
+
+// failureRecorder collects the assertion failures instead of failing the test.
+type failureRecorder struct {
+	testing.TB
+	failures []string
+}
+
+func (r *failureRecorder) Helper() {}
+
+func (r *failureRecorder) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+// welcome is the code under test, it only knows the interface.
+func welcome(api tgbot.TelegramApiInterface, chatID string) error {
+	sent, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: chatID, Text: "Welcome"})
+	if err != nil {
+		return err
+	}
+	_, err = api.PinChatMessage(&tgbot.PinChatMessageRequest{ChatID: chatID, MessageID: sent.Result.MessageID})
+	return err
+}
+
+func TestMockApiStubs(t *testing.T) {
+	api := tgbottest.NewMockApi()
+	api.OnSendMessage(func(request *tgbot.SendMessageRequest) (*tgbot.SendMessageResponse, error) {
+		return &tgbot.SendMessageResponse{Result: &tgbot.Message{MessageID: 7}}, nil
+	})
+	if err := welcome(api, "42"); err != nil {
+		t.Fatal(err)
+	}
+
+	tgbottest.AssertCallCount(t, api.SendMessageCalls(), 1)
+	tgbottest.AssertCalled(t, api.PinChatMessageCalls(), func(request *tgbot.PinChatMessageRequest) bool {
+		return request.ChatID == "42" && request.MessageID == 7
+	})
+	if calls := api.Calls(); len(calls) != 2 || calls[0].Method != "SendMessage" || calls[1].Method != "PinChatMessage" {
+		t.Errorf("Recorded calls %v", calls)
+	}
+}
+
+func TestMockApiDefaultsAndFailures(t *testing.T) {
+	api := tgbottest.NewMockApi()
+	response, err := api.GetMe(&tgbot.GetMeRequest{})
+	if err != nil || response == nil {
+		t.Errorf("Unstubbed call returned %v, %v", response, err)
+	}
+
+	failure := errors.New("Forbidden: bot was blocked by the user")
+	api.Fail("SendMessage", failure)
+	if err := welcome(api, "42"); !errors.Is(err, failure) {
+		t.Errorf("welcome returned %v", err)
+	}
+	tgbottest.AssertCallCount(t, api.PinChatMessageCalls(), 0)
+
+	api.Reset()
+	if calls := api.Calls(); len(calls) != 0 {
+		t.Errorf("Calls are kept after Reset: %v", calls)
+	}
+	if _, err := api.SendMessage(&tgbot.SendMessageRequest{}); !errors.Is(err, failure) {
+		t.Errorf("Stub is lost after Reset")
+	}
+}
+
+func TestMockApiAssertions(t *testing.T) {
+	api := tgbottest.NewMockApi()
+	api.SendMessage(&tgbot.SendMessageRequest{ChatID: "1", Text: "hello"})
+	isHello := func(request *tgbot.SendMessageRequest) bool { return request.Text == "hello" }
+	isBye := func(request *tgbot.SendMessageRequest) bool { return request.Text == "bye" }
+
+	recorder := &failureRecorder{TB: t}
+	if found := tgbottest.AssertCalled(recorder, api.SendMessageCalls(), isHello); found == nil || found.ChatID != "1" {
+		t.Errorf("AssertCalled returned %v", found)
+	}
+	tgbottest.AssertNotCalled(recorder, api.SendMessageCalls(), isBye)
+	tgbottest.AssertCallCount(recorder, api.SendMessageCalls(), 1)
+	if len(recorder.failures) != 0 {
+		t.Errorf("Passing assertions failed: %v", recorder.failures)
+	}
+
+	tgbottest.AssertCalled(recorder, api.SendMessageCalls(), isBye)
+	tgbottest.AssertNotCalled(recorder, api.SendMessageCalls(), isHello)
+	tgbottest.AssertCallCount(recorder, api.SendMessageCalls(), 2)
+	if len(recorder.failures) != 3 {
+		t.Errorf("Failing assertions reported %v", recorder.failures)
+	}
+}
+
+func TestMockApiDownloadFile(t *testing.T) {
+	api := tgbottest.NewMockApi()
+	api.OnDownloadFile(func(fileID string) (*tgbot.FileContent, error) {
+		return &tgbot.FileContent{ReadCloser: io.NopCloser(strings.NewReader("photo")), Size: 5}, nil
+	})
+
+	var client tgbot.TelegramApiInterface = api
+	file, err := client.WithContext(context.Background()).DownloadFile(context.Background(), "file-1")
+	if err != nil || file.Size != 5 {
+		t.Fatalf("DownloadFile = %+v, %v", file, err)
+	}
+	if calls := api.DownloadFileCalls(); len(calls) != 1 || calls[0] != "file-1" {
+		t.Errorf("DownloadFileCalls() = %q", calls)
+	}
+}
@@ -0,0 +1,643 @@
+// Package tgbottest provides a fake Telegram Bot API server for testing bots offline.
+//
+//	server := tgbottest.NewServer()
+//	defer server.Close()
+//	bot, _ := tgbot.NewCustomBot(server.URL, server.Token)
+//	server.SendUserMessage(chat, user, "/start")
+//	... run the handler ...
+//	if len(server.CallsTo("sendMessage")) != 1 { ... }
+package tgbottest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lanseg/tgbot"
+)
+
+// Token accepted by the fake server, it passes tgbot.ParseToken.
+const Token = "123456:TESTTOKENtesttokenTESTTOKENtesttoken"
+
+// Call is a single recorded Bot API call.
+type Call struct {
+	Method   string
+	Request  json.RawMessage
+	Response json.RawMessage
+}
+
+// Decode unmarshals the request of the call, e.g. into *tgbot.SendMessageRequest.
+func (c *Call) Decode(request interface{}) error {
+	return json.Unmarshal(c.Request, request)
+}
+
+// MethodHandler answers a Bot API call: the result is marshaled into the "result" field,
+// *APIError becomes an error response.
+type MethodHandler func(request json.RawMessage) (interface{}, error)
+
+// APIError is an error response of the Bot API.
+type APIError struct {
+	Code        int64
+	Description string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%d: %s", e.Code, e.Description)
+}
+
+func badRequest(format string, args ...any) *APIError {
+	return &APIError{Code: 400, Description: "Bad Request: " + fmt.Sprintf(format, args...)}
+}
+
+type fakeFile struct {
+	file *tgbot.File
+	data []byte
+}
+
+// Server is a stateful in-memory Bot API server.
+type Server struct {
+	*httptest.Server
+
+	Token string
+	// Bot user returned by getMe and set as the sender of the bot messages
+	Bot *tgbot.User
+	// Clock for the message dates
+	Now func() time.Time
+
+	mu             sync.Mutex
+	handlers       map[string]MethodHandler
+	calls          []*Call
+	users          map[int64]*tgbot.User
+	chats          map[int64]*tgbot.Chat
+	messages       map[int64]map[int64]*tgbot.Message
+	lastMessageID  map[int64]int64
+	updates        []*tgbot.Update
+	lastUpdateID   int64
+	updatesChanged chan struct{}
+	callbacks      map[string]*tgbot.AnswerCallbackQueryRequest
+	lastCallbackID int64
+	files          map[string]*fakeFile
+	lastFileID     int64
+}
+
+// NewServer starts a fake server, call Close when done.
+func NewServer() *Server {
+	server := &Server{
+		Token: Token,
+		Bot: &tgbot.User{
+			ID:        123456,
+			IsBot:     true,
+			FirstName: "Test Bot",
+			Username:  "test_bot",
+		},
+		Now:            time.Now,
+		handlers:       map[string]MethodHandler{},
+		users:          map[int64]*tgbot.User{},
+		chats:          map[int64]*tgbot.Chat{},
+		messages:       map[int64]map[int64]*tgbot.Message{},
+		lastMessageID:  map[int64]int64{},
+		updatesChanged: make(chan struct{}),
+		callbacks:      map[string]*tgbot.AnswerCallbackQueryRequest{},
+		files:          map[string]*fakeFile{},
+	}
+	server.registerMethods()
+	server.Server = httptest.NewServer(server)
+	return server
+}
+
+// Handle sets or overrides the handler of the method, e.g. to return an error. Handler is
+// called with the server locked, so it must not call the Server methods.
+func (s *Server) Handle(method string, handler MethodHandler) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[strings.ToLower(method)] = handler
+}
+
+func handle[T any](s *Server, method string, handler func(request *T) (interface{}, error)) {
+	s.handlers[strings.ToLower(method)] = func(raw json.RawMessage) (interface{}, error) {
+		request := new(T)
+		if err := json.Unmarshal(raw, request); err != nil {
+			return nil, badRequest("cannot parse request: %s", err)
+		}
+		return handler(request)
+	}
+}
+
+func (s *Server) registerMethods() {
+	handle(s, "getMe", func(*tgbot.GetMeRequest) (interface{}, error) {
+		return s.Bot, nil
+	})
+	handle(s, "sendMessage", func(request *tgbot.SendMessageRequest) (interface{}, error) {
+		return s.sendBotMessage(request.ChatID, &tgbot.Message{
+			MessageThreadID: request.MessageThreadID,
+			Text:            request.Text,
+			Entities:        request.Entities,
+		}, request.ReplyParameters)
+	})
+	handle(s, "sendPhoto", func(request *tgbot.SendPhotoRequest) (interface{}, error) {
+		file := s.inputFile(request.Photo, "photo.jpg")
+		return s.sendBotMessage(request.ChatID, &tgbot.Message{
+			MessageThreadID: request.MessageThreadID,
+			Caption:         request.Caption,
+			Photo: []*tgbot.PhotoSize{{
+				FileID:       file.FileID,
+				FileUniqueID: file.FileUniqueID,
+				FileSize:     file.FileSize,
+			}},
+		}, request.ReplyParameters)
+	})
+	handle(s, "sendDocument", func(request *tgbot.SendDocumentRequest) (interface{}, error) {
+		file := s.inputFile(request.Document, "document")
+		return s.sendBotMessage(request.ChatID, &tgbot.Message{
+			MessageThreadID: request.MessageThreadID,
+			Caption:         request.Caption,
+			Document: &tgbot.Document{
+				FileID:       file.FileID,
+				FileUniqueID: file.FileUniqueID,
+				FileSize:     file.FileSize,
+			},
+		}, request.ReplyParameters)
+	})
+	handle(s, "forwardMessage", func(request *tgbot.ForwardMessageRequest) (interface{}, error) {
+		original, err := s.findMessage(request.FromChatID, request.MessageID)
+		if err != nil {
+			return nil, err
+		}
+		forwarded := *original
+		forwarded.MessageThreadID = request.MessageThreadID
+		return s.sendBotMessage(request.ChatID, &forwarded, nil)
+	})
+	handle(s, "copyMessage", func(request *tgbot.CopyMessageRequest) (interface{}, error) {
+		original, err := s.findMessage(request.FromChatID, request.MessageID)
+		if err != nil {
+			return nil, err
+		}
+		copied := *original
+		copied.MessageThreadID = request.MessageThreadID
+		if request.Caption != "" {
+			copied.Caption = request.Caption
+		}
+		message, err := s.sendBotMessage(request.ChatID, &copied, request.ReplyParameters)
+		if err != nil {
+			return nil, err
+		}
+		return &tgbot.MessageId{MessageID: message.MessageID}, nil
+	})
+	handle(s, "editMessageText", func(request *tgbot.EditMessageTextRequest) (interface{}, error) {
+		return s.editMessage(request.ChatID, request.MessageID, func(message *tgbot.Message) {
+			message.Text = request.Text
+			message.Entities = request.Entities
+			if request.ReplyMarkup != nil {
+				message.ReplyMarkup = request.ReplyMarkup
+			}
+		})
+	})
+	handle(s, "editMessageCaption", func(request *tgbot.EditMessageCaptionRequest) (interface{}, error) {
+		return s.editMessage(request.ChatID, request.MessageID, func(message *tgbot.Message) {
+			message.Caption = request.Caption
+			message.CaptionEntities = request.CaptionEntities
+			if request.ReplyMarkup != nil {
+				message.ReplyMarkup = request.ReplyMarkup
+			}
+		})
+	})
+	handle(s, "editMessageReplyMarkup", func(request *tgbot.EditMessageReplyMarkupRequest) (interface{}, error) {
+		return s.editMessage(request.ChatID, request.MessageID, func(message *tgbot.Message) {
+			message.ReplyMarkup = request.ReplyMarkup
+		})
+	})
+	handle(s, "deleteMessage", func(request *tgbot.DeleteMessageRequest) (interface{}, error) {
+		return true, s.deleteMessages(request.ChatID, []int64{request.MessageID})
+	})
+	handle(s, "deleteMessages", func(request *tgbot.DeleteMessagesRequest) (interface{}, error) {
+		return true, s.deleteMessages(request.ChatID, request.MessageIds)
+	})
+	handle(s, "setMessageReaction", func(request *tgbot.SetMessageReactionRequest) (interface{}, error) {
+		if _, err := s.findMessage(request.ChatID, request.MessageID); err != nil {
+			return nil, err
+		}
+		return true, nil
+	})
+	handle(s, "sendChatAction", func(request *tgbot.SendChatActionRequest) (interface{}, error) {
+		if _, err := s.findChat(request.ChatID); err != nil {
+			return nil, err
+		}
+		return true, nil
+	})
+	handle(s, "answerCallbackQuery", func(request *tgbot.AnswerCallbackQueryRequest) (interface{}, error) {
+		answer, ok := s.callbacks[request.CallbackQueryID]
+		if !ok {
+			return nil, badRequest("query is too old and response timeout expired or query ID is invalid")
+		}
+		if answer != nil {
+			return nil, badRequest("query is already answered")
+		}
+		s.callbacks[request.CallbackQueryID] = request
+		return true, nil
+	})
+	handle(s, "getChat", func(request *tgbot.GetChatRequest) (interface{}, error) {
+		return s.findChat(request.ChatID)
+	})
+	handle(s, "getFile", func(request *tgbot.GetFileRequest) (interface{}, error) {
+		file, ok := s.files[request.FileID]
+		if !ok {
+			return nil, badRequest("invalid file_id")
+		}
+		return file.file, nil
+	})
+	handle(s, "getUpdates", func(request *tgbot.GetUpdatesRequest) (interface{}, error) {
+		return s.getUpdates(request), nil
+	})
+	handle(s, "deleteWebhook", func(*tgbot.DeleteWebhookRequest) (interface{}, error) {
+		return true, nil
+	})
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	if filePath, ok := strings.CutPrefix(path, "file/bot"+s.Token+"/"); ok {
+		s.serveFile(w, r, filePath)
+		return
+	}
+	method, ok := strings.CutPrefix(path, "bot"+s.Token+"/")
+	if !ok {
+		writeResponse(w, nil, &APIError{Code: 401, Description: "Unauthorized"})
+		return
+	}
+
+	request, err := io.ReadAll(r.Body)
+	if err != nil {
+		writeResponse(w, nil, badRequest("%s", err))
+		return
+	}
+	if len(request) == 0 {
+		request = []byte("{}")
+	}
+
+	call := &Call{Method: method, Request: request}
+	s.mu.Lock()
+	s.calls = append(s.calls, call)
+	handler, ok := s.handlers[strings.ToLower(method)]
+	var result interface{}
+	if !ok {
+		err = &APIError{Code: 404, Description: "Not Found"}
+	} else if strings.EqualFold(method, "getUpdates") {
+		// Long polling must not block the other calls
+		s.mu.Unlock()
+		result, err = handler(request)
+		s.mu.Lock()
+	} else {
+		result, err = handler(request)
+	}
+	// The result points to the server state, it is marshaled before the other calls change it
+	response, status := marshalResponse(result, err)
+	call.Response = response
+	s.mu.Unlock()
+
+	writeBody(w, response, status)
+}
+
+func writeResponse(w http.ResponseWriter, result interface{}, err error) {
+	response, status := marshalResponse(result, err)
+	writeBody(w, response, status)
+}
+
+func writeBody(w http.ResponseWriter, body []byte, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(body)
+}
+
+func marshalResponse(result interface{}, err error) ([]byte, int) {
+	response := map[string]interface{}{"ok": true, "result": result}
+	status := http.StatusOK
+	if err != nil {
+		apiErr, ok := err.(*APIError)
+		if !ok {
+			apiErr = &APIError{Code: 500, Description: err.Error()}
+		}
+		response = map[string]interface{}{
+			"ok":          false,
+			"error_code":  apiErr.Code,
+			"description": apiErr.Description,
+		}
+		status = int(apiErr.Code)
+	}
+	body, _ := json.Marshal(response)
+	return body, status
+}
+
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request, filePath string) {
+	s.mu.Lock()
+	var found *fakeFile
+	for _, file := range s.files {
+		if file.file.FilePath == filePath {
+			found = file
+		}
+	}
+	s.mu.Unlock()
+	if found == nil {
+		http.NotFound(w, r)
+		return
+	}
+	http.ServeContent(w, r, filePath, time.Time{}, strings.NewReader(string(found.data)))
+}
+
+// Calls returns copies of all recorded calls in order.
+func (s *Server) Calls() []*Call {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := make([]*Call, 0, len(s.calls))
+	for _, call := range s.calls {
+		copied := *call
+		result = append(result, &copied)
+	}
+	return result
+}
+
+// CallsTo returns recorded calls of the method, method name is case-insensitive.
+func (s *Server) CallsTo(method string) []*Call {
+	result := []*Call{}
+	for _, call := range s.Calls() {
+		if strings.EqualFold(call.Method, method) {
+			result = append(result, call)
+		}
+	}
+	return result
+}
+
+// AddUser registers the user, a private chat with the user is created as well.
+func (s *Server) AddUser(user *tgbot.User) *tgbot.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addUser(user)
+	return user
+}
+
+func (s *Server) addUser(user *tgbot.User) {
+	s.users[user.ID] = user
+	if _, ok := s.chats[user.ID]; !ok {
+		s.chats[user.ID] = &tgbot.Chat{
+			ID:        user.ID,
+			Type:      "private",
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			Username:  user.Username,
+		}
+	}
+}
+
+// AddChat registers the chat.
+func (s *Server) AddChat(chat *tgbot.Chat) *tgbot.Chat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.chats[chat.ID] = chat
+	return chat
+}
+
+// AddFile registers a file that can be sent by its id, fetched with getFile and downloaded.
+func (s *Server) AddFile(name string, data []byte) *tgbot.File {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.addFile(name, data)
+}
+
+func (s *Server) addFile(name string, data []byte) *tgbot.File {
+	s.lastFileID++
+	file := &tgbot.File{
+		FileID:       fmt.Sprintf("file%d", s.lastFileID),
+		FileUniqueID: fmt.Sprintf("unique%d", s.lastFileID),
+		FileSize:     int64(len(data)),
+		FilePath:     fmt.Sprintf("files/%d/%s", s.lastFileID, name),
+	}
+	s.files[file.FileID] = &fakeFile{file: file, data: data}
+	return file
+}
+
+// inputFile returns the file by its id or creates a new one for the url or upload.
+func (s *Server) inputFile(input interface{}, name string) *tgbot.File {
+	id, _ := input.(string)
+	if file, ok := s.files[id]; ok {
+		return file.file
+	}
+	return s.addFile(name, []byte(id))
+}
+
+func (s *Server) findChat(chatID string) (*tgbot.Chat, error) {
+	if id, err := strconv.ParseInt(chatID, 10, 64); err == nil {
+		if chat, ok := s.chats[id]; ok {
+			return chat, nil
+		}
+	}
+	if username, ok := strings.CutPrefix(chatID, "@"); ok {
+		for _, chat := range s.chats {
+			if chat.Username == username {
+				return chat, nil
+			}
+		}
+	}
+	return nil, badRequest("chat not found")
+}
+
+func (s *Server) findMessage(chatID string, messageID int64) (*tgbot.Message, error) {
+	chat, err := s.findChat(chatID)
+	if err != nil {
+		return nil, err
+	}
+	message, ok := s.messages[chat.ID][messageID]
+	if !ok {
+		return nil, badRequest("message not found")
+	}
+	return message, nil
+}
+
+func (s *Server) addMessage(chat *tgbot.Chat, message *tgbot.Message) *tgbot.Message {
+	s.lastMessageID[chat.ID]++
+	message.MessageID = s.lastMessageID[chat.ID]
+	message.Chat = chat
+	message.Date = s.Now().Unix()
+	message.EditDate = 0
+	if _, ok := s.messages[chat.ID]; !ok {
+		s.messages[chat.ID] = map[int64]*tgbot.Message{}
+	}
+	s.messages[chat.ID][message.MessageID] = message
+	return message
+}
+
+func (s *Server) sendBotMessage(chatID string, message *tgbot.Message, reply *tgbot.ReplyParameters) (*tgbot.Message, error) {
+	chat, err := s.findChat(chatID)
+	if err != nil {
+		return nil, err
+	}
+	if reply != nil {
+		replyTo, ok := s.messages[chat.ID][reply.MessageID]
+		if !ok && !reply.AllowSendingWithoutReply {
+			return nil, badRequest("message to be replied not found")
+		}
+		message.ReplyToMessage = replyTo
+	}
+	message.From = s.Bot
+	return s.addMessage(chat, message), nil
+}
+
+func (s *Server) editMessage(chatID string, messageID int64, edit func(message *tgbot.Message)) (*tgbot.Message, error) {
+	message, err := s.findMessage(chatID, messageID)
+	if err != nil {
+		return nil, badRequest("message to edit not found")
+	}
+	edit(message)
+	message.EditDate = s.Now().Unix()
+	return message, nil
+}
+
+func (s *Server) deleteMessages(chatID string, messageIDs []int64) error {
+	chat, err := s.findChat(chatID)
+	if err != nil {
+		return err
+	}
+	for _, id := range messageIDs {
+		if _, ok := s.messages[chat.ID][id]; !ok {
+			return badRequest("message to delete not found")
+		}
+		delete(s.messages[chat.ID], id)
+	}
+	return nil
+}
+
+// copyMessage returns a deep copy of the message, the server keeps changing the original one.
+// Must be called with the lock held.
+func copyMessage(message *tgbot.Message) *tgbot.Message {
+	if message == nil {
+		return nil
+	}
+	data, err := json.Marshal(message)
+	if err != nil {
+		panic(fmt.Sprintf("Cannot copy message: %s", err))
+	}
+	copied := &tgbot.Message{}
+	if err := json.Unmarshal(data, copied); err != nil {
+		panic(fmt.Sprintf("Cannot copy message: %s", err))
+	}
+	return copied
+}
+
+// Messages returns copies of the messages currently in the chat ordered by id.
+func (s *Server) Messages(chatID int64) []*tgbot.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := []*tgbot.Message{}
+	for id := int64(1); id <= s.lastMessageID[chatID]; id++ {
+		if message, ok := s.messages[chatID][id]; ok {
+			result = append(result, copyMessage(message))
+		}
+	}
+	return result
+}
+
+// Message returns a copy of the message or nil if it does not exist or was deleted.
+func (s *Server) Message(chatID int64, messageID int64) *tgbot.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return copyMessage(s.messages[chatID][messageID])
+}
+
+// AddUpdate queues the update for getUpdates, update id is assigned automatically.
+func (s *Server) AddUpdate(update *tgbot.Update) *tgbot.Update {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addUpdate(update)
+	return update
+}
+
+func (s *Server) addUpdate(update *tgbot.Update) {
+	s.lastUpdateID++
+	update.UpdateID = s.lastUpdateID
+	s.updates = append(s.updates, update)
+	close(s.updatesChanged)
+	s.updatesChanged = make(chan struct{})
+}
+
+// SendUserMessage adds a message from the user to the chat and queues it as an update, a copy
+// of the message is returned. Chat and user are registered if they are unknown.
+func (s *Server) SendUserMessage(chat *tgbot.Chat, from *tgbot.User, text string) *tgbot.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addUser(from)
+	if _, ok := s.chats[chat.ID]; !ok {
+		s.chats[chat.ID] = chat
+	}
+	message := s.addMessage(s.chats[chat.ID], &tgbot.Message{From: from, Text: text})
+	s.addUpdate(&tgbot.Update{Message: message})
+	return copyMessage(message)
+}
+
+// PressButton queues a callback query from the user pressing an inline button with the data.
+func (s *Server) PressButton(from *tgbot.User, data string) *tgbot.CallbackQuery {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.addUser(from)
+	s.lastCallbackID++
+	query := &tgbot.CallbackQuery{
+		ID:           strconv.FormatInt(s.lastCallbackID, 10),
+		From:         from,
+		ChatInstance: "test",
+		Data:         data,
+	}
+	s.callbacks[query.ID] = nil
+	s.addUpdate(&tgbot.Update{CallbackQuery: query})
+	return query
+}
+
+// CallbackAnswer returns a copy of the answerCallbackQuery request for the query, nil if not
+// answered.
+func (s *Server) CallbackAnswer(queryID string) *tgbot.AnswerCallbackQueryRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	answer := s.callbacks[queryID]
+	if answer == nil {
+		return nil
+	}
+	copied := *answer
+	return &copied
+}
+
+func (s *Server) pendingUpdates(request *tgbot.GetUpdatesRequest) ([]*tgbot.Update, chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if request.Offset > 0 {
+		// Updates before the offset are confirmed and never returned again
+		confirmed := 0
+		for confirmed < len(s.updates) && s.updates[confirmed].UpdateID < request.Offset {
+			confirmed++
+		}
+		s.updates = s.updates[confirmed:]
+	}
+	limit := int(request.Limit)
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	result := s.updates
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return append([]*tgbot.Update{}, result...), s.updatesChanged
+}
+
+func (s *Server) getUpdates(request *tgbot.GetUpdatesRequest) []*tgbot.Update {
+	updates, changed := s.pendingUpdates(request)
+	if len(updates) > 0 || request.Timeout <= 0 {
+		return updates
+	}
+	select {
+	case <-changed:
+	case <-time.After(request.TimeoutAsDuration()):
+	}
+	updates, _ = s.pendingUpdates(request)
+	return updates
+}
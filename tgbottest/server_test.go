@@ -0,0 +1,255 @@
+package tgbottest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lanseg/tgbot"
+	"github.com/lanseg/tgbot/tgbottest"
+)
+
+var (
+	testUser = &tgbot.User{ID: 100, FirstName: "Test", Username: "test_user"}
+	testChat = &tgbot.Chat{ID: 100, Type: "private"}
+)
+
+func newServerApi(t *testing.T) (*tgbottest.Server, *tgbot.TelegramApi) {
+	t.Helper()
+	server := tgbottest.NewServer()
+	t.Cleanup(server.Close)
+	bot, err := tgbot.NewCustomBot(server.URL, server.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return server, tgbot.NewTelegramApi(bot)
+}
+
+func TestSendMessage(t *testing.T) {
+	server, api := newServerApi(t)
+	server.AddUser(testUser)
+
+	sent, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "100", Text: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent.Result.MessageID != 1 || sent.Result.From.ID != server.Bot.ID || sent.Result.Chat.ID != 100 {
+		t.Errorf("Sent message %+v", sent.Result)
+	}
+	calls := server.CallsTo("sendMessage")
+	if len(calls) != 1 || !strings.Contains(string(calls[0].Response), `"text":"hello"`) {
+		t.Fatalf("Recorded calls %v", calls)
+	}
+	request := &tgbot.SendMessageRequest{}
+	if err := calls[0].Decode(request); err != nil || request.Text != "hello" {
+		t.Errorf("Decoded request %+v, %v", request, err)
+	}
+	if messages := server.Messages(100); len(messages) != 1 || messages[0].Text != "hello" {
+		t.Errorf("Chat has messages %v", messages)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	server, api := newServerApi(t)
+	if _, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "404", Text: "hello"}); err == nil ||
+		!strings.Contains(err.Error(), "chat not found") {
+		t.Errorf("Message to the unknown chat: %v", err)
+	}
+	if _, err := api.GetStickerSet(&tgbot.GetStickerSetRequest{Name: "stickers"}); err == nil {
+		t.Errorf("Unknown method succeeded")
+	}
+
+	server.Handle("getMe", func(json.RawMessage) (interface{}, error) {
+		return nil, &tgbottest.APIError{Code: 429, Description: "Too Many Requests"}
+	})
+	if _, err := api.GetMe(&tgbot.GetMeRequest{}); err == nil || !strings.Contains(err.Error(), "Too Many Requests") {
+		t.Errorf("Overridden getMe: %v", err)
+	}
+
+	bot, _ := tgbot.NewCustomBot(server.URL, "654321:OTHERTOKENothertokenOTHERTOKENother")
+	if _, err := tgbot.NewTelegramApi(bot).GetMe(&tgbot.GetMeRequest{}); err == nil {
+		t.Errorf("Unknown token is accepted")
+	}
+}
+
+func TestGetUpdates(t *testing.T) {
+	server, api := newServerApi(t)
+	done := make(chan []*tgbot.Update)
+	go func() {
+		updates, err := api.GetUpdates(&tgbot.GetUpdatesRequest{Timeout: 10})
+		if err != nil {
+			t.Error(err)
+		}
+		done <- updates.Result
+	}()
+	message := server.SendUserMessage(testChat, testUser, "/start")
+
+	updates := <-done
+	if len(updates) != 1 || updates[0].Message.Text != "/start" || updates[0].Message.MessageID != message.MessageID {
+		t.Fatalf("Got updates %v", updates)
+	}
+	next, err := api.GetUpdates(&tgbot.GetUpdatesRequest{Offset: updates[0].UpdateID + 1})
+	if err != nil || len(next.Result) != 0 {
+		t.Errorf("Confirmed updates are returned again: %v, %v", next.Result, err)
+	}
+}
+
+func TestCallbackAnswer(t *testing.T) {
+	server, api := newServerApi(t)
+	query := server.PressButton(testUser, "yes")
+	if answer := server.CallbackAnswer(query.ID); answer != nil {
+		t.Errorf("Query is answered before the answer: %v", answer)
+	}
+	if _, err := api.AnswerCallbackQuery(&tgbot.AnswerCallbackQueryRequest{CallbackQueryID: query.ID, Text: "ok"}); err != nil {
+		t.Fatal(err)
+	}
+	if answer := server.CallbackAnswer(query.ID); answer == nil || answer.Text != "ok" {
+		t.Errorf("Callback answer %v", answer)
+	}
+	if _, err := api.AnswerCallbackQuery(&tgbot.AnswerCallbackQueryRequest{CallbackQueryID: query.ID}); err == nil {
+		t.Errorf("Query is answered twice")
+	}
+}
+
+func TestAccessorsReturnCopies(t *testing.T) {
+	server, api := newServerApi(t)
+	sent := server.SendUserMessage(testChat, testUser, "original")
+	sent.Text = "changed"
+	server.Message(100, sent.MessageID).Text = "changed"
+	server.Messages(100)[0].Chat.Title = "changed"
+	server.Calls()
+
+	message := server.Message(100, sent.MessageID)
+	if message.Text != "original" || message.Chat.Title != "" {
+		t.Errorf("Server message is changed through the accessors: %+v", message)
+	}
+	if _, err := api.GetMe(&tgbot.GetMeRequest{}); err != nil {
+		t.Fatal(err)
+	}
+	server.Calls()[0].Method = "changed"
+	if calls := server.Calls(); calls[0].Method != "GetMe" {
+		t.Errorf("Recorded call is changed through Calls: %v", calls[0].Method)
+	}
+}
+
+// TestConcurrentCalls checks the server with the race detector: the handlers change the
+// messages that the other calls are marshaling or the test is reading.
+func TestConcurrentCalls(t *testing.T) {
+	server, api := newServerApi(t)
+	message := server.SendUserMessage(testChat, testUser, "message")
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				text := fmt.Sprintf("edit %d", j)
+				if _, err := api.EditMessageText(&tgbot.EditMessageTextRequest{
+					ChatID: "100", MessageID: message.MessageID, Text: text,
+				}); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				if _, err := api.SendMessage(&tgbot.SendMessageRequest{
+					ChatID:          "100",
+					Text:            "reply",
+					ReplyParameters: &tgbot.ReplyParameters{MessageID: message.MessageID},
+				}); err != nil {
+					t.Error(err)
+				}
+				if _, err := api.GetUpdates(&tgbot.GetUpdatesRequest{}); err != nil {
+					t.Error(err)
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				for _, message := range server.Messages(100) {
+					_ = message.Text
+				}
+				for _, call := range server.Calls() {
+					_ = len(call.Response)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if sent := len(server.CallsTo("sendMessage")); sent != 80 {
+		t.Errorf("Recorded %d sendMessage calls, want 80", sent)
+	}
+	if messages := server.Messages(100); len(messages) != 81 {
+		t.Errorf("Chat has %d messages, want 81", len(messages))
+	}
+}
+
+// blockingResult signals when the server starts marshaling it and waits for the release.
+type blockingResult struct {
+	message  *tgbot.Message
+	started  chan struct{}
+	released chan struct{}
+}
+
+func (r *blockingResult) MarshalJSON() ([]byte, error) {
+	close(r.started)
+	<-r.released
+	return json.Marshal(r.message)
+}
+
+// TestResultMarshaledUnderLock checks that the result is marshaled before the next call can
+// change it, run with -race to also see the data race.
+func TestResultMarshaledUnderLock(t *testing.T) {
+	server, api := newServerApi(t)
+	shared := &tgbot.Message{MessageID: 1, Text: "before"}
+	result := &blockingResult{message: shared, started: make(chan struct{}), released: make(chan struct{})}
+	server.Handle("sendMessage", func(json.RawMessage) (interface{}, error) {
+		return result, nil
+	})
+	server.Handle("setMyName", func(json.RawMessage) (interface{}, error) {
+		shared.Text = "after"
+		return true, nil
+	})
+
+	got := make(chan string)
+	go func() {
+		sent, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "100", Text: "before"})
+		if err != nil {
+			t.Error(err)
+		}
+		got <- sent.Result.Text
+	}()
+	<-result.started
+	changed := make(chan struct{})
+	go func() {
+		api.SetMyName(&tgbot.SetMyNameRequest{Name: "after"})
+		close(changed)
+	}()
+	select {
+	case <-changed:
+		t.Errorf("Result is changed while the server is marshaling it")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(result.released)
+	if text := <-got; text != "before" {
+		t.Errorf("Got text %q, want the text at the time of the call", text)
+	}
+	<-changed
+}
+
+func TestGetFile(t *testing.T) {
+	server, api := newServerApi(t)
+	file := server.AddFile("notes.txt", []byte("notes"))
+	got, err := api.GetFile(&tgbot.GetFileRequest{FileID: file.FileID})
+	if err != nil || got.Result.FilePath != file.FilePath || got.Result.FileSize != 5 {
+		t.Fatalf("getFile returned %v, %v", got, err)
+	}
+}
@@ -0,0 +1,28 @@
+package tgbot
+
+import "time"
+
+// Converters used by the generated accessors for "Unix time" and "in seconds" fields.
+// Zero value means "not set" in the API, so it maps to the zero time.Time and back.
+
+func unixToTime(unix int64) time.Time {
+	if unix == 0 {
+		return time.Time{}
+	}
+	return time.Unix(unix, 0)
+}
+
+func timeToUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func secondsToDuration(seconds int64) time.Duration {
+	return time.Duration(seconds) * time.Second
+}
+
+func durationToSeconds(d time.Duration) int64 {
+	return int64(d / time.Second)
+}
@@ -0,0 +1,39 @@
+package tgbot_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/lanseg/tgbot"
+)
+
+func TestTimeAccessors(t *testing.T) {
+	message := &tgbot.Message{Date: 1700000000}
+	if got := message.DateAsTime(); !got.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("DateAsTime() = %s", got)
+	}
+	if got := message.EditDateAsTime(); !got.IsZero() {
+		t.Errorf("EditDateAsTime() of the unset field = %s, want zero time", got)
+	}
+
+	request := &tgbot.BanChatMemberRequest{}
+	request.SetUntilDate(time.Unix(1700000000, 999))
+	if request.UntilDate != 1700000000 {
+		t.Errorf("SetUntilDate() set %d", request.UntilDate)
+	}
+	request.SetUntilDate(time.Time{})
+	if request.UntilDate != 0 {
+		t.Errorf("SetUntilDate(zero time) set %d, want 0", request.UntilDate)
+	}
+}
+
+func TestDurationAccessors(t *testing.T) {
+	request := &tgbot.GetUpdatesRequest{}
+	request.SetTimeout(90*time.Second + 500*time.Millisecond)
+	if request.Timeout != 90 {
+		t.Errorf("SetTimeout() set %d, want 90", request.Timeout)
+	}
+	if got := request.TimeoutAsDuration(); got != 90*time.Second {
+		t.Errorf("TimeoutAsDuration() = %s", got)
+	}
+}
@@ -0,0 +1,115 @@
+package tgbot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var tokenFormat = regexp.MustCompile(`^[0-9]+:[A-Za-z0-9_-]{30,}$`)
+
+// Token is a bot token that never shows its secret part when printed, logged or marshaled.
+type Token string
+
+// ParseToken checks that the token looks like "<bot id>:<secret>" as issued by @BotFather.
+func ParseToken(token string) (Token, error) {
+	token = strings.TrimSpace(token)
+	if !tokenFormat.MatchString(token) {
+		return "", fmt.Errorf("Token %s has invalid format, expected <bot id>:<secret>", Token(token))
+	}
+	return Token(token), nil
+}
+
+// TokenFromEnv reads the token from the environment variable.
+func TokenFromEnv(name string) (Token, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("Environment variable %s is not set", name)
+	}
+	return ParseToken(value)
+}
+
+// TokenFromFile reads the token from the file, surrounding whitespace is ignored.
+func TokenFromFile(path string) (Token, error) {
+	value, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return ParseToken(string(value))
+}
+
+// BotID returns the public part of the token.
+func (t Token) BotID() string {
+	id, _, found := strings.Cut(string(t), ":")
+	if !found {
+		return ""
+	}
+	return id
+}
+
+func (t Token) String() string {
+	if id := t.BotID(); id != "" {
+		return id + ":<redacted>"
+	}
+	return "<redacted>"
+}
+
+func (t Token) GoString() string {
+	return t.String()
+}
+
+func (t Token) Format(f fmt.State, verb rune) {
+	fmt.Fprint(f, t.String())
+}
+
+func (t Token) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.String())
+}
+
+func (t Token) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t Token) LogValue() slog.Value {
+	return slog.StringValue(t.String())
+}
+
+// redactedError keeps the original error for errors.Is and errors.As, but hides the token
+// from the message.
+type redactedError struct {
+	err     error
+	message string
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+func redactString(value string, token string) string {
+	value = strings.ReplaceAll(value, token, "<token>")
+	return strings.ReplaceAll(value, url.PathEscape(token), "<token>")
+}
+
+func redactError(err error, token string) error {
+	if err == nil || token == "" {
+		return err
+	}
+	message := redactString(err.Error(), token)
+	if message == err.Error() {
+		return err
+	}
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		urlErr.URL = redactString(urlErr.URL, token)
+	}
+	return &redactedError{err: err, message: message}
+}
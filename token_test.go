@@ -0,0 +1,109 @@
+package tgbot_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/lanseg/tgbot"
+	"github.com/lanseg/tgbot/tgbottest"
+)
+
+const secret = "TESTTOKENtesttokenTESTTOKENtesttoken"
+
+func TestParseToken(t *testing.T) {
+	token, err := tgbot.ParseToken(" " + tgbottest.Token + "\n")
+	if err != nil {
+		t.Fatalf("ParseToken failed: %s", err)
+	}
+	if string(token) != tgbottest.Token || token.BotID() != "123456" {
+		t.Errorf("Parsed token %q with bot id %q", string(token), token.BotID())
+	}
+	for _, invalid := range []string{"", "123456", "bot:" + secret, "123456:short", "123456:" + secret + "!"} {
+		_, err := tgbot.ParseToken(invalid)
+		if err == nil {
+			t.Errorf("ParseToken(%q) succeeded", invalid)
+		} else if strings.Contains(err.Error(), secret) {
+			t.Errorf("Error shows the token: %s", err)
+		}
+	}
+}
+
+func TestTokenIsRedacted(t *testing.T) {
+	token := tgbot.Token(tgbottest.Token)
+	log := &bytes.Buffer{}
+	slog.New(slog.NewTextHandler(log, nil)).Info("token", "token", token)
+	jsonToken, _ := json.Marshal(struct{ Token tgbot.Token }{token})
+	for _, printed := range []string{
+		fmt.Sprint(token), fmt.Sprintf("%s %v %q %#v", token, token, token, token),
+		string(jsonToken), log.String(),
+	} {
+		if strings.Contains(printed, secret) || !strings.Contains(printed, "redacted") {
+			t.Errorf("Token is printed as %s", printed)
+		}
+	}
+}
+
+func TestTokenFromEnvAndFile(t *testing.T) {
+	t.Setenv("TGBOT_TEST_TOKEN", tgbottest.Token)
+	if token, err := tgbot.TokenFromEnv("TGBOT_TEST_TOKEN"); err != nil || string(token) != tgbottest.Token {
+		t.Errorf("TokenFromEnv() = %v, %v", token, err)
+	}
+	if _, err := tgbot.TokenFromEnv("TGBOT_TEST_MISSING_TOKEN"); err == nil {
+		t.Errorf("TokenFromEnv succeeded for a missing variable")
+	}
+
+	path := filepath.Join(t.TempDir(), "token.txt")
+	if err := os.WriteFile(path, []byte(tgbottest.Token+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if token, err := tgbot.TokenFromFile(path); err != nil || string(token) != tgbottest.Token {
+		t.Errorf("TokenFromFile() = %v, %v", token, err)
+	}
+}
+
+func TestBotConstructorsCheckToken(t *testing.T) {
+	server := tgbottest.NewServer()
+	defer server.Close()
+	constructors := map[string]func(token string) (tgbot.TelegramBot, error){
+		"NewBot": func(token string) (tgbot.TelegramBot, error) { return tgbot.NewBot(token) },
+		"NewCustomBot": func(token string) (tgbot.TelegramBot, error) {
+			return tgbot.NewCustomBot(server.URL, token)
+		},
+		"NewLocalBot": func(token string) (tgbot.TelegramBot, error) {
+			return tgbot.NewLocalBot(server.URL, token)
+		},
+	}
+	for name, newBot := range constructors {
+		if _, err := newBot("not a token"); err == nil {
+			t.Errorf("%s accepted an invalid token", name)
+		}
+	}
+
+	// The whitespace around the token is dropped, otherwise the server would not know the bot
+	bot, err := tgbot.NewCustomBot(server.URL, tgbottest.Token+"\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tgbot.NewTelegramApi(bot).GetMe(&tgbot.GetMeRequest{}); err != nil {
+		t.Errorf("GetMe with the parsed token failed: %s", err)
+	}
+}
+
+func TestQueryErrorIsRedacted(t *testing.T) {
+	server := tgbottest.NewServer()
+	server.Close()
+	bot, err := tgbot.NewCustomBot(server.URL, tgbottest.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = tgbot.NewTelegramApi(bot).GetMe(&tgbot.GetMeRequest{})
+	if err == nil || strings.Contains(err.Error(), secret) {
+		t.Errorf("Error of the failed call: %v", err)
+	}
+}
@@ -0,0 +1,138 @@
+package tgbot
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+)
+
+// Span attributes set by the tracing interceptor and handler.
+const (
+	AttributeMethod       = "tgbot.method"
+	AttributeChatID       = "tgbot.chat_id"
+	AttributeUpdateID     = "tgbot.update_id"
+	AttributeUpdateType   = "tgbot.update_type"
+	AttributeErrorCode    = "tgbot.error_code"
+	AttributeRetryAttempt = "tgbot.retry_attempt"
+)
+
+// Span is a single traced operation. An OpenTelemetry adapter wraps trace.Span, converting
+// attribute values with attribute.String, attribute.Int64, etc.
+type Span interface {
+	SetAttribute(key string, value any)
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts spans, the returned context carries the span so the nested spans become its
+// children. An OpenTelemetry adapter calls otel.Tracer(...).Start and wraps the span.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(string, any) {}
+func (noopSpan) RecordError(error)        {}
+func (noopSpan) End()                     {}
+
+type noopTracer struct{}
+
+func (noopTracer) Start(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// NoopTracer is a Tracer that does nothing.
+var NoopTracer Tracer = noopTracer{}
+
+type updateIDKey struct{}
+
+type retryAttemptKey struct{}
+
+// WithRetryAttempt marks the calls made with the context as the retry number attempt, so the
+// retries of the same call can be told apart in the traces.
+func WithRetryAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, retryAttemptKey{}, attempt)
+}
+
+// RetryAttempt returns the number of the retry set with WithRetryAttempt, 0 for the first
+// attempt.
+func RetryAttempt(ctx context.Context) int {
+	attempt, _ := ctx.Value(retryAttemptKey{}).(int)
+	return attempt
+}
+
+// requestChatID returns the chat_id of the request, if it has one. The chat id is a string in
+// the most requests and an integer in a few, e.g. in SendGameRequest.
+func requestChatID(request interface{}) string {
+	value := reflect.ValueOf(request)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return ""
+	}
+	chatID := value.Elem().FieldByName("ChatID")
+	if !chatID.IsValid() {
+		return ""
+	}
+	switch chatID.Kind() {
+	case reflect.String:
+		return chatID.String()
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		if chatID.Int() != 0 {
+			return strconv.FormatInt(chatID.Int(), 10)
+		}
+	}
+	return ""
+}
+
+// TracingInterceptor starts a span named after the API method for every call. When the call is
+// made with the context of a traced update (TelegramApi.WithContext), the span is a child of the
+// update span and has its update id.
+func TracingInterceptor(tracer Tracer) Interceptor {
+	if tracer == nil {
+		tracer = NoopTracer
+	}
+	return func(next QueryFunc) QueryFunc {
+		return func(ctx context.Context, apiMethod string, request interface{}) ([]byte, error) {
+			ctx, span := tracer.Start(ctx, apiMethod)
+			defer span.End()
+
+			span.SetAttribute(AttributeMethod, apiMethod)
+			if chatID := requestChatID(request); chatID != "" {
+				span.SetAttribute(AttributeChatID, chatID)
+			}
+			if updateID, ok := ctx.Value(updateIDKey{}).(int64); ok {
+				span.SetAttribute(AttributeUpdateID, updateID)
+			}
+			if attempt := RetryAttempt(ctx); attempt > 0 {
+				span.SetAttribute(AttributeRetryAttempt, attempt)
+			}
+
+			response, err := next(ctx, apiMethod, request)
+			if err != nil {
+				span.RecordError(err)
+			} else if code, _ := responseError(response); code != 0 {
+				span.SetAttribute(AttributeErrorCode, code)
+			}
+			return response, err
+		}
+	}
+}
+
+// TraceHandler starts a span for every handled update, the span is in the handler context.
+func TraceHandler(tracer Tracer, handler UpdateHandler) UpdateHandler {
+	if tracer == nil {
+		tracer = NoopTracer
+	}
+	return func(ctx context.Context, update *Update) {
+		kind := string(update.Kind())
+		ctx, span := tracer.Start(ctx, "update "+kind)
+		defer span.End()
+
+		span.SetAttribute(AttributeUpdateID, update.UpdateID)
+		span.SetAttribute(AttributeUpdateType, kind)
+		if chatID := updateChatID(update); chatID != 0 {
+			span.SetAttribute(AttributeChatID, strconv.FormatInt(chatID, 10))
+		}
+		handler(context.WithValue(ctx, updateIDKey{}, update.UpdateID), update)
+	}
+}
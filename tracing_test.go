@@ -0,0 +1,131 @@
+package tgbot_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/lanseg/tgbot"
+)
+
+type testSpan struct {
+	name       string
+	parent     *testSpan
+	attributes map[string]any
+	errors     []error
+	ended      bool
+}
+
+func (s *testSpan) SetAttribute(key string, value any) { s.attributes[key] = value }
+func (s *testSpan) RecordError(err error)              { s.errors = append(s.errors, err) }
+func (s *testSpan) End()                               { s.ended = true }
+
+type spanKey struct{}
+
+type testTracer struct {
+	mu    sync.Mutex
+	spans []*testSpan
+}
+
+func (t *testTracer) Start(ctx context.Context, name string) (context.Context, tgbot.Span) {
+	parent, _ := ctx.Value(spanKey{}).(*testSpan)
+	span := &testSpan{name: name, parent: parent, attributes: map[string]any{}}
+	t.mu.Lock()
+	t.spans = append(t.spans, span)
+	t.mu.Unlock()
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func TestTracingInterceptor(t *testing.T) {
+	tests := []struct {
+		name    string
+		request interface{}
+		fake    *fakeQuery
+		want    map[string]any
+	}{
+		{
+			name:    "string chat id",
+			request: &tgbot.SendMessageRequest{ChatID: "@channel"},
+			fake:    &fakeQuery{response: `{"ok":true}`},
+			want:    map[string]any{tgbot.AttributeMethod: "sendMessage", tgbot.AttributeChatID: "@channel"},
+		},
+		{
+			name:    "integer chat id",
+			request: &tgbot.SendGameRequest{ChatID: 42},
+			fake:    &fakeQuery{response: `{"ok":false,"error_code":400}`},
+			want: map[string]any{tgbot.AttributeMethod: "sendMessage", tgbot.AttributeChatID: "42",
+				tgbot.AttributeErrorCode: int64(400)},
+		},
+		{
+			name:    "no chat id",
+			request: &tgbot.GetMeRequest{},
+			fake:    &fakeQuery{response: `{"ok":true}`},
+			want:    map[string]any{tgbot.AttributeMethod: "sendMessage"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tracer := &testTracer{}
+			query := tgbot.Chain(tc.fake.query, tgbot.TracingInterceptor(tracer))
+			query(context.Background(), "sendMessage", tc.request)
+
+			if len(tracer.spans) != 1 || !tracer.spans[0].ended {
+				t.Fatalf("Got spans %v", tracer.spans)
+			}
+			attributes := tracer.spans[0].attributes
+			if len(attributes) != len(tc.want) {
+				t.Errorf("Got attributes %v, want %v", attributes, tc.want)
+			}
+			for key, value := range tc.want {
+				if attributes[key] != value {
+					t.Errorf("Attribute %s is %v (%T), want %v", key, attributes[key], attributes[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestTracingInterceptorRecordsErrorAndRetry(t *testing.T) {
+	tracer := &testTracer{}
+	fake := &fakeQuery{err: errors.New("timeout")}
+	query := tgbot.Chain(fake.query, tgbot.TracingInterceptor(tracer))
+	query(tgbot.WithRetryAttempt(context.Background(), 2), "getMe", &tgbot.GetMeRequest{})
+
+	span := tracer.spans[0]
+	if len(span.errors) != 1 || span.attributes[tgbot.AttributeRetryAttempt] != 2 {
+		t.Errorf("Span has errors %v and attributes %v", span.errors, span.attributes)
+	}
+}
+
+func TestTraceHandler(t *testing.T) {
+	tracer := &testTracer{}
+	query := tgbot.Chain((&fakeQuery{response: `{"ok":true}`}).query, tgbot.TracingInterceptor(tracer))
+	handler := tgbot.TraceHandler(tracer, func(ctx context.Context, update *tgbot.Update) {
+		query(ctx, "sendMessage", &tgbot.SendMessageRequest{ChatID: "7"})
+	})
+	handler(context.Background(), &tgbot.Update{
+		UpdateID: 100,
+		Message:  &tgbot.Message{Chat: &tgbot.Chat{ID: 7}},
+	})
+
+	if len(tracer.spans) != 2 {
+		t.Fatalf("Got %d spans", len(tracer.spans))
+	}
+	update, call := tracer.spans[0], tracer.spans[1]
+	if update.name != "update message" || update.attributes[tgbot.AttributeChatID] != "7" ||
+		update.attributes[tgbot.AttributeUpdateType] != "message" {
+		t.Errorf("Update span %s has attributes %v", update.name, update.attributes)
+	}
+	if call.parent != update || call.attributes[tgbot.AttributeUpdateID] != int64(100) {
+		t.Errorf("Call span is not correlated with the update: %v", call.attributes)
+	}
+}
+
+func TestNoopTracer(t *testing.T) {
+	fake := &fakeQuery{response: `{"ok":true}`}
+	query := tgbot.Chain(fake.query, tgbot.TracingInterceptor(nil))
+	if _, err := query(context.Background(), "getMe", nil); err != nil || len(fake.methods) != 1 {
+		t.Errorf("Query with the noop tracer failed: %v", err)
+	}
+}
@@ -0,0 +1,235 @@
+package tgbot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+)
+
+// UpdateContext is an update together with the api bound to the handler context. The shortcuts
+// respond in the chat and the forum topic of the update, replies quote the received message.
+type UpdateContext struct {
+	context.Context
+
+	Update *Update
+	// Api makes the calls with the handler context
+	Api TelegramApiInterface
+}
+
+// NewUpdateContext binds the api to the context of the update.
+func NewUpdateContext(ctx context.Context, api TelegramApiInterface, update *Update) *UpdateContext {
+	return &UpdateContext{Context: ctx, Update: update, Api: api.WithContext(ctx)}
+}
+
+// ContextHandler returns an UpdateHandler that calls the handler with the UpdateContext.
+func ContextHandler(api TelegramApiInterface, handler func(c *UpdateContext)) UpdateHandler {
+	return func(ctx context.Context, update *Update) {
+		handler(NewUpdateContext(ctx, api, update))
+	}
+}
+
+// Chat returns the chat the update happened in, nil if there is none, e.g. for inline queries.
+func (c *UpdateContext) Chat() *Chat {
+	return c.Update.EffectiveChat()
+}
+
+// User returns the user who caused the update, nil if it is unknown, e.g. for channel posts.
+func (c *UpdateContext) User() *User {
+	return c.Update.EffectiveUser()
+}
+
+// SenderChat returns the chat on behalf of which the user acted, nil if they acted as themselves.
+func (c *UpdateContext) SenderChat() *Chat {
+	return c.Update.EffectiveSenderChat()
+}
+
+// Message returns the received message or post, or the message with the pressed button. Nil for
+// the other updates and the inaccessible callback messages.
+func (c *UpdateContext) Message() *Message {
+	return c.Update.EffectiveMessage()
+}
+
+func (c *UpdateContext) chatID() (string, error) {
+	chat := c.Chat()
+	if chat == nil {
+		return "", fmt.Errorf("Update %d has no chat", c.Update.UpdateID)
+	}
+	return strconv.FormatInt(chat.ID, 10), nil
+}
+
+// threadID returns the forum topic of the update message, zero outside of the topics.
+func (c *UpdateContext) threadID() int64 {
+	if message := c.Message(); message != nil && message.IsTopicMessage {
+		return message.MessageThreadID
+	}
+	return 0
+}
+
+// messageID returns the chat and the id of the update message.
+func (c *UpdateContext) messageID() (string, int64, error) {
+	chatID, err := c.chatID()
+	if err != nil {
+		return "", 0, err
+	}
+	if query := c.Update.CallbackQuery; query != nil && query.Message != nil {
+		return chatID, maybeMessageID(query.Message), nil
+	}
+	if message := c.Message(); message != nil {
+		return chatID, message.MessageID, nil
+	}
+	return "", 0, fmt.Errorf("Update %d has no message", c.Update.UpdateID)
+}
+
+// replyParameters quotes the received message, callback queries are answered without a quote.
+func (c *UpdateContext) replyParameters() *ReplyParameters {
+	if c.Update.CallbackQuery != nil {
+		return nil
+	}
+	message := c.Message()
+	if message == nil {
+		return nil
+	}
+	return &ReplyParameters{MessageID: message.MessageID, AllowSendingWithoutReply: true}
+}
+
+// Reply sends the text to the chat of the update.
+func (c *UpdateContext) Reply(text string) (*Message, error) {
+	return c.ReplyWith(&SendMessageRequest{Text: text})
+}
+
+// ReplyWith sends the message to the chat of the update: unless the request has a chat, the
+// chat, the topic and the reply parameters of the update are filled.
+func (c *UpdateContext) ReplyWith(request *SendMessageRequest) (*Message, error) {
+	if request.ChatID == "" {
+		chatID, err := c.chatID()
+		if err != nil {
+			return nil, err
+		}
+		request.ChatID = chatID
+		if request.MessageThreadID == 0 {
+			request.MessageThreadID = c.threadID()
+		}
+		if request.ReplyParameters == nil {
+			request.ReplyParameters = c.replyParameters()
+		}
+	}
+	response, err := c.Api.SendMessage(request)
+	if err != nil {
+		return nil, err
+	}
+	return response.Result, nil
+}
+
+// ReplyPhoto sends the photo, a file_id or an URL, to the chat of the update.
+func (c *UpdateContext) ReplyPhoto(photo string, caption string) (*Message, error) {
+	return c.ReplyPhotoWith(&SendPhotoRequest{Photo: photo, Caption: caption})
+}
+
+// ReplyPhotoWith sends the photo like ReplyWith.
+func (c *UpdateContext) ReplyPhotoWith(request *SendPhotoRequest) (*Message, error) {
+	if request.ChatID == "" {
+		chatID, err := c.chatID()
+		if err != nil {
+			return nil, err
+		}
+		request.ChatID = chatID
+		if request.MessageThreadID == 0 {
+			request.MessageThreadID = c.threadID()
+		}
+		if request.ReplyParameters == nil {
+			request.ReplyParameters = c.replyParameters()
+		}
+	}
+	response, err := c.Api.SendPhoto(request)
+	if err != nil {
+		return nil, err
+	}
+	return response.Result, nil
+}
+
+// Edit replaces the text of the message with the pressed button.
+func (c *UpdateContext) Edit(text string) (*Message, error) {
+	return c.EditWith(&EditMessageTextRequest{Text: text})
+}
+
+// EditWith edits the message with the pressed button, including the messages sent in the inline
+// mode, for which the result is nil.
+func (c *UpdateContext) EditWith(request *EditMessageTextRequest) (*Message, error) {
+	query := c.Update.CallbackQuery
+	if query == nil {
+		return nil, fmt.Errorf("Update %d is not a callback query", c.Update.UpdateID)
+	}
+	if query.InlineMessageID != "" {
+		request.InlineMessageID = query.InlineMessageID
+		// Inline messages are edited with True as the result
+		if api, ok := c.Api.(*TelegramApi); ok {
+			_, err := queryAndUnmarshal[bool](api.bot, "EditMessageText", request)
+			return nil, err
+		}
+		_, err := c.Api.EditMessageText(request)
+		return nil, err
+	}
+	chatID, messageID, err := c.messageID()
+	if err != nil {
+		return nil, err
+	}
+	request.ChatID, request.MessageID = chatID, messageID
+	response, err := c.Api.EditMessageText(request)
+	if err != nil {
+		return nil, err
+	}
+	return response.Result, nil
+}
+
+// React sets the emoji reaction on the update message, an empty emoji removes the reaction.
+func (c *UpdateContext) React(emoji string) error {
+	chatID, messageID, err := c.messageID()
+	if err != nil {
+		return err
+	}
+	request := &SetMessageReactionRequest{ChatID: chatID, MessageID: messageID}
+	if emoji != "" {
+		request.Reaction = []*ReactionType{{Type: "emoji", Emoji: emoji}}
+	}
+	_, err = c.Api.SetMessageReaction(request)
+	return err
+}
+
+// AnswerCallback answers the callback query, the text is shown as a notification or as an alert.
+func (c *UpdateContext) AnswerCallback(text string, alert bool) error {
+	query := c.Update.CallbackQuery
+	if query == nil {
+		return fmt.Errorf("Update %d is not a callback query", c.Update.UpdateID)
+	}
+	_, err := c.Api.AnswerCallbackQuery(&AnswerCallbackQueryRequest{
+		CallbackQueryID: query.ID,
+		Text:            text,
+		ShowAlert:       alert,
+	})
+	return err
+}
+
+// Delete deletes the update message, for callback queries the message with the button.
+func (c *UpdateContext) Delete() error {
+	chatID, messageID, err := c.messageID()
+	if err != nil {
+		return err
+	}
+	_, err = c.Api.DeleteMessage(&DeleteMessageRequest{ChatID: chatID, MessageID: messageID})
+	return err
+}
+
+// SendChatAction shows the action, e.g. "typing" or "upload_photo", in the chat and the topic of
+// the update.
+func (c *UpdateContext) SendChatAction(action string) error {
+	chatID, err := c.chatID()
+	if err != nil {
+		return err
+	}
+	_, err = c.Api.SendChatAction(&SendChatActionRequest{
+		ChatID:          chatID,
+		MessageThreadID: c.threadID(),
+		Action:          action,
+	})
+	return err
+}
@@ -0,0 +1,168 @@
+package tgbot_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/lanseg/tgbot"
+	"github.com/lanseg/tgbot/tgbottest"
+)
+
+func TestUpdateContextReply(t *testing.T) {
+	server, api := newTestApi(t)
+	chat := &tgbot.Chat{ID: 10, Type: "supergroup", IsForum: true}
+	message := server.SendUserMessage(chat, &tgbot.User{ID: 20, FirstName: "User"}, "hi")
+	message.IsTopicMessage, message.MessageThreadID = true, 3
+	c := tgbot.NewUpdateContext(context.Background(), api, &tgbot.Update{UpdateID: 1, Message: message})
+
+	reply, err := c.Reply("hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Chat.ID != chat.ID || reply.MessageThreadID != 3 || reply.ReplyToMessage == nil ||
+		reply.ReplyToMessage.MessageID != message.MessageID {
+		t.Errorf("Reply is not a quote in the topic: %+v", reply)
+	}
+
+	// The request with a chat is sent as is.
+	request := &tgbot.SendMessageRequest{ChatID: "10", Text: "to the chat"}
+	if _, err := c.ReplyWith(request); err != nil {
+		t.Fatal(err)
+	}
+	if request.MessageThreadID != 0 || request.ReplyParameters != nil {
+		t.Errorf("ReplyWith changed the request with a chat: %+v", request)
+	}
+}
+
+func TestUpdateContextCallback(t *testing.T) {
+	server, api := newTestApi(t)
+	chat := server.AddChat(&tgbot.Chat{ID: 10, Type: "private"})
+	sent, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "10", Text: "menu"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := server.PressButton(&tgbot.User{ID: 20, FirstName: "User"}, "edit")
+	query.Message = sent.Result
+	c := tgbot.NewUpdateContext(context.Background(), api, &tgbot.Update{UpdateID: 1, CallbackQuery: query})
+
+	if message := c.Message(); message == nil || message.MessageID != sent.Result.MessageID {
+		t.Errorf("Message() = %+v, want the message with the button", message)
+	}
+	if _, err := c.Edit("edited"); err != nil {
+		t.Fatal(err)
+	}
+	if message := server.Message(chat.ID, sent.Result.MessageID); message.Text != "edited" {
+		t.Errorf("Message text is %q after Edit", message.Text)
+	}
+	if err := c.React("👍"); err != nil {
+		t.Fatal(err)
+	}
+	reaction := &tgbot.SetMessageReactionRequest{}
+	if calls := server.CallsTo("setMessageReaction"); len(calls) != 1 || calls[0].Decode(reaction) != nil ||
+		reaction.MessageID != sent.Result.MessageID || len(reaction.Reaction) != 1 || reaction.Reaction[0].Emoji != "👍" {
+		t.Errorf("React sent %+v", reaction)
+	}
+	if err := c.AnswerCallback("done", true); err != nil {
+		t.Fatal(err)
+	}
+	if answer := server.CallbackAnswer(query.ID); answer == nil || answer.Text != "done" || !answer.ShowAlert {
+		t.Errorf("Callback answer %+v", answer)
+	}
+	if err := c.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if message := server.Message(chat.ID, sent.Result.MessageID); message != nil {
+		t.Errorf("Message is not deleted: %+v", message)
+	}
+}
+
+func TestUpdateContextInaccessibleMessage(t *testing.T) {
+	server, api := newTestApi(t)
+	chat := server.AddChat(&tgbot.Chat{ID: 10, Type: "private"})
+	sent, err := api.SendMessage(&tgbot.SendMessageRequest{ChatID: "10", Text: "old"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The message is too old, only its chat and id are known.
+	query := &tgbot.CallbackQuery{ID: "1", Message: &tgbot.InaccessibleMessage{MessageID: sent.Result.MessageID, Chat: chat}}
+	c := tgbot.NewUpdateContext(context.Background(), api, &tgbot.Update{UpdateID: 1, CallbackQuery: query})
+
+	if message := c.Message(); message != nil {
+		t.Errorf("Message() = %+v for an inaccessible message", message)
+	}
+	if err := c.Delete(); err != nil {
+		t.Fatal(err)
+	}
+	if message := server.Message(chat.ID, sent.Result.MessageID); message != nil {
+		t.Errorf("Inaccessible message is not deleted")
+	}
+}
+
+func TestUpdateContextInlineEdit(t *testing.T) {
+	server, api := newTestApi(t)
+	server.Handle("editMessageText", func(json.RawMessage) (interface{}, error) {
+		return true, nil
+	})
+	query := &tgbot.CallbackQuery{ID: "1", InlineMessageID: "inline"}
+	c := tgbot.NewUpdateContext(context.Background(), api, &tgbot.Update{UpdateID: 1, CallbackQuery: query})
+
+	if message, err := c.Edit("edited"); message != nil || err != nil {
+		t.Fatalf("Edit of an inline message = %+v, %v", message, err)
+	}
+	request := &tgbot.EditMessageTextRequest{}
+	if calls := server.CallsTo("editMessageText"); len(calls) != 1 || calls[0].Decode(request) != nil ||
+		request.InlineMessageID != "inline" || request.ChatID != "" {
+		t.Errorf("Edit sent %+v", request)
+	}
+}
+
+func TestUpdateContextErrors(t *testing.T) {
+	server, api := newTestApi(t)
+	update := &tgbot.Update{UpdateID: 1, InlineQuery: &tgbot.InlineQuery{ID: "1", From: &tgbot.User{ID: 20}}}
+	var got *tgbot.UpdateContext
+	tgbot.ContextHandler(api, func(c *tgbot.UpdateContext) {
+		got = c
+	})(context.Background(), update)
+	if got == nil || got.Update != update || got.User().ID != 20 {
+		t.Fatalf("ContextHandler passed %+v", got)
+	}
+
+	for name, call := range map[string]func() error{
+		"Reply":          func() error { _, err := got.Reply("hi"); return err },
+		"Edit":           func() error { _, err := got.Edit("hi"); return err },
+		"React":          func() error { return got.React("👍") },
+		"Delete":         got.Delete,
+		"AnswerCallback": func() error { return got.AnswerCallback("hi", false) },
+		"SendChatAction": func() error { return got.SendChatAction("typing") },
+	} {
+		if err := call(); err == nil {
+			t.Errorf("%s succeeded for an inline query", name)
+		}
+	}
+	if calls := server.Calls(); len(calls) != 0 {
+		t.Errorf("Api is called %d times, first %s", len(calls), calls[0].Method)
+	}
+}
+
+func TestContextHandlerMockApi(t *testing.T) {
+	api := tgbottest.NewMockApi()
+	handler := tgbot.ContextHandler(api, func(c *tgbot.UpdateContext) {
+		if _, err := c.Reply("hello"); err != nil {
+			t.Errorf("Reply failed: %s", err)
+		}
+		if _, err := c.Edit("edited"); err != nil {
+			t.Errorf("Edit of an inline message failed: %s", err)
+		}
+	})
+	message := &tgbot.Message{MessageID: 5, Chat: &tgbot.Chat{ID: 10, Type: "private"}}
+	handler(context.Background(), &tgbot.Update{UpdateID: 1, CallbackQuery: &tgbot.CallbackQuery{
+		ID: "1", Message: message, InlineMessageID: "inline"}})
+
+	tgbottest.AssertCalled(t, api.SendMessageCalls(), func(r *tgbot.SendMessageRequest) bool {
+		return r.ChatID == "10" && r.Text == "hello"
+	})
+	tgbottest.AssertCalled(t, api.EditMessageTextCalls(), func(r *tgbot.EditMessageTextRequest) bool {
+		return r.InlineMessageID == "inline" && r.Text == "edited"
+	})
+}
@@ -0,0 +1,66 @@
+package tgbot
+
+import (
+	"context"
+	"strings"
+)
+
+// UpdateHandler processes a single update received with getUpdates or a webhook.
+type UpdateHandler func(ctx context.Context, update *Update)
+
+// accessibleMessage returns the message of a callback query as Message, nil if the message is
+// too old and inaccessible to the bot.
+func accessibleMessage(message MaybeInaccessibleMessage) *Message {
+	if message, ok := message.(*Message); ok {
+		return message
+	}
+	return nil
+}
+
+// maybeMessageChat returns the chat of the message, accessible or not.
+func maybeMessageChat(message MaybeInaccessibleMessage) *Chat {
+	switch message := message.(type) {
+	case *Message:
+		return message.Chat
+	case *InaccessibleMessage:
+		return message.Chat
+	}
+	return nil
+}
+
+// maybeMessageID returns the id of the message, accessible or not.
+func maybeMessageID(message MaybeInaccessibleMessage) int64 {
+	switch message := message.(type) {
+	case *Message:
+		return message.MessageID
+	case *InaccessibleMessage:
+		return message.MessageID
+	}
+	return 0
+}
+
+// updateChatID returns the id of the update chat, zero if there is no chat.
+func updateChatID(update *Update) int64 {
+	if chat := update.EffectiveChat(); chat != nil {
+		return chat.ID
+	}
+	return 0
+}
+
+// updateUserID returns the id of the update user, zero if it is unknown.
+func updateUserID(update *Update) int64 {
+	if user := update.EffectiveUser(); user != nil {
+		return user.ID
+	}
+	return 0
+}
+
+// commandName returns the bot command the text starts with, e.g. "/start" for "/start@bot now",
+// empty if the text is not a command.
+func commandName(text string) string {
+	if !strings.HasPrefix(text, "/") {
+		return ""
+	}
+	command, _, _ := strings.Cut(strings.Fields(text)[0], "@")
+	return command
+}
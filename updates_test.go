@@ -0,0 +1,132 @@
+package tgbot_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/lanseg/tgbot"
+)
+
+func TestUpdateAccessors(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		update       string
+		kind         tgbot.UpdateKind
+		chatID       int64
+		userID       int64
+		senderChatID int64
+		messageID    int64
+	}{
+		{
+			name:   "message",
+			update: `{"message": {"message_id": 1, "date": 1, "chat": {"id": 10}, "from": {"id": 20}}}`,
+			kind:   tgbot.UpdateKindMessage, chatID: 10, userID: 20, messageID: 1,
+		},
+		{
+			name:   "channel post",
+			update: `{"channel_post": {"message_id": 2, "date": 1, "chat": {"id": -100}, "sender_chat": {"id": -100}}}`,
+			kind:   tgbot.UpdateKindChannelPost, chatID: -100, senderChatID: -100, messageID: 2,
+		},
+		{
+			name:   "callback query",
+			update: `{"callback_query": {"id": "1", "from": {"id": 20}, "message": {"message_id": 3, "date": 1, "chat": {"id": 10}}}}`,
+			kind:   tgbot.UpdateKindCallbackQuery, chatID: 10, userID: 20, messageID: 3,
+		},
+		{
+			name:   "callback query with an inaccessible message",
+			update: `{"callback_query": {"id": "1", "from": {"id": 20}, "message": {"message_id": 3, "date": 0, "chat": {"id": 10}}}}`,
+			kind:   tgbot.UpdateKindCallbackQuery, chatID: 10, userID: 20,
+		},
+		{
+			name:   "inline callback query",
+			update: `{"callback_query": {"id": "1", "from": {"id": 20}, "inline_message_id": "inline"}}`,
+			kind:   tgbot.UpdateKindCallbackQuery, userID: 20,
+		},
+		{
+			name:   "reaction",
+			update: `{"message_reaction": {"chat": {"id": 10}, "message_id": 1, "date": 1, "actor_chat": {"id": -5}}}`,
+			kind:   tgbot.UpdateKindMessageReaction, chatID: 10, senderChatID: -5,
+		},
+		{
+			name:   "inline query",
+			update: `{"inline_query": {"id": "1", "from": {"id": 20}, "query": "q"}}`,
+			kind:   tgbot.UpdateKindInlineQuery, userID: 20,
+		},
+		{
+			name:   "chat boost",
+			update: `{"chat_boost": {"chat": {"id": -100}, "boost": {"boost_id": "b", "add_date": 1, "expiration_date": 2, "source": {"source": "premium", "user": {"id": 20}}}}}`,
+			kind:   tgbot.UpdateKindChatBoost, chatID: -100, userID: 20,
+		},
+		{
+			name:   "removed chat boost",
+			update: `{"removed_chat_boost": {"chat": {"id": -100}, "boost_id": "b", "remove_date": 1, "source": {"source": "gift_code", "user": {"id": 20}}}}`,
+			kind:   tgbot.UpdateKindRemovedChatBoost, chatID: -100, userID: 20,
+		},
+		{
+			name:   "unclaimed giveaway boost",
+			update: `{"chat_boost": {"chat": {"id": -100}, "boost": {"boost_id": "b", "add_date": 1, "expiration_date": 2, "source": {"source": "giveaway", "giveaway_message_id": 5, "is_unclaimed": true}}}}`,
+			kind:   tgbot.UpdateKindChatBoost, chatID: -100,
+		},
+		{
+			name:   "unknown",
+			update: `{"update_id": 1, "future_update": {}}`,
+			kind:   tgbot.UpdateKindUnknown,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			update := &tgbot.Update{}
+			if err := json.Unmarshal([]byte(tc.update), update); err != nil {
+				t.Fatal(err)
+			}
+			if update.Kind() != tc.kind {
+				t.Errorf("Kind() = %s, want %s", update.Kind(), tc.kind)
+			}
+			if chat := update.EffectiveChat(); (chat == nil && tc.chatID != 0) || (chat != nil && chat.ID != tc.chatID) {
+				t.Errorf("EffectiveChat() = %+v, want %d", chat, tc.chatID)
+			}
+			if user := update.EffectiveUser(); (user == nil && tc.userID != 0) || (user != nil && user.ID != tc.userID) {
+				t.Errorf("EffectiveUser() = %+v, want %d", user, tc.userID)
+			}
+			if chat := update.EffectiveSenderChat(); (chat == nil && tc.senderChatID != 0) || (chat != nil && chat.ID != tc.senderChatID) {
+				t.Errorf("EffectiveSenderChat() = %+v, want %d", chat, tc.senderChatID)
+			}
+			message := update.EffectiveMessage()
+			if (message == nil && tc.messageID != 0) || (message != nil && message.MessageID != tc.messageID) {
+				t.Errorf("EffectiveMessage() = %+v, want %d", message, tc.messageID)
+			}
+		})
+	}
+}
+
+func TestMaybeInaccessibleMessage(t *testing.T) {
+	update := &tgbot.Update{}
+	if err := json.Unmarshal([]byte(`{"callback_query": {"id": "1", "message": {
+		"message_id": 3, "date": 1, "chat": {"id": 10},
+		"pinned_message": {"message_id": 1, "date": 0, "chat": {"id": 10}}}}}`), update); err != nil {
+		t.Fatal(err)
+	}
+	message, ok := update.CallbackQuery.Message.(*tgbot.Message)
+	if !ok {
+		t.Fatalf("Callback message is %T, want *tgbot.Message", update.CallbackQuery.Message)
+	}
+	// The message is returned as is, not a copy
+	if update.EffectiveMessage() != message {
+		t.Errorf("EffectiveMessage() is not the callback message")
+	}
+	if pinned, ok := message.PinnedMessage.(*tgbot.InaccessibleMessage); !ok || pinned.MessageID != 1 {
+		t.Errorf("Pinned message is %+v, want an inaccessible message", message.PinnedMessage)
+	}
+
+	// The inaccessible message keeps its zero date out of the json
+	data, err := json.Marshal(update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded := &tgbot.Update{}
+	if err := json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("Cannot unmarshal %s: %s", data, err)
+	}
+	if _, ok := decoded.CallbackQuery.Message.(*tgbot.Message).PinnedMessage.(*tgbot.InaccessibleMessage); !ok {
+		t.Errorf("Pinned message is not inaccessible after a round trip: %s", data)
+	}
+}
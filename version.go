@@ -0,0 +1,59 @@
+package tgbot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseAPIVersion parses "7.0" or "Bot API 7.0" into major and minor numbers.
+func parseAPIVersion(version string) (int, int, error) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "Bot API ")
+	majorText, minorText, _ := strings.Cut(version, ".")
+	major, err := strconv.Atoi(majorText)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Cannot parse Bot API version %q: %s", version, err)
+	}
+	minor := 0
+	if minorText != "" {
+		if minor, err = strconv.Atoi(minorText); err != nil {
+			return 0, 0, fmt.Errorf("Cannot parse Bot API version %q: %s", version, err)
+		}
+	}
+	return major, minor, nil
+}
+
+// CompareAPIVersions returns -1, 0 or 1 if the version a is older, the same or newer than b.
+func CompareAPIVersions(a string, b string) (int, error) {
+	aMajor, aMinor, err := parseAPIVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	bMajor, bMinor, err := parseAPIVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case aMajor < bMajor, aMajor == bMajor && aMinor < bMinor:
+		return -1, nil
+	case aMajor == bMajor && aMinor == bMinor:
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// CheckAPIVersion fails if the Bot API server is older than APIVersion, so some of the generated
+// methods and fields are unknown to it. The Bot API has no method that returns the server
+// version, so the check is only as good as the version passed in: for a local telegram-bot-api
+// server it is printed by "telegram-bot-api --version".
+func CheckAPIVersion(serverVersion string) error {
+	cmp, err := CompareAPIVersions(serverVersion, APIVersion)
+	if err != nil {
+		return err
+	}
+	if cmp < 0 {
+		return fmt.Errorf("Bot API server supports version %s, but the types are generated for %s (%s)",
+			serverVersion, APIVersion, APIDate)
+	}
+	return nil
+}
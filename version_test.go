@@ -0,0 +1,43 @@
+package tgbot_test
+
+import (
+	"testing"
+
+	"github.com/lanseg/tgbot"
+)
+
+func TestCompareAPIVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"7.0", "7.0", 0},
+		{"Bot API 7.0", "7", 0},
+		{"6.9", "7.0", -1},
+		{"7.10", "7.9", 1},
+		{"8.0", "7.10", 1},
+	}
+	for _, tc := range tests {
+		got, err := tgbot.CompareAPIVersions(tc.a, tc.b)
+		if err != nil || got != tc.want {
+			t.Errorf("CompareAPIVersions(%q, %q) = %d, %v, want %d", tc.a, tc.b, got, err, tc.want)
+		}
+	}
+	for _, version := range []string{"", "seven", "7.x"} {
+		if _, err := tgbot.CompareAPIVersions(version, "7.0"); err == nil {
+			t.Errorf("CompareAPIVersions(%q) succeeded", version)
+		}
+	}
+}
+
+func TestCheckAPIVersion(t *testing.T) {
+	if err := tgbot.CheckAPIVersion(tgbot.APIVersion); err != nil {
+		t.Errorf("CheckAPIVersion(APIVersion) failed: %s", err)
+	}
+	if err := tgbot.CheckAPIVersion("99.0"); err != nil {
+		t.Errorf("CheckAPIVersion of a newer server failed: %s", err)
+	}
+	if err := tgbot.CheckAPIVersion("6.9"); err == nil {
+		t.Errorf("CheckAPIVersion of an older server succeeded")
+	}
+}
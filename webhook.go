@@ -0,0 +1,80 @@
+package tgbot
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+)
+
+// SecretTokenHeader carries the secret_token of setWebhook in every webhook request.
+const SecretTokenHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+// Webhook is an http.Handler that receives the updates sent by Telegram after setWebhook.
+// Telegram repeats the request if it fails or times out, the repeated updates that were already
+// acknowledged are skipped.
+type Webhook struct {
+	handler UpdateHandler
+	acker   *UpdateAcker
+
+	// SecretToken is the secret_token of setWebhook, requests without it are rejected
+	SecretToken string
+	// Logger reports the rejected requests, slog.Default() if nil
+	Logger *slog.Logger
+	// Executor runs the handler in its pool of workers, nil to run it in the request. The request
+	// waits for the handler, an update that cannot be submitted is answered with an error and is
+	// not acknowledged, so Telegram sends it again.
+	Executor *Executor
+}
+
+// NewWebhook loads the handled updates from the store, NewMemoryAckStore() remembers them
+// until restart.
+func NewWebhook(ctx context.Context, handler UpdateHandler, store AckStore, mode AckMode) (*Webhook, error) {
+	acker, err := NewUpdateAcker(ctx, store, mode, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &Webhook{handler: handler, acker: acker}, nil
+}
+
+func (w *Webhook) logger() *slog.Logger {
+	if w.Logger != nil {
+		return w.Logger
+	}
+	return slog.Default()
+}
+
+func (w *Webhook) handle(ctx context.Context, update *Update) error {
+	if w.Executor != nil {
+		return w.Executor.HandlerErr(w.handler)(ctx, update)
+	}
+	w.handler(ctx, update)
+	return nil
+}
+
+func (w *Webhook) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.Method != http.MethodPost {
+		http.Error(writer, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	secret := request.Header.Get(SecretTokenHeader)
+	if w.SecretToken != "" && subtle.ConstantTimeCompare([]byte(secret), []byte(w.SecretToken)) != 1 {
+		w.logger().WarnContext(request.Context(), "Webhook request with a wrong secret token",
+			slog.String("remote", request.RemoteAddr))
+		http.Error(writer, "Forbidden", http.StatusForbidden)
+		return
+	}
+	update := &Update{}
+	if err := json.NewDecoder(request.Body).Decode(update); err != nil {
+		http.Error(writer, "Cannot parse update", http.StatusBadRequest)
+		return
+	}
+	if _, err := w.acker.handle(request.Context(), update, w.handle); err != nil {
+		w.logger().ErrorContext(request.Context(), "Cannot handle update",
+			slog.Int64("update_id", update.UpdateID), slog.String("error", err.Error()))
+		http.Error(writer, "Cannot handle update", http.StatusInternalServerError)
+		return
+	}
+	writer.WriteHeader(http.StatusOK)
+}
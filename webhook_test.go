@@ -0,0 +1,111 @@
+package tgbot_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lanseg/tgbot"
+)
+
+func postUpdate(webhook http.Handler, body string, secret string) int {
+	request := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	if secret != "" {
+		request.Header.Set(tgbot.SecretTokenHeader, secret)
+	}
+	recorder := httptest.NewRecorder()
+	webhook.ServeHTTP(recorder, request)
+	return recorder.Code
+}
+
+func TestWebhook(t *testing.T) {
+	var texts []string
+	webhook, err := tgbot.NewWebhook(context.Background(), func(_ context.Context, update *tgbot.Update) {
+		texts = append(texts, update.Message.Text)
+	}, tgbot.NewMemoryAckStore(), tgbot.AckAfterHandling)
+	if err != nil {
+		t.Fatal(err)
+	}
+	webhook.SecretToken = "secret"
+
+	update := `{"update_id": 1, "message": {"message_id": 1, "date": 0, "chat": {"id": 1, "type": "private"}, "text": "hi"}}`
+	for _, tc := range []struct {
+		name   string
+		body   string
+		secret string
+		want   int
+	}{
+		{name: "no secret", body: update, want: http.StatusForbidden},
+		{name: "update", body: update, secret: "secret", want: http.StatusOK},
+		{name: "retry", body: update, secret: "secret", want: http.StatusOK},
+		{name: "broken json", body: "{", secret: "secret", want: http.StatusBadRequest},
+	} {
+		if code := postUpdate(webhook, tc.body, tc.secret); code != tc.want {
+			t.Errorf("%s: got status %d, want %d", tc.name, code, tc.want)
+		}
+	}
+	if len(texts) != 1 || texts[0] != "hi" {
+		t.Errorf("Handler got %q, want the update once", texts)
+	}
+}
+
+func TestWebhookConcurrentRetries(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	webhook, err := tgbot.NewWebhook(context.Background(), func(context.Context, *tgbot.Update) {
+		calls.Add(1)
+		<-release
+	}, tgbot.NewMemoryAckStore(), tgbot.AckAfterHandling)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Telegram retries the request while the first one is still being handled.
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if code := postUpdate(webhook, `{"update_id": 5}`, ""); code != http.StatusOK {
+				t.Errorf("Got status %d", code)
+			}
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if calls.Load() != 1 {
+		t.Errorf("Handler is called %d times for the same update", calls.Load())
+	}
+}
+
+func TestWebhookExecutorClosed(t *testing.T) {
+	var calls atomic.Int32
+	webhook, err := tgbot.NewWebhook(context.Background(), func(context.Context, *tgbot.Update) {
+		calls.Add(1)
+	}, tgbot.NewMemoryAckStore(), tgbot.AckAfterHandling)
+	if err != nil {
+		t.Fatal(err)
+	}
+	webhook.Logger = quietLogger
+	webhook.Executor = tgbot.NewExecutor(1, 0, nil, quietLogger)
+	webhook.Executor.Close()
+
+	if code := postUpdate(webhook, `{"update_id": 7}`, ""); code != http.StatusInternalServerError {
+		t.Errorf("Got status %d for the update that is not submitted, want 500", code)
+	}
+	// Telegram sends the update again, it was not acknowledged.
+	webhook.Executor = tgbot.NewExecutor(1, 0, nil, quietLogger)
+	defer webhook.Executor.Close()
+	if code := postUpdate(webhook, `{"update_id": 7}`, ""); code != http.StatusOK {
+		t.Errorf("Got status %d for the retry, want 200", code)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("Handler is called %d times, want once on the retry", calls.Load())
+	}
+}